@@ -2,20 +2,23 @@ module github.com/siegfried/2020rule
 
 go 1.25.1
 
+require (
+	github.com/caseymrm/menuet v1.0.1
+	github.com/lextoumbourou/idle v0.0.0-20211129071637-69c91a94f74b
+	github.com/progrium/darwinkit v0.5.0
+	modernc.org/sqlite v1.44.0
+)
+
 require (
 	github.com/caseymrm/askm v1.0.0 // indirect
-	github.com/caseymrm/menuet v1.0.1 // indirect
 	github.com/dustin/go-humanize v1.0.1 // indirect
 	github.com/google/uuid v1.6.0 // indirect
-	github.com/lextoumbourou/idle v0.0.0-20211129071637-69c91a94f74b // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/ncruces/go-strftime v1.0.0 // indirect
-	github.com/progrium/darwinkit v0.5.0 // indirect
 	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
 	golang.org/x/exp v0.0.0-20251023183803-a4bb9ffd2546 // indirect
 	golang.org/x/sys v0.37.0 // indirect
 	modernc.org/libc v1.67.4 // indirect
 	modernc.org/mathutil v1.7.1 // indirect
 	modernc.org/memory v1.11.0 // indirect
-	modernc.org/sqlite v1.44.0 // indirect
 )
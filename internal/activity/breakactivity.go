@@ -0,0 +1,145 @@
+package activity
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lextoumbourou/idle"
+)
+
+const (
+	// breakSampleInterval is how often the idle time is read during a break
+	breakSampleInterval = 1 * time.Second
+	// minActiveShare is the share of samples with input for a break to count
+	// as worked through
+	minActiveShare = 0.9
+)
+
+// BreakActivity is what the input during a break says about it
+type BreakActivity int
+
+const (
+	// BreakActivityMixed means the input doesn't tell whether the user rested
+	BreakActivityMixed BreakActivity = iota
+	// BreakActivityResting means there was no input during the whole break
+	BreakActivityResting
+	// BreakActivityActive means the user kept typing or moving the mouse
+	BreakActivityActive
+)
+
+// String returns a human-readable break activity name
+func (a BreakActivity) String() string {
+	switch a {
+	case BreakActivityResting:
+		return "resting"
+	case BreakActivityActive:
+		return "active"
+	default:
+		return "mixed"
+	}
+}
+
+// classifyBreakActivity judges a break from the system idle times read every
+// interval while it lasted. A sample with an idle time below interval saw
+// input since the one before. The first sample is left out since the user
+// may still be finishing a keystroke when the break starts. Without enough
+// samples the activity is mixed.
+func classifyBreakActivity(samples []time.Duration, interval time.Duration) BreakActivity {
+	if len(samples) < 2 {
+		return BreakActivityMixed
+	}
+
+	judged := samples[1:]
+	active := 0
+	for _, idleFor := range judged {
+		if idleFor < interval {
+			active++
+		}
+	}
+
+	switch {
+	case active == 0:
+		return BreakActivityResting
+	case float64(active)/float64(len(judged)) >= minActiveShare:
+		return BreakActivityActive
+	default:
+		return BreakActivityMixed
+	}
+}
+
+// BreakObserver samples the system idle time during a break to infer
+// whether the user rested or worked through it
+type BreakObserver struct {
+	idleTime func() (time.Duration, error)
+	current  *observation
+	mu       sync.Mutex
+}
+
+// observation holds the samples taken during one break
+type observation struct {
+	stop    chan struct{}
+	samples []time.Duration
+	mu      sync.Mutex
+}
+
+// NewBreakObserver creates a break observer reading the system idle time
+func NewBreakObserver() *BreakObserver {
+	return &BreakObserver{idleTime: idle.Get}
+}
+
+// Begin starts sampling for a new break, discarding a previous break that
+// was never ended
+func (o *BreakObserver) Begin() {
+	obs := &observation{stop: make(chan struct{})}
+
+	o.mu.Lock()
+	previous := o.current
+	o.current = obs
+	o.mu.Unlock()
+
+	if previous != nil {
+		close(previous.stop)
+	}
+	go o.sample(obs)
+}
+
+// End stops sampling and returns what the input during the break says
+// about it. Without a break in progress the activity is mixed.
+func (o *BreakObserver) End() BreakActivity {
+	o.mu.Lock()
+	obs := o.current
+	o.current = nil
+	o.mu.Unlock()
+
+	if obs == nil {
+		return BreakActivityMixed
+	}
+	close(obs.stop)
+
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	return classifyBreakActivity(obs.samples, breakSampleInterval)
+}
+
+// sample reads the idle time every breakSampleInterval until the
+// observation stops. Failed reads are left out.
+func (o *BreakObserver) sample(obs *observation) {
+	ticker := time.NewTicker(breakSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			idleFor, err := o.idleTime()
+			if err != nil {
+				continue
+			}
+			obs.mu.Lock()
+			obs.samples = append(obs.samples, idleFor)
+			obs.mu.Unlock()
+
+		case <-obs.stop:
+			return
+		}
+	}
+}
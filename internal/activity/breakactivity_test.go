@@ -0,0 +1,34 @@
+package activity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifyBreakActivity(t *testing.T) {
+	const s = time.Second
+	const interval = breakSampleInterval
+
+	tests := []struct {
+		name    string
+		samples []time.Duration
+		want    BreakActivity
+	}{
+		{"no samples", nil, BreakActivityMixed},
+		{"only the first sample", []time.Duration{0}, BreakActivityMixed},
+		{"no input", []time.Duration{0, 2 * s, 3 * s, 4 * s}, BreakActivityResting},
+		{"input at the start is left out", []time.Duration{0, 1 * s, 2 * s, 3 * s}, BreakActivityResting},
+		{"typing throughout", []time.Duration{0, 0, 100 * time.Millisecond, 0, 500 * time.Millisecond}, BreakActivityActive},
+		{"exactly the active share", []time.Duration{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 5 * s}, BreakActivityActive},
+		{"just below the active share", []time.Duration{0, 0, 0, 0, 0, 0, 0, 0, 0, 4 * s, 5 * s}, BreakActivityMixed},
+		{"some input", []time.Duration{0, 2 * s, 0, 1 * s, 2 * s}, BreakActivityMixed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyBreakActivity(tt.samples, interval); got != tt.want {
+				t.Errorf("classifyBreakActivity(%v) = %s, want %s", tt.samples, got, tt.want)
+			}
+		})
+	}
+}
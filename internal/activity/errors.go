@@ -0,0 +1,7 @@
+package activity
+
+import "errors"
+
+// ErrIdleUnavailable is returned by Monitor.Start when the system doesn't
+// report the idle time
+var ErrIdleUnavailable = errors.New("idle time is unavailable")
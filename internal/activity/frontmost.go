@@ -0,0 +1,85 @@
+package activity
+
+/*
+#cgo LDFLAGS: -framework CoreGraphics -framework CoreFoundation
+#include <stdbool.h>
+#include <CoreGraphics/CoreGraphics.h>
+
+// Reports whether the process owns a normal window covering a whole display
+static bool hasFullscreenWindow(int pid) {
+	CFArrayRef windows = CGWindowListCopyWindowInfo(
+		kCGWindowListOptionOnScreenOnly | kCGWindowListExcludeDesktopElements,
+		kCGNullWindowID);
+	if (windows == NULL) {
+		return false;
+	}
+
+	uint32_t displayCount = 0;
+	CGDirectDisplayID displays[16];
+	CGGetActiveDisplayList(16, displays, &displayCount);
+
+	bool fullscreen = false;
+	CFIndex count = CFArrayGetCount(windows);
+	for (CFIndex i = 0; i < count && !fullscreen; i++) {
+		CFDictionaryRef window = CFArrayGetValueAtIndex(windows, i);
+
+		int ownerPID = 0;
+		CFNumberRef owner = CFDictionaryGetValue(window, kCGWindowOwnerPID);
+		if (owner == NULL || !CFNumberGetValue(owner, kCFNumberIntType, &ownerPID) || ownerPID != pid) {
+			continue;
+		}
+
+		int layer = -1;
+		CFNumberRef layerRef = CFDictionaryGetValue(window, kCGWindowLayer);
+		if (layerRef == NULL || !CFNumberGetValue(layerRef, kCFNumberIntType, &layer) || layer != 0) {
+			continue;
+		}
+
+		CGRect bounds;
+		CFDictionaryRef boundsRef = CFDictionaryGetValue(window, kCGWindowBounds);
+		if (boundsRef == NULL || !CGRectMakeWithDictionaryRepresentation(boundsRef, &bounds)) {
+			continue;
+		}
+
+		for (uint32_t d = 0; d < displayCount; d++) {
+			if (CGRectEqualToRect(bounds, CGDisplayBounds(displays[d]))) {
+				fullscreen = true;
+				break;
+			}
+		}
+	}
+
+	CFRelease(windows);
+	return fullscreen;
+}
+*/
+import "C"
+
+import (
+	"github.com/progrium/darwinkit/macos/appkit"
+)
+
+// AppSource reports which app the user is currently working in
+type AppSource interface {
+	// FrontmostApp returns the bundle ID of the frontmost app and whether
+	// it covers a whole display
+	FrontmostApp() (bundleID string, fullscreen bool)
+}
+
+// workspaceAppSource reads the frontmost app from NSWorkspace
+type workspaceAppSource struct{}
+
+// NewWorkspaceAppSource creates an AppSource backed by NSWorkspace
+func NewWorkspaceAppSource() AppSource {
+	return workspaceAppSource{}
+}
+
+// FrontmostApp returns the bundle ID of the frontmost app and whether it is fullscreen
+func (workspaceAppSource) FrontmostApp() (string, bool) {
+	app := appkit.Workspace_SharedWorkspace().FrontmostApplication()
+	if app.IsNil() {
+		return "", false
+	}
+	pid := app.ProcessIdentifier()
+	return app.BundleIdentifier(), bool(C.hasFullscreenWindow(C.int(pid)))
+}
@@ -1,6 +1,7 @@
 package activity
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ type Monitor struct {
 	onBecameActive  func()
 	mu              sync.Mutex
 	running         bool
+	enabled         bool
 }
 
 // NewMonitor creates a new activity monitor
@@ -28,22 +30,30 @@ func NewMonitor(cfg *config.Config) *Monitor {
 		pollInterval: 10 * time.Second, // Poll every 10 seconds
 		stopChan:     make(chan struct{}),
 		isIdle:       false,
+		enabled:      true,
 	}
 }
 
-// Start begins monitoring user activity
-func (m *Monitor) Start() {
+// Start begins monitoring user activity. It returns ErrIdleUnavailable
+// without starting if the idle time can't be read; the user is then never
+// reported idle.
+func (m *Monitor) Start() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if m.running {
-		return
+		return nil
+	}
+
+	if _, err := idle.Get(); err != nil {
+		return fmt.Errorf("%w: %v", ErrIdleUnavailable, err)
 	}
 
 	m.running = true
 	m.ticker = time.NewTicker(m.pollInterval)
 
 	go m.monitorLoop()
+	return nil
 }
 
 // Stop stops monitoring user activity
@@ -71,6 +81,24 @@ func (m *Monitor) IsIdle() bool {
 	return m.isIdle
 }
 
+// IdleFor reads how long the user has been idle right now, without waiting
+// for the next poll. It is 0 while the monitor is stopped or disabled and
+// if the idle time can't be read.
+func (m *Monitor) IdleFor() time.Duration {
+	m.mu.Lock()
+	active := m.running && m.enabled
+	m.mu.Unlock()
+	if !active {
+		return 0
+	}
+
+	idleDuration, err := idle.Get()
+	if err != nil {
+		return 0
+	}
+	return idleDuration
+}
+
 // SetOnBecameIdle sets the callback for when the user becomes idle
 func (m *Monitor) SetOnBecameIdle(callback func()) {
 	m.mu.Lock()
@@ -85,6 +113,20 @@ func (m *Monitor) SetOnBecameActive(callback func()) {
 	m.onBecameActive = callback
 }
 
+// SetEnabled turns idle detection on or off without stopping the monitor.
+// While disabled the user is never reported idle; disabling while idle
+// reports the user active again so an auto-paused timer resumes.
+func (m *Monitor) SetEnabled(enabled bool) {
+	m.mu.Lock()
+	m.enabled = enabled
+	wasIdle := m.isIdle
+	m.mu.Unlock()
+
+	if !enabled && wasIdle {
+		m.setActive()
+	}
+}
+
 // UpdateConfig updates the configuration
 func (m *Monitor) UpdateConfig(cfg *config.Config) {
 	m.mu.Lock()
@@ -104,8 +146,16 @@ func (m *Monitor) monitorLoop() {
 	}
 }
 
-// checkIdleStatus checks the current idle time and updates state
+// checkIdleStatus checks the current idle time and updates state. It does
+// nothing while the monitor is disabled.
 func (m *Monitor) checkIdleStatus() {
+	m.mu.Lock()
+	enabled := m.enabled
+	m.mu.Unlock()
+	if !enabled {
+		return
+	}
+
 	idleDuration, err := idle.Get()
 	if err != nil {
 		// If we can't get idle time, assume active
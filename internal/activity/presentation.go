@@ -0,0 +1,26 @@
+package activity
+
+// presentationApps lists the bundle IDs of apps that present fullscreen
+var presentationApps = map[string]bool{
+	"com.apple.iWork.Keynote":  true,
+	"com.microsoft.Powerpoint": true,
+	"us.zoom.xos":              true,
+}
+
+// PresentationDetector reports whether the user is giving a presentation
+type PresentationDetector struct {
+	source AppSource
+}
+
+// NewPresentationDetector creates a detector based on the frontmost app
+func NewPresentationDetector(source AppSource) *PresentationDetector {
+	return &PresentationDetector{
+		source: source,
+	}
+}
+
+// IsPresenting returns true while a known presentation app is fullscreen
+func (d *PresentationDetector) IsPresenting() bool {
+	bundleID, fullscreen := d.source.FrontmostApp()
+	return fullscreen && presentationApps[bundleID]
+}
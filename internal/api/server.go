@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/timer"
+)
+
+// maxBodyBytes limits the size of request bodies
+const maxBodyBytes = 64 << 10
+
+// TimerStatus reports the state of the break timer
+type TimerStatus interface {
+	GetState() timer.State
+	IsBreakDue() bool
+	GetProgress() float64
+	GetTimeUntilBreak() time.Duration
+}
+
+// Server exposes a small HTTP API on localhost for scripting the app
+type Server struct {
+	addr            string
+	configManager   *config.Manager
+	timer           TimerStatus
+	httpServer      *http.Server
+	onConfigUpdated func(*config.Config)
+	mu              sync.Mutex
+}
+
+// NewServer creates a new API server listening on addr (e.g. "127.0.0.1:7620")
+func NewServer(addr string, cm *config.Manager) *Server {
+	return &Server{
+		addr:          addr,
+		configManager: cm,
+	}
+}
+
+// SetOnConfigUpdated sets the callback for when the config was changed via the API
+func (s *Server) SetOnConfigUpdated(callback func(*config.Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onConfigUpdated = callback
+}
+
+// SetTimer sets the timer whose status is served at GET /timer
+func (s *Server) SetTimer(t TimerStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timer = t
+}
+
+// Handler returns the HTTP handler serving all API routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /config", s.handleGetConfig)
+	mux.HandleFunc("PATCH /config", s.handlePatchConfig)
+	mux.HandleFunc("GET /timer", s.handleGetTimer)
+	return requireLoopbackHost(mux)
+}
+
+// requireLoopbackHost rejects requests not addressed to a loopback host, so
+// web pages can't reach the API through DNS rebinding
+func requireLoopbackHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLoopbackHost(r.Host) {
+			writeError(w, http.StatusForbidden, errors.New("host not allowed"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost returns whether the Host header names localhost or a
+// loopback address, with or without a port
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Start begins serving requests in the background
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.httpServer = &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	httpServer := s.httpServer
+	s.mu.Unlock()
+
+	go func() {
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Warning: API server stopped: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop shuts the server down, waiting briefly for open requests
+func (s *Server) Stop() error {
+	s.mu.Lock()
+	httpServer := s.httpServer
+	s.httpServer = nil
+	s.mu.Unlock()
+
+	if httpServer == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return httpServer.Shutdown(ctx)
+}
+
+// handleGetConfig returns the running configuration
+func (s *Server) handleGetConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.configManager.Get().ToJSONMap())
+}
+
+// handlePatchConfig applies a partial update to the running configuration
+func (s *Server) handlePatchConfig(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cfg := s.configManager.Get().Clone()
+	if err := cfg.Patch(body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.configManager.Update(cfg); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	cfg = s.configManager.Get()
+
+	s.mu.Lock()
+	callback := s.onConfigUpdated
+	s.mu.Unlock()
+	if callback != nil {
+		callback(cfg)
+	}
+
+	writeJSON(w, http.StatusOK, cfg.ToJSONMap())
+}
+
+// handleGetTimer returns whether a break is due and how far the current
+// work interval has progressed
+func (s *Server) handleGetTimer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	t := s.timer
+	s.mu.Unlock()
+	if t == nil {
+		writeError(w, http.StatusServiceUnavailable, errors.New("timer not available"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"state":               t.GetState().String(),
+		"break_due":           t.IsBreakDue(),
+		"progress":            t.GetProgress(),
+		"seconds_until_break": int(t.GetTimeUntilBreak().Seconds()),
+	})
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Warning: failed to write API response: %v", err)
+	}
+}
+
+// writeError writes err as a JSON error body
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
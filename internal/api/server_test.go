@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// newTestServer creates a server whose config lives in a temporary data
+// directory
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	t.Setenv("TWENTY_RULE_DATA_DIR", t.TempDir())
+
+	cm, err := config.NewManager()
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return NewServer("127.0.0.1:0", cm)
+}
+
+// serve sends a request addressed to the API's loopback address and
+// returns the response status and decoded JSON body
+func serve(t *testing.T, s *Server, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, r)
+	req.Host = "127.0.0.1:7620"
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("response is not a JSON object: %q", rec.Body.String())
+	}
+	return rec.Code, decoded
+}
+
+func TestGetConfig(t *testing.T) {
+	s := newTestServer(t)
+
+	status, body := serve(t, s, http.MethodGet, "/config", "")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+	if got := body["work_duration_minutes"]; got != 20.0 {
+		t.Errorf("work_duration_minutes = %v, want 20", got)
+	}
+	if _, ok := body["cadence_schedule"]; !ok {
+		t.Error("config has no cadence_schedule")
+	}
+}
+
+func TestPatchConfig(t *testing.T) {
+	s := newTestServer(t)
+	var updated *config.Config
+	s.SetOnConfigUpdated(func(cfg *config.Config) { updated = cfg })
+
+	status, body := serve(t, s, http.MethodPatch, "/config", `{
+		"work_duration_minutes": 25,
+		"cadence_schedule": [{"start_hour": 9, "end_hour": 12, "work_duration_minutes": 30}],
+		"overlay_themes": {"recovery": {"background": "#203040", "message": "Stretch"}},
+		"per_app_work_duration_minutes": {"com.apple.Safari": 15}
+	}`)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %v", status, http.StatusOK, body)
+	}
+	if got := body["work_duration_minutes"]; got != 25.0 {
+		t.Errorf("response work_duration_minutes = %v, want 25", got)
+	}
+
+	cfg := s.configManager.Get()
+	if cfg.WorkDuration != 25*time.Minute {
+		t.Errorf("work duration = %s, want 25m", cfg.WorkDuration)
+	}
+	if len(cfg.CadenceSchedule) != 1 || cfg.CadenceSchedule[0].WorkDuration != 30*time.Minute {
+		t.Errorf("cadence schedule = %+v, want one 30 minute window", cfg.CadenceSchedule)
+	}
+	if cfg.OverlayThemes["recovery"].Message != "Stretch" {
+		t.Errorf("overlay themes = %+v, want a recovery theme", cfg.OverlayThemes)
+	}
+	if cfg.PerAppWorkDuration["com.apple.Safari"] != 15*time.Minute {
+		t.Errorf("per app work durations = %v, want 15m for Safari", cfg.PerAppWorkDuration)
+	}
+	if updated == nil || updated.WorkDuration != 25*time.Minute {
+		t.Error("config update callback didn't get the new config")
+	}
+}
+
+func TestPatchConfigRejectsInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{"malformed JSON", `{"work_duration_minutes": `},
+		{"unknown key", `{"work_minutes": 25}`},
+		{"wrong type", `{"work_duration_minutes": "25"}`},
+		{"invalid value", `{"overlay_opacity": 2}`},
+		{"unknown key in a cadence window", `{"cadence_schedule": [{"start_hour": 9, "end_hour": 12, "work_duration_minutes": 30, "note": "mornings"}]}`},
+		{"wrong type in a cadence window", `{"cadence_schedule": [{"start_hour": "9", "end_hour": 12, "work_duration_minutes": 30}]}`},
+		{"cadence window that is no object", `{"cadence_schedule": [9]}`},
+		{"unknown key in an overlay theme", `{"overlay_themes": {"recovery": {"colour": "#203040"}}}`},
+		{"unknown breathing phase", `{"breathing_pacing_seconds": {"inhale": 4, "hold": 4}}`},
+		{"per app duration that is no number", `{"per_app_work_duration_minutes": {"com.apple.Safari": "15"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			before := s.configManager.Get().ToJSONMap()
+
+			status, body := serve(t, s, http.MethodPatch, "/config", tt.patch)
+			if status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+			if _, ok := body["error"]; !ok {
+				t.Errorf("body %v has no error", body)
+			}
+			if after := s.configManager.Get().ToJSONMap(); !reflect.DeepEqual(after, before) {
+				t.Error("rejected patch changed the config")
+			}
+		})
+	}
+}
+
+func TestPatchConfigRejectsFileOnlyFields(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{"on_break_start_command", "touch /tmp/pwned"},
+		{"on_break_end_command", "touch /tmp/pwned"},
+		{"event_log_path", "/tmp/events.log"},
+		{"overlay_messages_file", "/etc/passwd"},
+		{"webhook_url", "https://attacker.example/hook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			s := newTestServer(t)
+			before := s.configManager.Get().ToJSONMap()
+
+			status, body := serve(t, s, http.MethodPatch, "/config", `{"`+tt.key+`": "`+tt.value+`"}`)
+			if status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+			if _, ok := body["error"]; !ok {
+				t.Errorf("body %v has no error", body)
+			}
+			if after := s.configManager.Get().ToJSONMap(); !reflect.DeepEqual(after, before) {
+				t.Errorf("%s was changed", tt.key)
+			}
+		})
+	}
+}
+
+func TestRequestsMustAddressLoopback(t *testing.T) {
+	tests := []struct {
+		host string
+		want int
+	}{
+		{"127.0.0.1:7620", http.StatusOK},
+		{"localhost:7620", http.StatusOK},
+		{"[::1]:7620", http.StatusOK},
+		{"localhost", http.StatusOK},
+		{"evil.example.com:7620", http.StatusForbidden},
+		{"192.168.1.2:7620", http.StatusForbidden},
+		{"", http.StatusForbidden},
+	}
+
+	s := newTestServer(t)
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/config", nil)
+			req.Host = tt.host
+
+			rec := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -4,63 +4,209 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/caseymrm/menuet"
 	"github.com/siegfried/2020rule/internal/activity"
+	"github.com/siegfried/2020rule/internal/api"
+	"github.com/siegfried/2020rule/internal/brightness"
 	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/eventlog"
+	"github.com/siegfried/2020rule/internal/gaze"
+	"github.com/siegfried/2020rule/internal/hook"
+	"github.com/siegfried/2020rule/internal/hotkey"
+	"github.com/siegfried/2020rule/internal/instance"
+	"github.com/siegfried/2020rule/internal/notify"
 	"github.com/siegfried/2020rule/internal/overlay"
+	"github.com/siegfried/2020rule/internal/permissions"
+	"github.com/siegfried/2020rule/internal/power"
+	"github.com/siegfried/2020rule/internal/sound"
 	"github.com/siegfried/2020rule/internal/stats"
 	"github.com/siegfried/2020rule/internal/timer"
 	"github.com/siegfried/2020rule/internal/ui"
+	"github.com/siegfried/2020rule/internal/webhook"
 )
 
+// breakExtension is how much a break is lengthened per request
+const breakExtension = 10 * time.Second
+
+// breakPostponement is how far a break is moved by clicking the overlay
+const breakPostponement = 5 * time.Minute
+
+// rebuildStatsDays is how many days back "Statistiken neu berechnen" goes
+const rebuildStatsDays = 90
+
 // App is the main application coordinator
 type App struct {
 	configManager   *config.Manager
 	statsStore      *stats.Store
+	dayRollover     *stats.DayRollover
 	timerManager    *timer.Manager
 	activityMonitor *activity.Monitor
+	powerMonitor    *power.Monitor
+	soundPlayer     *sound.Player
 	overlayWindow   *overlay.Window
 	menuBar         *ui.MenuBar
+	apiServer       *api.Server
+	instanceLock    *instance.Lock
+	pauseHotkey     *hotkey.Listener
+	gazeVerifier    *gaze.Verifier
+	breakObserver   *activity.BreakObserver
+	brightness      *brightness.Dimmer
+	restGoalDay     string // Day the rest goal was last reported reached, YYYY-MM-DD
+	restGoalMu      sync.Mutex
+	eventLog        *eventlog.Logger
+	eventLogMu      sync.Mutex
+	webhook         *webhook.Sender
+	webhookMu       sync.Mutex
+	hooks           *hook.Executor
+	permissions     permissions.Checker
+	reviewTicker    *time.Ticker
+	reviewStop      chan struct{}
 	sessionID       int64
+	pauseID         atomic.Int64 // Open pause record, 0 while not paused
+	currentBreakID  atomic.Int64
+	breakNotifiedAt atomic.Int64 // Unix nanoseconds, notification break style only
+	notifications   *notify.Limiter
+	completedBreaks atomic.Int64
 }
 
-// New creates a new application instance
+// New creates a new application instance. If another instance is already
+// running, the returned error wraps instance.ErrAlreadyRunning.
 func New() (*App, error) {
-	app := &App{}
+	app := &App{
+		reviewStop:  make(chan struct{}),
+		permissions: permissions.NewSystemChecker(),
+	}
+
+	// Make sure no other instance shares the database and config, even
+	// while loading or recovering the config
+	lockPath, err := instance.DefaultLockPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lock path: %w", err)
+	}
+	instanceLock, err := instance.Acquire(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	app.instanceLock = instanceLock
 
 	// Initialize config manager
+	config.SetSoundLookup(func(name string) error {
+		_, err := sound.Resolve(name)
+		return err
+	})
 	configManager, err := config.NewManager()
 	if err != nil {
+		instanceLock.Release()
 		return nil, fmt.Errorf("failed to create config manager: %w", err)
 	}
 	app.configManager = configManager
 
+	// Route all notifications through the hourly cap
+	app.notifications = notify.NewLimiter(menuet.App(), configManager.Get().MaxNotificationsPerHour)
+
 	// Initialize stats store
 	statsStore, err := stats.NewStore()
 	if err != nil {
+		instanceLock.Release()
 		return nil, fmt.Errorf("failed to create stats store: %w", err)
 	}
 	app.statsStore = statsStore
+	app.dayRollover = stats.NewDayRollover(statsStore)
 
 	// Get configuration
 	cfg := configManager.Get()
+	statsStore.SetPostponeMode(stats.PostponeMode(cfg.PostponeCounts))
+	statsStore.SetWeekStart(cfg.WeekStartDay())
+	statsStore.SetStreakFreezes(cfg.StreakFreezes)
+	statsStore.SetHealthScoreGoals(cfg.BreakDuration, cfg.DailyComplianceGoal)
+
+	// Breaks still pending were interrupted when the app last quit
+	if abandoned, err := statsStore.AbandonPendingBreaks(); err != nil {
+		log.Printf("Warning: failed to close interrupted breaks: %v", err)
+	} else if abandoned > 0 {
+		log.Printf("Marked %d interrupted breaks as abandoned", abandoned)
+	}
+
+	// Pauses still open were interrupted when the app last quit
+	if closed, err := statsStore.CloseOpenPauses(); err != nil {
+		log.Printf("Warning: failed to close interrupted pauses: %v", err)
+	} else if closed > 0 {
+		log.Printf("Closed %d interrupted pauses", closed)
+	}
+
+	// Drop statistics beyond the retention period
+	if cfg.RetentionDays > 0 {
+		pruned, err := statsStore.PruneOlderThan(cfg.RetentionDays)
+		if err != nil {
+			log.Printf("Warning: failed to prune statistics: %v", err)
+		} else if pruned > 0 {
+			log.Printf("Pruned %d records older than %d days", pruned, cfg.RetentionDays)
+		}
+	}
 
 	// Initialize timer manager
 	timerManager := timer.NewManager(cfg, statsStore)
+	appSource := activity.NewWorkspaceAppSource()
+	timerManager.SetPresentationDetector(activity.NewPresentationDetector(appSource))
+	timerManager.SetAppSource(appSource)
 	app.timerManager = timerManager
 
+	// Initialize sound player for the break chime
+	app.soundPlayer = sound.NewPlayer()
+
+	// Initialize power monitor (breaks pause on battery with PauseOnBattery)
+	app.powerMonitor = power.NewMonitor(power.NewIOKitSource())
+	timerManager.SetPowerSource(app.powerMonitor)
+
 	// Initialize activity monitor
 	activityMonitor := activity.NewMonitor(cfg)
+	activityMonitor.SetEnabled(cfg.IdleAutoPauseEnabled)
 	app.activityMonitor = activityMonitor
+	timerManager.SetIdleDetector(activityMonitor)
 
 	// Initialize overlay window
 	overlayWindow := overlay.NewWindow(cfg)
+	overlayWindow.SetNotify(func(title, message string) {
+		app.notifications.Notify(menuet.Notification{Title: title, Message: message}, notify.PriorityHigh)
+	})
 	app.overlayWindow = overlayWindow
+	timerManager.SetOverlaySuppressor(overlayWindow)
 
 	// Initialize menu bar
-	menuBar := ui.NewMenuBar(timerManager, statsStore)
+	menuBar := ui.NewMenuBar(cfg, timerManager, statsStore)
 	app.menuBar = menuBar
 
+	// Initialize local HTTP API (disabled unless a port is configured)
+	if cfg.APIPort > 0 {
+		app.apiServer = api.NewServer(fmt.Sprintf("127.0.0.1:%d", cfg.APIPort), configManager)
+		app.apiServer.SetTimer(timerManager)
+	}
+
+	// Initialize timer event log
+	app.openEventLog(cfg.EventLogPath)
+
+	// Initialize webhook (only used with a WebhookURL)
+	app.openWebhook(cfg.WebhookURL)
+
+	// Initialize break commands (only used with OnBreakStartCommand or OnBreakEndCommand)
+	app.hooks = hook.NewExecutor(hook.NewShellRunner())
+
+	// Initialize global pause hotkey
+	app.pauseHotkey = hotkey.NewListener(hotkey.NewEventMonitorBackend())
+
+	// Initialize gaze verification (only used with VerifyGazeAway)
+	app.gazeVerifier = gaze.NewVerifier(gaze.NewCameraDetector())
+	app.breakObserver = activity.NewBreakObserver()
+
+	// Initialize display dimming (only used with DimBrightnessDuringBreak)
+	app.brightness = brightness.NewDimmer(brightness.NewDisplayServicesBackend())
+
 	// Set up callbacks
 	app.setupCallbacks()
 
@@ -88,12 +234,66 @@ func (a *App) Run() error {
 		}
 	}
 
+	// Present remaining onboarding steps once the menu bar app is up
+	go func() {
+		time.Sleep(2 * time.Second)
+		a.presentOnboarding()
+	}()
+
+	// Features that failed to start, reported to the user once running
+	var degraded []string
+
+	// Turn off features whose permissions are missing
+	permissionGaps := a.checkPermissions()
+
 	// Start activity monitoring
-	a.activityMonitor.Start()
+	if err := a.activityMonitor.Start(); err != nil {
+		log.Printf("Warning: failed to start activity monitoring: %v", err)
+		if a.configManager.Get().IdleAutoPauseEnabled {
+			degraded = append(degraded, idleUnavailableMessage)
+		}
+	}
+
+	// Start power source monitoring
+	a.powerMonitor.Start()
 
 	// Start timer
 	a.timerManager.Start()
 
+	// Catch up with the time slept after waking
+	a.observeWake()
+
+	// Keep daily stats current across midnight
+	a.dayRollover.Start()
+
+	// Start daily review checks
+	a.startDailyReview()
+
+	// Register global pause hotkey
+	a.bindPauseHotkey(a.configManager.Get().PauseHotkey)
+
+	// Start local HTTP API
+	if a.apiServer != nil {
+		if err := a.apiServer.Start(); err != nil {
+			log.Printf("Warning: failed to start API server: %v", err)
+			degraded = append(degraded, apiUnavailableMessage)
+		}
+	}
+
+	// Report degraded features once the menu bar app is up
+	if len(degraded) > 0 {
+		go func() {
+			time.Sleep(2 * time.Second)
+			a.notifyDegraded(degraded)
+		}()
+	}
+	if len(permissionGaps) > 0 {
+		go func() {
+			time.Sleep(2 * time.Second)
+			a.notifyPermissionGaps(permissionGaps)
+		}()
+	}
+
 	log.Println("Application started successfully")
 
 	// Run menu bar (this blocks until quit)
@@ -106,12 +306,41 @@ func (a *App) Run() error {
 func (a *App) Shutdown() {
 	log.Println("Shutting down application...")
 
+	// Stop local HTTP API
+	if a.apiServer != nil {
+		if err := a.apiServer.Stop(); err != nil {
+			log.Printf("Warning: failed to stop API server: %v", err)
+		}
+	}
+
+	// Stop daily review checks
+	a.stopDailyReview()
+
+	// Unregister global pause hotkey
+	a.pauseHotkey.Close()
+
+	// Release the camera if a break is being verified
+	a.gazeVerifier.End()
+	a.breakObserver.End()
+
+	// Don't leave the displays dimmed by a break
+	a.brightness.Restore()
+
 	// Stop activity monitoring
 	a.activityMonitor.Stop()
 
+	// Stop power source monitoring
+	a.powerMonitor.Stop()
+
 	// Stop timer
 	a.timerManager.Stop()
 
+	// Stop daily stats rollover
+	a.dayRollover.Stop()
+
+	// End a pause that lasts until quitting
+	a.endPause()
+
 	// End session
 	if a.sessionID > 0 {
 		// TODO: Track paused duration
@@ -120,30 +349,305 @@ func (a *App) Shutdown() {
 		}
 	}
 
+	// Close timer event log
+	a.openEventLog("")
+
+	// Stop webhook deliveries
+	a.openWebhook("")
+
 	// Close stats store
 	if err := a.statsStore.Close(); err != nil {
 		log.Printf("Warning: failed to close stats store: %v", err)
 	}
 
+	// Allow the next instance to start
+	if err := a.instanceLock.Release(); err != nil {
+		log.Printf("Warning: failed to release instance lock: %v", err)
+	}
+
 	log.Println("Shutdown complete")
 }
 
+// applyConfig propagates a changed configuration to all components
+func (a *App) applyConfig(cfg *config.Config) {
+	a.statsStore.SetPostponeMode(stats.PostponeMode(cfg.PostponeCounts))
+	a.statsStore.SetWeekStart(cfg.WeekStartDay())
+	a.statsStore.SetStreakFreezes(cfg.StreakFreezes)
+	a.statsStore.SetHealthScoreGoals(cfg.BreakDuration, cfg.DailyComplianceGoal)
+	a.timerManager.UpdateConfig(cfg)
+	a.activityMonitor.UpdateConfig(cfg)
+	a.activityMonitor.SetEnabled(cfg.IdleAutoPauseEnabled)
+	a.overlayWindow.UpdateConfig(cfg)
+	a.menuBar.UpdateConfig(cfg)
+	a.bindPauseHotkey(cfg.PauseHotkey)
+	a.notifications.SetMaxPerHour(cfg.MaxNotificationsPerHour)
+	a.openEventLog(cfg.EventLogPath)
+	a.openWebhook(cfg.WebhookURL)
+}
+
+// openEventLog switches the timer event log to path, closing the previous
+// log if the path changed. An empty path disables the log.
+func (a *App) openEventLog(path string) {
+	a.eventLogMu.Lock()
+	defer a.eventLogMu.Unlock()
+
+	if a.eventLog != nil {
+		if a.eventLog.Path() == path {
+			return
+		}
+		if err := a.eventLog.Close(); err != nil {
+			log.Printf("Warning: failed to close event log: %v", err)
+		}
+		a.eventLog = nil
+	}
+
+	if path == "" {
+		return
+	}
+
+	eventLog, err := eventlog.Open(path, eventlog.DefaultMaxSize)
+	if err != nil {
+		log.Printf("Warning: failed to open event log: %v", err)
+		return
+	}
+	a.eventLog = eventLog
+}
+
+// logEvent writes a timer event to the event log if enabled
+func (a *App) logEvent(event timer.Event) {
+	a.eventLogMu.Lock()
+	defer a.eventLogMu.Unlock()
+
+	if a.eventLog == nil {
+		return
+	}
+	if err := a.eventLog.Log(event); err != nil {
+		log.Printf("Warning: failed to write event log: %v", err)
+	}
+}
+
+// bindPauseHotkey registers the global pause hotkey. Failures are only
+// logged since the menu offers the same actions.
+func (a *App) bindPauseHotkey(spec string) {
+	// The hotkey can't see key presses in other apps without accessibility
+	if spec != "" && !a.permissions.Check().Accessibility {
+		log.Printf("Not registering pause hotkey %q without accessibility access", spec)
+		spec = ""
+	}
+	if err := a.pauseHotkey.Bind(spec); err != nil {
+		log.Printf("Warning: failed to register pause hotkey %q: %v", spec, err)
+	}
+}
+
+// recordPause keeps the pause records in step with the timer state: a
+// pause starts when the timer is paused and ends when it leaves the paused
+// states
+func (a *App) recordPause(state timer.State) {
+	var kind stats.PauseKind
+	switch state {
+	case timer.StatePausedManual:
+		kind = stats.PauseKindManual
+	case timer.StatePausedInactive:
+		kind = stats.PauseKindIdle
+	default:
+		a.endPause()
+		return
+	}
+	if a.pauseID.Load() != 0 {
+		return
+	}
+
+	pauseID, err := a.statsStore.StartPause(kind)
+	if err != nil {
+		log.Printf("Warning: failed to record pause: %v", err)
+		return
+	}
+	a.pauseID.Store(pauseID)
+}
+
+// endPause ends the open pause record, if any
+func (a *App) endPause() {
+	pauseID := a.pauseID.Swap(0)
+	if pauseID == 0 {
+		return
+	}
+	if err := a.statsStore.EndPause(pauseID); err != nil {
+		log.Printf("Warning: failed to end pause: %v", err)
+	}
+}
+
+// extendBreak lengthens the current break by breakExtension. The overlay
+// goes first since a countdown that already finished can't be extended.
+func (a *App) extendBreak() {
+	if !a.overlayWindow.AddTime(breakExtension) {
+		return
+	}
+	a.timerManager.ExtendBreak(breakExtension)
+}
+
+// openDataDir reveals the folder holding config.json and stats.db in Finder
+func (a *App) openDataDir() {
+	dir, err := config.DataDir()
+	if err != nil {
+		log.Printf("Warning: failed to get data folder: %v", err)
+		return
+	}
+	if err := exec.Command("open", dir).Start(); err != nil {
+		log.Printf("Warning: failed to open data folder %s: %v", dir, err)
+		menuet.App().Alert(menuet.Alert{
+			MessageText:     "Datenordner konnte nicht geöffnet werden",
+			InformativeText: dir,
+		})
+	}
+}
+
+// rebuildStats recalculates the daily statistics of the last
+// rebuildStatsDays days and notifies the user when done
+func (a *App) rebuildStats() {
+	now := time.Now()
+	days, err := a.statsStore.RebuildDailyStats(now.AddDate(0, 0, -(rebuildStatsDays-1)), now)
+
+	notification := menuet.Notification{
+		Title:   "Statistiken neu berechnet",
+		Message: fmt.Sprintf("%d Tage aktualisiert.", days),
+	}
+	if err != nil {
+		log.Printf("Warning: failed to rebuild statistics: %v", err)
+		notification.Title = "Statistiken konnten nicht neu berechnet werden"
+		notification.Message = err.Error()
+	}
+	a.notifications.Notify(notification, notify.PriorityHigh)
+}
+
+// togglePause pauses a running timer or resumes a paused one
+func (a *App) togglePause() {
+	switch a.timerManager.GetState() {
+	case timer.StateRunning:
+		a.timerManager.Pause()
+	case timer.StatePausedManual, timer.StatePausedInactive:
+		a.timerManager.Resume()
+	}
+}
+
+// handleNotificationResponse dispatches clicks on notifications by identifier
+func (a *App) handleNotificationResponse(id, response string) {
+	switch {
+	case strings.HasPrefix(id, onboardingNotificationPrefix):
+		a.handleOnboardingResponse(id)
+	case strings.HasPrefix(id, ratingNotificationPrefix):
+		a.handleRatingResponse(id, response)
+	case strings.HasPrefix(id, skipReasonNotificationPrefix):
+		a.handleSkipReasonResponse(id, response)
+	case strings.HasPrefix(id, permissionsNotificationPrefix):
+		a.handlePermissionsResponse(id)
+	case strings.HasPrefix(id, welcomeNotificationPrefix):
+		a.handleWelcomeResponse()
+	case strings.HasPrefix(id, breakWarningNotificationPrefix):
+		a.handleBreakWarningResponse(id)
+	case strings.HasPrefix(id, breakNotificationPrefix):
+		a.handleBreakResponse(id, response)
+	}
+}
+
 // setupCallbacks configures all component callbacks
 func (a *App) setupCallbacks() {
+	// Notification callbacks
+	menuet.App().NotificationResponder = a.handleNotificationResponse
+
 	// Timer callbacks
-	a.timerManager.SetOnBreakRequired(func() {
-		log.Println("Break required - showing overlay")
-		cfg := a.configManager.Get()
-		a.overlayWindow.Show(cfg.BreakDuration)
+	a.timerManager.SetOnPreBreak(func(untilBreak time.Duration) {
+		if a.overlayWindow.ShouldSuppress() {
+			return
+		}
+		a.overlayWindow.StartDim(untilBreak)
+	})
+
+	a.timerManager.SetOnBreakWarning(func(untilBreak time.Duration) {
+		if a.overlayWindow.ShouldSuppress() {
+			return
+		}
+		a.showBreakWarning(untilBreak)
+	})
+
+	a.timerManager.SetOnBreakRequired(func(info timer.BreakInfo) {
+		a.currentBreakID.Store(info.ID)
+		if cfg := a.configManager.Get(); cfg.NotificationSound {
+			a.soundPlayer.PlayBreakStart(cfg.BreakStartSound, cfg.SoundVolume)
+		}
+		if cfg := a.configManager.Get(); cfg.DimBrightnessDuringBreak {
+			a.brightness.Dim(cfg.BreakBrightnessLevel)
+		}
+		if a.configManager.Get().BreakStyle == config.BreakStyleNotification {
+			log.Printf("Break required (%s) - showing notification", info.Kind)
+			a.overlayWindow.StopDim()
+			a.showBreakNotification(info)
+			return
+		}
+
+		log.Printf("Break required (%s) - showing overlay", info.Kind)
+		a.overlayWindow.SetNextBreakAt(info.NextBreakAt)
+		a.overlayWindow.SetBreakKind(string(info.Kind))
+		switch info.Kind {
+		case stats.BreakKindRecovery:
+			a.overlayWindow.ShowRecovery(info.Duration)
+		case stats.BreakKindSessionLimit:
+			a.overlayWindow.ShowSessionLimit(info.Duration, info.Lockout)
+		default:
+			a.overlayWindow.Show(info.Duration)
+		}
+		if a.configManager.Get().VerifyGazeAway {
+			a.gazeVerifier.Begin()
+		}
+		if a.configManager.Get().InferCompletionFromActivity {
+			a.breakObserver.Begin()
+		}
 	})
 
 	a.timerManager.SetOnBreakComplete(func() {
 		log.Println("Break completed")
 		a.overlayWindow.Hide()
+		a.maybeAskRating(a.currentBreakID.Load())
+		a.checkRestGoal()
 	})
 
 	a.timerManager.SetOnStateChange(func(state timer.State) {
 		log.Printf("Timer state changed to: %s", state.String())
+		if state == timer.StatePausedManual || state == timer.StatePausedInactive {
+			a.overlayWindow.StopDim()
+		}
+		// However the break ended, completed, skipped or postponed
+		if state != timer.StateBreakRequired {
+			a.brightness.Restore()
+		}
+		a.recordPause(state)
+	})
+
+	a.timerManager.SetOnResumedFromIdle(func(away time.Duration) {
+		log.Printf("Resumed after being away for %s", away.Round(time.Second))
+		a.notifyResumedFromIdle(away)
+		a.notifyCatchUpSummary(away)
+	})
+
+	a.timerManager.SetOnEvent(func(event timer.Event) {
+		a.logEvent(event)
+		a.sendWebhookEvent(event)
+		a.runBreakCommand(event)
+		if event.Type == timer.EventBreakSkipped {
+			a.maybeAskSkipReason(event.BreakID)
+		}
+	})
+
+	a.dayRollover.SetOnDayEnd(a.sendDailySummary)
+
+	a.timerManager.SetOnIntervalAdapted(func(workDuration time.Duration) {
+		log.Printf("Adaptive interval changed the work duration to %s", workDuration)
+		cfg := a.configManager.Get()
+		cfg.WorkDuration = workDuration
+		if err := a.configManager.Update(cfg); err != nil {
+			log.Printf("Warning: failed to save adapted work duration: %v", err)
+			return
+		}
+		a.applyConfig(a.configManager.Get())
 	})
 
 	// Activity monitor callbacks
@@ -157,26 +661,156 @@ func (a *App) setupCallbacks() {
 		a.timerManager.ResumeFromInactive()
 	})
 
+	// Power monitor callbacks
+	a.powerMonitor.SetOnChange(func(onBattery bool) {
+		if onBattery {
+			log.Println("Switched to battery power")
+		} else {
+			log.Println("Switched to AC power")
+		}
+	})
+
+	// Hotkey callbacks
+	a.pauseHotkey.SetOnPressed(func() {
+		log.Println("Pause hotkey pressed")
+		a.togglePause()
+	})
+
 	// Overlay callbacks
 	a.overlayWindow.SetOnComplete(func() {
 		log.Println("Overlay countdown complete")
+		lookedAway := a.gazeVerifier.End()
+		switch a.breakObserver.End() {
+		case activity.BreakActivityResting:
+			log.Println("No input during the break - counting it as completed")
+			a.timerManager.CompleteBreak()
+			return
+		case activity.BreakActivityActive:
+			log.Println("User typed through the break - not counting it")
+			a.timerManager.SkipBreak()
+			return
+		}
+		if !lookedAway {
+			log.Println("User kept looking at the screen - not counting the break")
+			a.timerManager.SkipBreak()
+			return
+		}
 		a.timerManager.CompleteBreak()
 	})
 
+	a.overlayWindow.SetOnDismiss(func(elapsed time.Duration) {
+		log.Printf("User ended break after %s", elapsed.Round(time.Second))
+		lookedAway := a.gazeVerifier.End()
+		if a.breakObserver.End() == activity.BreakActivityActive {
+			log.Println("User typed through the break - not counting it")
+			a.timerManager.SkipBreak()
+			return
+		}
+		if !lookedAway {
+			log.Println("User kept looking at the screen - not counting the break")
+			a.timerManager.SkipBreak()
+			return
+		}
+		if !a.timerManager.EndBreakEarly(elapsed) {
+			log.Println("Break ended too early - recorded as skipped")
+		}
+	})
+
+	a.overlayWindow.SetOnPostpone(func() {
+		log.Printf("User postponed break by %s", breakPostponement)
+		a.gazeVerifier.End()
+		a.breakObserver.End()
+		a.timerManager.PostponeBreak(breakPostponement)
+	})
+
+	a.overlayWindow.SetOnEmergencyExit(func() {
+		log.Println("User used the emergency exit - recording break as abandoned")
+		a.gazeVerifier.End()
+		a.breakObserver.End()
+		a.timerManager.AbandonBreak()
+	})
+
+	a.overlayWindow.SetOnAddTime(func() {
+		log.Println("User extended break from overlay")
+		a.extendBreak()
+	})
+
 	// Menu bar callbacks
+	a.menuBar.SetOnExtend(func() {
+		log.Println("User extended break")
+		a.extendBreak()
+	})
+
+	a.menuBar.SetOnExtendWork(func(d time.Duration) {
+		granted := a.timerManager.ExtendWorkInterval(d)
+		log.Printf("User extended work interval by %s", granted)
+	})
+
+	a.menuBar.SetOnBreakNow(func() {
+		log.Println("User started a break manually")
+		a.timerManager.TriggerBreakNow()
+	})
+
+	a.menuBar.SetOnSilent(func(silent bool) {
+		if silent {
+			log.Println("User turned silent mode on")
+		} else {
+			log.Println("User turned silent mode off")
+		}
+		a.timerManager.SetSilent(silent)
+	})
+
+	a.menuBar.SetOnRebuildStats(func() {
+		log.Println("User rebuilt the statistics")
+		go a.rebuildStats()
+	})
+
+	a.menuBar.SetOnOpenDataDir(func() {
+		log.Println("User opened data folder")
+		a.openDataDir()
+	})
+
+	a.menuBar.SetOnDiagnose(func() {
+		log.Println("User ran diagnostics")
+		a.runDiagnostics()
+	})
+
 	a.menuBar.SetOnPause(func() {
 		log.Println("User paused timer")
 		a.timerManager.Pause()
 	})
 
+	a.menuBar.SetOnPauseFor(func(d time.Duration) {
+		log.Printf("User paused timer for %s", d)
+		a.timerManager.PauseFor(d)
+	})
+
 	a.menuBar.SetOnResume(func() {
 		log.Println("User resumed timer")
 		a.timerManager.Resume()
 	})
 
+	a.menuBar.SetOnSuspend(func() {
+		log.Println("User suspended breaks until tomorrow")
+		a.timerManager.SuspendUntilTomorrow()
+	})
+
+	a.menuBar.SetOnUnsuspend(func() {
+		log.Println("User re-enabled breaks")
+		a.timerManager.CancelSuspension()
+	})
+
 	a.menuBar.SetOnQuit(func() {
 		log.Println("User requested quit")
 		a.Shutdown()
 		os.Exit(0)
 	})
+
+	// API callbacks
+	if a.apiServer != nil {
+		a.apiServer.SetOnConfigUpdated(func(cfg *config.Config) {
+			log.Println("Configuration updated via API")
+			a.applyConfig(cfg)
+		})
+	}
 }
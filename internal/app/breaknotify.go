@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/notify"
+	"github.com/siegfried/2020rule/internal/stats"
+	"github.com/siegfried/2020rule/internal/timer"
+)
+
+const (
+	// breakNotificationPrefix identifies break notifications of the
+	// notification break style
+	breakNotificationPrefix = "break:"
+	// breakNotificationGrace is how long after the break should have ended an
+	// unanswered break notification counts as skipped
+	breakNotificationGrace = 1 * time.Minute
+)
+
+// breakController is the part of the timer manager break notifications act on
+type breakController interface {
+	EndBreakEarly(elapsed time.Duration) bool
+	SkipBreak()
+}
+
+// showBreakNotification presents a break as a notification instead of the
+// overlay. A click on the notification itself is reported just like its
+// button, so the break is answered by reply: "Pause gemacht" to end it,
+// "Überspringen" to skip it. Closing or ignoring the notification skips the
+// break once the grace period ends.
+func (a *App) showBreakNotification(info timer.BreakInfo) {
+	title := "Zeit für eine Augenpause!"
+	switch info.Kind {
+	case stats.BreakKindRecovery:
+		title = "Lange ohne Pause – Zeit für eine Erholungspause!"
+	case stats.BreakKindSessionLimit:
+		title = "Mach eine längere Pause!"
+	}
+
+	a.breakNotifiedAt.Store(time.Now().UnixNano())
+	a.notifications.Notify(menuet.Notification{
+		Title:               title,
+		Message:             fmt.Sprintf("Schau %d Sekunden lang auf etwas in 6 Metern Entfernung.", int(info.Duration.Seconds())),
+		ActionButton:        "Antworten",
+		CloseButton:         "Überspringen",
+		ResponsePlaceholder: "Pause gemacht / Überspringen",
+		Identifier:          breakNotificationPrefix + strconv.FormatInt(info.ID, 10),
+	}, notify.PriorityHigh)
+
+	time.AfterFunc(info.Duration+breakNotificationGrace, func() {
+		if a.currentBreakID.Load() != info.ID || a.timerManager.GetState() != timer.StateBreakRequired {
+			return
+		}
+		log.Println("Break notification unanswered - skipping break")
+		applyBreakAction(a.timerManager, breakActionSkip, 0)
+	})
+}
+
+// handleBreakResponse ends the break of a break notification as replied.
+// Clicks without a reply leave the break running.
+func (a *App) handleBreakResponse(id, response string) {
+	breakID, err := strconv.ParseInt(strings.TrimPrefix(id, breakNotificationPrefix), 10, 64)
+	if err != nil || breakID != a.currentBreakID.Load() {
+		return
+	}
+	action, ok := parseBreakReply(response)
+	if !ok {
+		log.Printf("Ignoring break notification reply %q", response)
+		return
+	}
+	elapsed := time.Since(time.Unix(0, a.breakNotifiedAt.Load()))
+	applyBreakAction(a.timerManager, action, elapsed)
+}
+
+// breakAction is what the user chose in a break notification
+type breakAction int
+
+const (
+	// breakActionDone means the user replied "Pause gemacht"
+	breakActionDone breakAction = iota
+	// breakActionSkip means the user skipped or ignored the notification
+	breakActionSkip
+)
+
+// breakReplyWords maps the first word of a reply to a break notification to
+// what the user chose
+var breakReplyWords = map[string]breakAction{
+	"pause":        breakActionDone,
+	"fertig":       breakActionDone,
+	"erledigt":     breakActionDone,
+	"ok":           breakActionDone,
+	"überspringen": breakActionSkip,
+	"skip":         breakActionSkip,
+}
+
+// parseBreakReply returns the action a reply to a break notification names
+// by its first word, e.g. "Pause gemacht" or "Überspringen". An empty reply,
+// from a click on the notification itself, names none.
+func parseBreakReply(reply string) (breakAction, bool) {
+	words := strings.Fields(strings.ToLower(reply))
+	if len(words) == 0 {
+		return 0, false
+	}
+	action, ok := breakReplyWords[strings.Trim(words[0], ".,:!")]
+	return action, ok
+}
+
+// applyBreakAction ends the current break according to action. A break
+// marked as done counts as completed if it lasted long enough.
+func applyBreakAction(breaks breakController, action breakAction, elapsed time.Duration) {
+	switch action {
+	case breakActionDone:
+		if !breaks.EndBreakEarly(elapsed) {
+			log.Println("Break marked as done too early - recorded as skipped")
+		}
+	case breakActionSkip:
+		breaks.SkipBreak()
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeBreaks records the calls break notifications make
+type fakeBreaks struct {
+	endedEarly []time.Duration
+	skipped    int
+	completes  bool // What EndBreakEarly reports
+}
+
+func (f *fakeBreaks) EndBreakEarly(elapsed time.Duration) bool {
+	f.endedEarly = append(f.endedEarly, elapsed)
+	return f.completes
+}
+
+func (f *fakeBreaks) SkipBreak() {
+	f.skipped++
+}
+
+func TestParseBreakReply(t *testing.T) {
+	tests := []struct {
+		reply  string
+		want   breakAction
+		wantOK bool
+	}{
+		{"Pause gemacht", breakActionDone, true},
+		{"fertig!", breakActionDone, true},
+		{" ok ", breakActionDone, true},
+		{"Überspringen", breakActionSkip, true},
+		{"skip", breakActionSkip, true},
+		{"", 0, false},
+		{"vielleicht", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reply, func(t *testing.T) {
+			got, ok := parseBreakReply(tt.reply)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("parseBreakReply(%q) = %v, %t, want %v, %t", tt.reply, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBreakNotificationOutcome(t *testing.T) {
+	const elapsed = 18 * time.Second
+
+	tests := []struct {
+		name        string
+		reply       string // Empty if the notification goes unanswered
+		wantEndedAt []time.Duration
+		wantSkipped int
+	}{
+		{"done", "Pause gemacht", []time.Duration{elapsed}, 0},
+		{"skipped", "Überspringen", nil, 1},
+		{"ignored", "", nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaks := &fakeBreaks{completes: true}
+			// The grace period skips unanswered breaks
+			action := breakActionSkip
+			if tt.reply != "" {
+				var ok bool
+				if action, ok = parseBreakReply(tt.reply); !ok {
+					t.Fatalf("reply %q not understood", tt.reply)
+				}
+			}
+			applyBreakAction(breaks, action, elapsed)
+
+			if len(breaks.endedEarly) != len(tt.wantEndedAt) || (len(tt.wantEndedAt) > 0 && breaks.endedEarly[0] != tt.wantEndedAt[0]) {
+				t.Errorf("EndBreakEarly calls = %v, want %v", breaks.endedEarly, tt.wantEndedAt)
+			}
+			if breaks.skipped != tt.wantSkipped {
+				t.Errorf("SkipBreak calls = %d, want %d", breaks.skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
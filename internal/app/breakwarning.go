@@ -0,0 +1,39 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/notify"
+)
+
+// breakWarningNotificationPrefix identifies pre-break warning notifications
+const breakWarningNotificationPrefix = "breakwarning:"
+
+// showBreakWarning announces the break due in untilBreak with the option to
+// postpone it before it starts
+func (a *App) showBreakWarning(untilBreak time.Duration) {
+	seconds := int(untilBreak.Round(time.Second).Seconds())
+	a.notifications.Notify(menuet.Notification{
+		Title:        "Gleich ist Augenpause",
+		Message:      fmt.Sprintf("In %d Sekunden beginnt deine nächste Pause.", seconds),
+		ActionButton: fmt.Sprintf("%d Minuten später", int(breakPostponement.Minutes())),
+		CloseButton:  "OK",
+		Identifier:   breakWarningNotificationPrefix + time.Now().Add(untilBreak).Format(time.RFC3339),
+	}, notify.PriorityHigh)
+}
+
+// handleBreakWarningResponse postpones the break announced by a clicked
+// warning notification, unless it has already started
+func (a *App) handleBreakWarningResponse(id string) {
+	dueAt, err := time.Parse(time.RFC3339, strings.TrimPrefix(id, breakWarningNotificationPrefix))
+	if err != nil || time.Until(dueAt) < -time.Second {
+		return
+	}
+	if a.timerManager.PostponeUpcomingBreak(breakPostponement) {
+		log.Printf("User postponed upcoming break by %s", breakPostponement)
+	}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/notify"
+	"github.com/siegfried/2020rule/internal/stats"
+)
+
+// catchUpNotificationPrefix identifies catch-up summary notifications
+const catchUpNotificationPrefix = "catchup:"
+
+// catchUpMessage sums up the breaks missed while away from their counts by
+// outcome. It reports false if no break was missed.
+func catchUpMessage(counts map[stats.BreakOutcome]int) (string, bool) {
+	var parts []string
+	if n := counts[stats.OutcomeIdle]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d automatisch ausgelassen", n))
+	}
+	if n := counts[stats.OutcomeSkipped] + counts[stats.OutcomeAbandoned]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d übersprungen", n))
+	}
+	if n := counts[stats.OutcomeSuppressed]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d im Stillmodus", n))
+	}
+	if len(parts) == 0 {
+		return "", false
+	}
+	return "Pausen während du weg warst: " + strings.Join(parts, ", ") + ".", true
+}
+
+// notifyCatchUpSummary tells the user how many breaks were missed during
+// the away time that just ended
+func (a *App) notifyCatchUpSummary(away time.Duration) {
+	if !a.configManager.Get().ShowCatchUpSummary {
+		return
+	}
+
+	now := time.Now()
+	counts, err := a.statsStore.CountBreaksBetween(now.Add(-away), now)
+	if err != nil {
+		log.Printf("Warning: failed to count breaks while away: %v", err)
+		return
+	}
+	message, missed := catchUpMessage(counts)
+	if !missed {
+		return
+	}
+
+	a.notifications.Notify(menuet.Notification{
+		Title:      fmt.Sprintf("Du warst %s weg", formatAway(away)),
+		Message:    message,
+		Identifier: catchUpNotificationPrefix + now.Format(time.RFC3339),
+	}, notify.PriorityLow)
+}
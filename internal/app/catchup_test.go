@@ -0,0 +1,38 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/siegfried/2020rule/internal/stats"
+)
+
+func TestCatchUpMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		counts     map[stats.BreakOutcome]int
+		want       string
+		wantMissed bool
+	}{
+		{"nothing recorded", nil, "", false},
+		{"only completed", map[stats.BreakOutcome]int{stats.OutcomeCompleted: 3}, "", false},
+		{"only postponed and pending", map[stats.BreakOutcome]int{stats.OutcomePostponed: 1, stats.OutcomePending: 1}, "", false},
+		{"idle", map[stats.BreakOutcome]int{stats.OutcomeIdle: 2}, "Pausen während du weg warst: 2 automatisch ausgelassen.", true},
+		{"skipped and abandoned", map[stats.BreakOutcome]int{stats.OutcomeSkipped: 1, stats.OutcomeAbandoned: 1}, "Pausen während du weg warst: 2 übersprungen.", true},
+		{"silent", map[stats.BreakOutcome]int{stats.OutcomeSuppressed: 1}, "Pausen während du weg warst: 1 im Stillmodus.", true},
+		{
+			"everything",
+			map[stats.BreakOutcome]int{stats.OutcomeIdle: 3, stats.OutcomeSkipped: 1, stats.OutcomeSuppressed: 2, stats.OutcomeCompleted: 5},
+			"Pausen während du weg warst: 3 automatisch ausgelassen, 1 übersprungen, 2 im Stillmodus.",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, missed := catchUpMessage(tt.counts)
+			if got != tt.want || missed != tt.wantMissed {
+				t.Errorf("catchUpMessage() = %q, %t, want %q, %t", got, missed, tt.want, tt.wantMissed)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/caseymrm/menuet"
+	"github.com/lextoumbourou/idle"
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/doctor"
+)
+
+const (
+	// diagnosticsFileName is the report written to the data folder for bug reports
+	diagnosticsFileName = "diagnostics.json"
+	// launchAgentLabel identifies the app's launch agent
+	launchAgentLabel = "com.siegfried.2020rule"
+)
+
+// diagnosticChecks returns the checks of the diagnostics report. They only
+// read from the running components.
+func (a *App) diagnosticChecks() []doctor.Check {
+	var agentPath string
+	if home, err := os.UserHomeDir(); err == nil {
+		agentPath = filepath.Join(home, "Library", "LaunchAgents", launchAgentLabel+".plist")
+	}
+	cfg := a.configManager.Get()
+
+	return []doctor.Check{
+		doctor.DatabaseCheck(a.statsStore),
+		doctor.ConfigCheck(cfg),
+		doctor.ScreensCheck(func() int { return len(appkit.Screen_Screens()) }),
+		doctor.IdleCheck(idle.Get),
+		doctor.LaunchAgentCheck(agentPath, cfg.AutoStartOnLogin, doctor.FileExists),
+	}
+}
+
+// runDiagnostics checks the app's components, saves the report to the data
+// folder and shows the results
+func (a *App) runDiagnostics() {
+	report := doctor.Run(a.diagnosticChecks())
+	log.Printf("Diagnostics:\n%s", report)
+
+	message := "Alle Prüfungen bestanden"
+	if !report.OK() {
+		message = "Es wurden Probleme gefunden"
+	}
+	details := report.String()
+
+	if path, err := a.saveDiagnostics(report); err != nil {
+		log.Printf("Warning: failed to save diagnostics: %v", err)
+	} else {
+		details += "\n\nBericht gespeichert unter " + path
+	}
+
+	menuet.App().Alert(menuet.Alert{
+		MessageText:     message,
+		InformativeText: details,
+	})
+}
+
+// saveDiagnostics writes report as JSON to the data folder and returns its path
+func (a *App) saveDiagnostics(report doctor.Report) (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	data, err := report.JSON()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, diagnosticsFileName)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
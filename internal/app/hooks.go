@@ -0,0 +1,45 @@
+package app
+
+import (
+	"github.com/siegfried/2020rule/internal/hook"
+	"github.com/siegfried/2020rule/internal/timer"
+)
+
+// breakEndOutcomes maps the events that end a shown break to the outcome
+// passed to the break end command
+var breakEndOutcomes = map[timer.EventType]string{
+	timer.EventBreakCompleted: "completed",
+	timer.EventBreakSkipped:   "skipped",
+	timer.EventBreakPostponed: "postponed",
+	timer.EventBreakAbandoned: "abandoned",
+}
+
+// breakCommandFor returns the configured command to run for event and the
+// hook event describing it. ok is false if event doesn't start or end a
+// shown break, e.g. a break postponed from its warning before it started.
+func breakCommandFor(event timer.Event, startCommand, endCommand string) (command string, hookEvent hook.Event, ok bool) {
+	hookEvent = hook.Event{Time: event.Time, BreakID: event.BreakID}
+	if event.Type == timer.EventBreakStarted {
+		hookEvent.Name = hook.EventBreakStart
+		return startCommand, hookEvent, true
+	}
+
+	outcome, ends := breakEndOutcomes[event.Type]
+	if !ends || event.State != timer.StateBreakRequired.String() {
+		return "", hookEvent, false
+	}
+	hookEvent.Name = hook.EventBreakEnd
+	hookEvent.Outcome = outcome
+	return endCommand, hookEvent, true
+}
+
+// runBreakCommand runs the configured command for a break starting or
+// ending in the background
+func (a *App) runBreakCommand(event timer.Event) {
+	cfg := a.configManager.Get()
+	command, hookEvent, ok := breakCommandFor(event, cfg.OnBreakStartCommand, cfg.OnBreakEndCommand)
+	if !ok {
+		return
+	}
+	a.hooks.Fire(command, hookEvent)
+}
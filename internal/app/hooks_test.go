@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/hook"
+	"github.com/siegfried/2020rule/internal/timer"
+)
+
+func TestBreakCommandFor(t *testing.T) {
+	const start, end = "say start", "say end"
+	at := time.Date(2025, time.June, 4, 9, 20, 0, 0, time.Local)
+	shown := timer.StateBreakRequired.String()
+	running := timer.StateRunning.String()
+
+	tests := []struct {
+		name        string
+		eventType   timer.EventType
+		state       string
+		wantCommand string
+		wantName    string
+		wantOutcome string
+		wantOK      bool
+	}{
+		{"break started", timer.EventBreakStarted, running, start, hook.EventBreakStart, "", true},
+		{"break completed", timer.EventBreakCompleted, shown, end, hook.EventBreakEnd, "completed", true},
+		{"break skipped", timer.EventBreakSkipped, shown, end, hook.EventBreakEnd, "skipped", true},
+		{"break postponed", timer.EventBreakPostponed, shown, end, hook.EventBreakEnd, "postponed", true},
+		{"break abandoned", timer.EventBreakAbandoned, shown, end, hook.EventBreakEnd, "abandoned", true},
+		{"postponed from the warning", timer.EventBreakPostponed, running, "", "", "", false},
+		{"suppressed break", timer.EventBreakSuppressed, running, "", "", "", false},
+		{"state change", timer.EventStateChange, shown, "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := timer.Event{Time: at, Type: tt.eventType, State: tt.state, BreakID: 7}
+			command, hookEvent, ok := breakCommandFor(event, start, end)
+			if ok != tt.wantOK {
+				t.Fatalf("breakCommandFor() ok = %t, want %t", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if command != tt.wantCommand {
+				t.Errorf("command = %q, want %q", command, tt.wantCommand)
+			}
+			want := hook.Event{Name: tt.wantName, Time: at, BreakID: 7, Outcome: tt.wantOutcome}
+			if hookEvent != want {
+				t.Errorf("hook event = %+v, want %+v", hookEvent, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"log"
+	"strings"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/notify"
+)
+
+// onboardingNotificationPrefix identifies notifications for onboarding steps
+const onboardingNotificationPrefix = "onboarding:"
+
+// onboardingMessages holds the notification text for each onboarding step
+var onboardingMessages = map[string]menuet.Notification{
+	config.OnboardingStepPermissions: {
+		Title:   "Berechtigungen erteilen",
+		Message: "Erlaube 2020Rule unter Systemeinstellungen › Datenschutz & Sicherheit › Bedienungshilfen, damit Inaktivität erkannt wird.",
+	},
+	config.OnboardingStepDurations: {
+		Title:   "Dauer anpassen",
+		Message: "Arbeits- und Pausendauer kannst du in der config.json im Datenordner ändern.",
+	},
+	config.OnboardingStepLoginItem: {
+		Title:   "Bei Anmeldung starten",
+		Message: "Aktiviere „Start at Login“ im Menü, damit du keine Pause verpasst.",
+	},
+}
+
+// presentOnboarding shows a notification for the next pending onboarding
+// step. Only one step is presented per launch; it counts as done once the
+// user clicks the notification.
+func (a *App) presentOnboarding() {
+	pending := a.configManager.Get().PendingOnboardingSteps()
+	if len(pending) == 0 {
+		return
+	}
+
+	step := pending[0]
+	notification, ok := onboardingMessages[step]
+	if !ok {
+		return
+	}
+
+	notification.Identifier = onboardingNotificationPrefix + step
+	notification.ActionButton = "Erledigt"
+	a.notifications.Notify(notification, notify.PriorityHigh)
+}
+
+// handleOnboardingResponse marks the step of a clicked onboarding notification as done
+func (a *App) handleOnboardingResponse(id string) {
+	step := strings.TrimPrefix(id, onboardingNotificationPrefix)
+
+	cfg := a.configManager.Get()
+	cfg.MarkStepDone(step)
+	if err := a.configManager.Update(cfg); err != nil {
+		log.Printf("Warning: failed to save onboarding step %q: %v", step, err)
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"log"
+	"os/exec"
+	"strings"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/notify"
+	"github.com/siegfried/2020rule/internal/permissions"
+)
+
+// permissionsNotificationPrefix identifies notifications about missing
+// permissions; the rest of the identifier is the settings pane to open
+const permissionsNotificationPrefix = "permissions:"
+
+// Privacy panes of the System Settings, opened from the notification
+const (
+	accessibilitySettingsPane   = "Privacy_Accessibility"
+	screenRecordingSettingsPane = "Privacy_ScreenCapture"
+)
+
+// permissionGap is an enabled feature that is turned off because the
+// permission it needs is missing
+type permissionGap struct {
+	message      string
+	settingsPane string
+}
+
+// permissionGaps lists the features enabled in cfg that caps doesn't allow
+func permissionGaps(caps permissions.Capabilities, cfg *config.Config) []permissionGap {
+	var gaps []permissionGap
+	if !caps.Accessibility && cfg.PauseHotkey != "" {
+		gaps = append(gaps, permissionGap{
+			message:      "Tastenkürzel braucht Zugriff auf Bedienungshilfen – deaktiviert",
+			settingsPane: accessibilitySettingsPane,
+		})
+	}
+	if !caps.ScreenRecording && cfg.DeferBreaksDuringPresentations {
+		gaps = append(gaps, permissionGap{
+			message:      "Präsentationserkennung braucht Bildschirmaufnahme – deaktiviert",
+			settingsPane: screenRecordingSettingsPane,
+		})
+	}
+	return gaps
+}
+
+// checkPermissions asks for missing permissions if configured and turns
+// off the features lacking theirs. It returns the features turned off.
+func (a *App) checkPermissions() []permissionGap {
+	cfg := a.configManager.Get()
+	caps := a.permissions.Check()
+	if !caps.Complete() && cfg.RequestPermissionsOnStart {
+		a.permissions.Request()
+		caps = a.permissions.Check()
+	}
+	log.Printf("Permissions: accessibility=%t, screen recording=%t", caps.Accessibility, caps.ScreenRecording)
+
+	// Without screen recording fullscreen windows can't be told apart
+	if !caps.ScreenRecording {
+		a.timerManager.SetPresentationDetector(nil)
+	}
+
+	return permissionGaps(caps, cfg)
+}
+
+// notifyPermissionGaps tells the user which features are off for lack of
+// a permission and offers to open the settings to grant it
+func (a *App) notifyPermissionGaps(gaps []permissionGap) {
+	messages := make([]string, 0, len(gaps))
+	for _, gap := range gaps {
+		messages = append(messages, gap.message)
+	}
+	a.notifications.Notify(menuet.Notification{
+		Title:        "Berechtigungen fehlen",
+		Message:      strings.Join(messages, "\n"),
+		ActionButton: "Einstellungen öffnen",
+		Identifier:   permissionsNotificationPrefix + gaps[0].settingsPane,
+	}, notify.PriorityNormal)
+}
+
+// handlePermissionsResponse opens the settings pane of a clicked
+// permissions notification
+func (a *App) handlePermissionsResponse(id string) {
+	pane := strings.TrimPrefix(id, permissionsNotificationPrefix)
+	url := "x-apple.systempreferences:com.apple.preference.security?" + pane
+	if err := exec.Command("open", url).Start(); err != nil {
+		log.Printf("Warning: failed to open privacy settings: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/permissions"
+	"github.com/siegfried/2020rule/internal/timer"
+)
+
+// fakeChecker grants caps, and afterRequest once Request was called
+type fakeChecker struct {
+	caps         permissions.Capabilities
+	afterRequest permissions.Capabilities
+	requested    int
+}
+
+func (f *fakeChecker) Check() permissions.Capabilities {
+	if f.requested > 0 {
+		return f.afterRequest
+	}
+	return f.caps
+}
+
+func (f *fakeChecker) Request() {
+	f.requested++
+}
+
+// panes returns the settings panes of gaps
+func panes(gaps []permissionGap) []string {
+	var panes []string
+	for _, gap := range gaps {
+		panes = append(panes, gap.settingsPane)
+	}
+	return panes
+}
+
+func TestPermissionGaps(t *testing.T) {
+	tests := []struct {
+		name         string
+		caps         permissions.Capabilities
+		hotkey       string
+		presentation bool
+		want         []string
+	}{
+		{"all granted", permissions.Capabilities{Accessibility: true, ScreenRecording: true}, "cmd+opt+p", true, nil},
+		{"none needed", permissions.Capabilities{}, "", false, nil},
+		{"hotkey without accessibility", permissions.Capabilities{ScreenRecording: true}, "cmd+opt+p", true, []string{accessibilitySettingsPane}},
+		{"presentations without screen recording", permissions.Capabilities{Accessibility: true}, "cmd+opt+p", true, []string{screenRecordingSettingsPane}},
+		{"both missing", permissions.Capabilities{}, "cmd+opt+p", true, []string{accessibilitySettingsPane, screenRecordingSettingsPane}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.PauseHotkey = tt.hotkey
+			cfg.DeferBreaksDuringPresentations = tt.presentation
+
+			got := panes(permissionGaps(tt.caps, cfg))
+			if len(got) != len(tt.want) {
+				t.Fatalf("permissionGaps() panes = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("permissionGaps() panes = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPermissions(t *testing.T) {
+	granted := permissions.Capabilities{Accessibility: true, ScreenRecording: true}
+
+	tests := []struct {
+		name            string
+		requestOnLaunch bool
+		wantRequested   int
+		wantGaps        int
+	}{
+		{"requests missing permissions", true, 1, 0},
+		{"doesn't ask unless configured", false, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TWENTY_RULE_DATA_DIR", t.TempDir())
+			configManager, err := config.NewManager()
+			if err != nil {
+				t.Fatalf("NewManager: %v", err)
+			}
+			cfg := configManager.Get()
+			cfg.PauseHotkey = "cmd+opt+p"
+			cfg.DeferBreaksDuringPresentations = true
+			cfg.RequestPermissionsOnStart = tt.requestOnLaunch
+			if err := configManager.Update(cfg); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+
+			checker := &fakeChecker{afterRequest: granted}
+			a := &App{
+				configManager: configManager,
+				permissions:   checker,
+				timerManager:  timer.NewManager(cfg, nil),
+			}
+
+			gaps := a.checkPermissions()
+			if checker.requested != tt.wantRequested {
+				t.Errorf("Request called %d times, want %d", checker.requested, tt.wantRequested)
+			}
+			if len(gaps) != tt.wantGaps {
+				t.Errorf("%d gaps, want %d", len(gaps), tt.wantGaps)
+			}
+		})
+	}
+}
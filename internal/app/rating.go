@@ -0,0 +1,51 @@
+package app
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/notify"
+)
+
+// ratingNotificationPrefix identifies eye comfort rating notifications
+const ratingNotificationPrefix = "rating:"
+
+// shouldAskRating returns whether to ask for a rating after the given number
+// of completed breaks, asking every Nth break
+func shouldAskRating(every, completedBreaks int) bool {
+	return every > 0 && completedBreaks > 0 && completedBreaks%every == 0
+}
+
+// maybeAskRating asks for an eye comfort rating after every Nth completed break
+func (a *App) maybeAskRating(breakID int64) {
+	completed := int(a.completedBreaks.Add(1))
+	if breakID <= 0 || !shouldAskRating(a.configManager.Get().AskRatingEvery, completed) {
+		return
+	}
+
+	a.notifications.Notify(menuet.Notification{
+		Title:               "Wie fühlen sich deine Augen an?",
+		Message:             "Antworte mit 1 (angestrengt) bis 5 (entspannt).",
+		ResponsePlaceholder: "1–5",
+		Identifier:          ratingNotificationPrefix + strconv.FormatInt(breakID, 10),
+	}, notify.PriorityLow)
+}
+
+// handleRatingResponse stores the rating replied to a rating notification
+func (a *App) handleRatingResponse(id, response string) {
+	breakID, err := strconv.ParseInt(strings.TrimPrefix(id, ratingNotificationPrefix), 10, 64)
+	if err != nil {
+		return
+	}
+	rating, err := strconv.Atoi(strings.TrimSpace(response))
+	if err != nil {
+		log.Printf("Ignoring eye comfort rating %q", response)
+		return
+	}
+
+	if err := a.statsStore.RecordRating(breakID, rating); err != nil {
+		log.Printf("Warning: failed to record rating: %v", err)
+	}
+}
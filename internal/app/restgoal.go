@@ -0,0 +1,52 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/notify"
+)
+
+// restGoalNotificationPrefix identifies "rest goal reached" notifications
+const restGoalNotificationPrefix = "restgoal:"
+
+// restGoalJustReached reports whether rest meets goal, both in minutes, and
+// the goal hasn't been reported reached on today yet. Without a goal it is
+// never reached.
+func restGoalJustReached(rest, goal int, reportedDay, today string) bool {
+	return goal > 0 && rest >= goal && reportedDay != today
+}
+
+// checkRestGoal notifies the user once a day when the completed break time
+// reaches the daily rest goal
+func (a *App) checkRestGoal() {
+	goal := a.configManager.Get().DailyRestGoalMinutes
+	if goal <= 0 {
+		return
+	}
+
+	rest, err := a.statsStore.GetRestMinutesToday()
+	if err != nil {
+		log.Printf("Warning: failed to get today's rest time: %v", err)
+		return
+	}
+
+	today := time.Now().Format("2006-01-02")
+	a.restGoalMu.Lock()
+	reached := restGoalJustReached(rest, goal, a.restGoalDay, today)
+	if reached {
+		a.restGoalDay = today
+	}
+	a.restGoalMu.Unlock()
+	if !reached {
+		return
+	}
+
+	a.notifications.Notify(menuet.Notification{
+		Title:      "Tagesziel Augenruhe erreicht 🎉",
+		Message:    fmt.Sprintf("Heute schon %d Minuten Pause für deine Augen.", rest),
+		Identifier: restGoalNotificationPrefix + today,
+	}, notify.PriorityLow)
+}
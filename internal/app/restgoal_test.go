@@ -0,0 +1,28 @@
+package app
+
+import "testing"
+
+func TestRestGoalJustReached(t *testing.T) {
+	const today, yesterday = "2025-06-04", "2025-06-03"
+
+	tests := []struct {
+		name        string
+		rest, goal  int
+		reportedDay string
+		want        bool
+	}{
+		{"no goal", 10, 0, "", false},
+		{"below the goal", 6, 7, yesterday, false},
+		{"goal reached", 7, 7, yesterday, true},
+		{"goal passed", 9, 7, "", true},
+		{"already reported today", 9, 7, today, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := restGoalJustReached(tt.rest, tt.goal, tt.reportedDay, today); got != tt.want {
+				t.Errorf("restGoalJustReached(%d, %d) = %t, want %t", tt.rest, tt.goal, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/notify"
+	"github.com/siegfried/2020rule/internal/stats"
+)
+
+const (
+	// reviewNotificationPrefix identifies daily review notifications
+	reviewNotificationPrefix = "review:"
+	// reviewCheckInterval is how often the daily review time is checked
+	reviewCheckInterval = 1 * time.Minute
+	// reviewDateLayout is the format of LastDailyReview
+	reviewDateLayout = "2006-01-02"
+)
+
+// shouldShowReview returns whether the daily review is due at now: the
+// review time has passed today and no review was shown today yet
+func shouldShowReview(now time.Time, hour, minute int, lastReview string) bool {
+	if lastReview == now.Format(reviewDateLayout) {
+		return false
+	}
+	reviewAt := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	return !now.Before(reviewAt)
+}
+
+// startDailyReview periodically checks whether the daily review is due
+func (a *App) startDailyReview() {
+	a.reviewTicker = time.NewTicker(reviewCheckInterval)
+	ticker := a.reviewTicker
+
+	go func() {
+		a.checkDailyReview()
+		for {
+			select {
+			case <-ticker.C:
+				a.checkDailyReview()
+			case <-a.reviewStop:
+				return
+			}
+		}
+	}()
+}
+
+// stopDailyReview stops the daily review checks
+func (a *App) stopDailyReview() {
+	if a.reviewTicker == nil {
+		return
+	}
+	a.reviewTicker.Stop()
+	close(a.reviewStop)
+	a.reviewTicker = nil
+}
+
+// checkDailyReview shows today's review if it is due. Days without any
+// breaks are skipped.
+func (a *App) checkDailyReview() {
+	cfg := a.configManager.Get()
+	if cfg.DailyReviewTime == "" {
+		return
+	}
+	hour, minute, err := config.ParseTimeOfDay(cfg.DailyReviewTime)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	if !shouldShowReview(now, hour, minute, cfg.LastDailyReview) {
+		return
+	}
+
+	report, err := a.statsStore.GetComplianceReport(stats.PeriodToday, stats.ComplianceMode(cfg.ComplianceMode))
+	if err != nil {
+		log.Printf("Warning: failed to load daily review: %v", err)
+		return
+	}
+	if report.TotalBreaks == 0 {
+		return
+	}
+
+	streak, freezesUsed, err := a.statsStore.GetCurrentStreak(cfg.DailyComplianceGoal)
+	if err != nil {
+		log.Printf("Warning: failed to load streak: %v", err)
+	}
+
+	message := fmt.Sprintf("Heute: %d/%d Pausen (%.0f%%)",
+		report.CompletedBreaks,
+		report.TotalBreaks,
+		report.ComplianceRate)
+	switch {
+	case streak == 1:
+		message += " · Serie: 1 Tag"
+	case streak > 1:
+		message += fmt.Sprintf(" · Serie: %d Tage", streak)
+	}
+	if streak > 0 && cfg.StreakFreezes > 0 {
+		message += fmt.Sprintf(" (%d/%d Joker genutzt)", freezesUsed, cfg.StreakFreezes)
+	}
+
+	a.notifications.Notify(menuet.Notification{
+		Title:      "Tagesrückblick",
+		Message:    message,
+		Identifier: reviewNotificationPrefix + now.Format(reviewDateLayout),
+	}, notify.PriorityLow)
+
+	// Remember the review so restarts don't show it again
+	cfg.LastDailyReview = now.Format(reviewDateLayout)
+	if err := a.configManager.Update(cfg); err != nil {
+		log.Printf("Warning: failed to save daily review date: %v", err)
+	}
+}
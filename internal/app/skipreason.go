@@ -0,0 +1,68 @@
+package app
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/notify"
+	"github.com/siegfried/2020rule/internal/stats"
+)
+
+// skipReasonNotificationPrefix identifies "why did you skip" notifications
+const skipReasonNotificationPrefix = "skipreason:"
+
+// skipReasonWords maps the answers to a skip reason notification, by number
+// or keyword, to the reason they stand for
+var skipReasonWords = map[string]stats.SkipReason{
+	"1":        stats.SkipReasonMeeting,
+	"meeting":  stats.SkipReasonMeeting,
+	"2":        stats.SkipReasonDeadline,
+	"deadline": stats.SkipReasonDeadline,
+	"frist":    stats.SkipReasonDeadline,
+	"3":        stats.SkipReasonJustBecause,
+	"einfach":  stats.SkipReasonJustBecause,
+}
+
+// parseSkipReason returns the skip reason the reply names by its number or
+// first word, e.g. "2" or "Deadline morgen"
+func parseSkipReason(reply string) (stats.SkipReason, bool) {
+	words := strings.Fields(strings.ToLower(reply))
+	if len(words) == 0 {
+		return "", false
+	}
+	reason, ok := skipReasonWords[strings.Trim(words[0], ".,:")]
+	return reason, ok
+}
+
+// maybeAskSkipReason asks why a break was skipped if AskSkipReason is enabled
+func (a *App) maybeAskSkipReason(breakID int64) {
+	if breakID <= 0 || !a.configManager.Get().AskSkipReason {
+		return
+	}
+
+	a.notifications.Notify(menuet.Notification{
+		Title:               "Warum hast du die Pause übersprungen?",
+		Message:             "1 Meeting, 2 Deadline, 3 einfach so",
+		ResponsePlaceholder: "1–3",
+		Identifier:          skipReasonNotificationPrefix + strconv.FormatInt(breakID, 10),
+	}, notify.PriorityLow)
+}
+
+// handleSkipReasonResponse stores the reason replied to a skip reason notification
+func (a *App) handleSkipReasonResponse(id, response string) {
+	breakID, err := strconv.ParseInt(strings.TrimPrefix(id, skipReasonNotificationPrefix), 10, 64)
+	if err != nil {
+		return
+	}
+	reason, ok := parseSkipReason(response)
+	if !ok {
+		log.Printf("Ignoring skip reason %q", response)
+		return
+	}
+
+	if err := a.statsStore.RecordSkipReason(breakID, reason); err != nil {
+		log.Printf("Warning: failed to record skip reason: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/siegfried/2020rule/internal/stats"
+)
+
+func TestParseSkipReason(t *testing.T) {
+	tests := []struct {
+		reply  string
+		want   stats.SkipReason
+		wantOK bool
+	}{
+		{"1", stats.SkipReasonMeeting, true},
+		{"Meeting", stats.SkipReasonMeeting, true},
+		{" 2 ", stats.SkipReasonDeadline, true},
+		{"Deadline morgen", stats.SkipReasonDeadline, true},
+		{"Frist.", stats.SkipReasonDeadline, true},
+		{"3", stats.SkipReasonJustBecause, true},
+		{"einfach so", stats.SkipReasonJustBecause, true},
+		{"", "", false},
+		{"4", "", false},
+		{"keine Lust", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reply, func(t *testing.T) {
+			got, ok := parseSkipReason(tt.reply)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseSkipReason(%q) = %q, %t, want %q, %t", tt.reply, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
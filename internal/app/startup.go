@@ -0,0 +1,30 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/notify"
+)
+
+const (
+	// degradedNotificationPrefix identifies notifications about features
+	// that failed to start
+	degradedNotificationPrefix = "degraded:"
+	// idleUnavailableMessage tells the user idle auto-pause is off because
+	// the idle time can't be read
+	idleUnavailableMessage = "Aktivitätserkennung nicht verfügbar – Auto-Pause deaktiviert"
+	// apiUnavailableMessage tells the user the local HTTP API didn't start
+	apiUnavailableMessage = "Lokale API nicht verfügbar – Port belegt?"
+)
+
+// notifyDegraded tells the user which features are unavailable because
+// their component failed to start
+func (a *App) notifyDegraded(problems []string) {
+	a.notifications.Notify(menuet.Notification{
+		Title:      "Einige Funktionen sind nicht verfügbar",
+		Message:    strings.Join(problems, "\n"),
+		Identifier: degradedNotificationPrefix + time.Now().Format(time.RFC3339),
+	}, notify.PriorityNormal)
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"log"
+
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/macos/foundation"
+)
+
+// workspaceDidWake is posted by NSWorkspace after the Mac woke from sleep
+const workspaceDidWake foundation.NotificationName = "NSWorkspaceDidWakeNotification"
+
+// observeWake lets the timer catch up with the time slept after each wake
+func (a *App) observeWake() {
+	appkit.Workspace_SharedWorkspace().NotificationCenter().AddObserverForNameObjectQueueUsingBlock(
+		workspaceDidWake,
+		nil,
+		foundation.OperationQueue_MainQueue(),
+		func(notification foundation.Notification) {
+			log.Println("System woke from sleep")
+			a.timerManager.HandleWake()
+		},
+	)
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/timer"
+	"github.com/siegfried/2020rule/internal/webhook"
+)
+
+// openWebhook switches the webhook to url, closing the previous sender if
+// the URL changed. An empty URL disables the webhook.
+func (a *App) openWebhook(url string) {
+	a.webhookMu.Lock()
+	previous := a.webhook
+	if previous != nil && previous.URL() == url {
+		a.webhookMu.Unlock()
+		return
+	}
+
+	a.webhook = nil
+	if url != "" {
+		a.webhook = webhook.NewSender(url, http.DefaultClient)
+		a.webhook.Start()
+	}
+	a.webhookMu.Unlock()
+
+	// Close waits for a delivery in flight, which mustn't hold up sendWebhook
+	if previous != nil {
+		previous.Close()
+	}
+}
+
+// sendWebhook queues payload for the webhook if enabled
+func (a *App) sendWebhook(payload webhook.Payload) {
+	a.webhookMu.Lock()
+	defer a.webhookMu.Unlock()
+
+	if a.webhook == nil {
+		return
+	}
+	if err := a.webhook.Send(payload); err != nil {
+		log.Printf("Warning: dropped webhook %s: %v", payload.Event, err)
+	}
+}
+
+// sendWebhookEvent forwards completed and skipped breaks to the webhook
+func (a *App) sendWebhookEvent(event timer.Event) {
+	var name string
+	switch event.Type {
+	case timer.EventBreakCompleted:
+		name = webhook.EventBreakCompleted
+	case timer.EventBreakSkipped:
+		name = webhook.EventBreakSkipped
+	default:
+		return
+	}
+
+	a.sendWebhook(webhook.Payload{
+		Event:   name,
+		Time:    event.Time,
+		BreakID: event.BreakID,
+	})
+}
+
+// sendDailySummary sends the final stats of day to the webhook
+func (a *App) sendDailySummary(day time.Time) {
+	daily, err := a.statsStore.GetDailyStats(day)
+	if err != nil {
+		log.Printf("Warning: failed to load daily summary for webhook: %v", err)
+		return
+	}
+
+	a.sendWebhook(webhook.Payload{
+		Event: webhook.EventDailySummary,
+		Time:  time.Now(),
+		Summary: &webhook.Summary{
+			Date:            day.Format("2006-01-02"),
+			BreaksRequired:  daily.BreaksRequired,
+			BreaksCompleted: daily.BreaksCompleted,
+			BreaksSkipped:   daily.BreaksSkipped,
+			ComplianceRate:  daily.ComplianceRate,
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/notify"
+)
+
+// welcomeNotificationPrefix identifies "welcome back" notifications
+const welcomeNotificationPrefix = "welcome:"
+
+// notifyResumedFromIdle tells the user the timer kept its progress while
+// they were away and offers to start the interval over
+func (a *App) notifyResumedFromIdle(away time.Duration) {
+	if !a.configManager.Get().NotifyOnResumeFromIdle {
+		return
+	}
+
+	a.notifications.Notify(menuet.Notification{
+		Title:        "Willkommen zurück – Timer läuft weiter",
+		Message:      fmt.Sprintf("Du warst %s weg.", formatAway(away)),
+		ActionButton: "Neu starten",
+		Identifier:   welcomeNotificationPrefix + time.Now().Format(time.RFC3339),
+	}, notify.PriorityNormal)
+}
+
+// handleWelcomeResponse restarts the work interval from a "welcome back" notification
+func (a *App) handleWelcomeResponse() {
+	a.timerManager.RestartInterval()
+}
+
+// formatAway formats how long the user was away, e.g. "1 Std. 5 Min."
+func formatAway(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%d Min.", minutes)
+	}
+	return fmt.Sprintf("%d Std. %d Min.", minutes/60, minutes%60)
+}
@@ -0,0 +1,98 @@
+package brightness
+
+import (
+	"errors"
+	"log"
+	"sync"
+)
+
+// DisplayID identifies a display, a CGDirectDisplayID on macOS
+type DisplayID uint32
+
+// Backend reads and changes the brightness of displays
+type Backend interface {
+	// Displays lists the active displays
+	Displays() ([]DisplayID, error)
+	// Get returns the brightness of display between 0 and 1. It returns
+	// ErrUnsupported if the display's brightness can't be read.
+	Get(display DisplayID) (float64, error)
+	// Set changes the brightness of display to level between 0 and 1
+	Set(display DisplayID, level float64) error
+}
+
+// Dimmer lowers the brightness of all displays for a break and restores
+// the brightness each had before afterwards
+type Dimmer struct {
+	backend Backend
+	saved   map[DisplayID]float64 // Brightness before dimming, nil while not dimmed
+	mu      sync.Mutex
+}
+
+// NewDimmer creates a dimmer using backend
+func NewDimmer(backend Backend) *Dimmer {
+	return &Dimmer{backend: backend}
+}
+
+// Dim saves the brightness of every display that supports it and lowers it
+// to level. Displays whose brightness can't be read are left alone. While
+// already dimmed, only the level changes and the saved brightness is kept.
+func (d *Dimmer) Dim(level float64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.saved != nil {
+		for display := range d.saved {
+			d.set(display, level)
+		}
+		return
+	}
+
+	displays, err := d.backend.Displays()
+	if err != nil {
+		log.Printf("Warning: failed to list displays for dimming: %v", err)
+		return
+	}
+
+	d.saved = make(map[DisplayID]float64, len(displays))
+	for _, display := range displays {
+		current, err := d.backend.Get(display)
+		if err != nil {
+			if !errors.Is(err, ErrUnsupported) {
+				log.Printf("Warning: failed to read brightness of display %d: %v", display, err)
+			}
+			continue
+		}
+		// Never brighten a display that is already darker than the break level
+		if current <= level {
+			continue
+		}
+		d.saved[display] = current
+		d.set(display, level)
+	}
+}
+
+// Restore sets every dimmed display back to the brightness it had before
+// Dim. It does nothing while not dimmed.
+func (d *Dimmer) Restore() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for display, level := range d.saved {
+		d.set(display, level)
+	}
+	d.saved = nil
+}
+
+// Dimmed reports whether the displays are currently dimmed for a break
+func (d *Dimmer) Dimmed() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.saved != nil
+}
+
+// set changes the brightness of display, logging failures
+func (d *Dimmer) set(display DisplayID, level float64) {
+	if err := d.backend.Set(display, level); err != nil {
+		log.Printf("Warning: failed to set brightness of display %d: %v", display, err)
+	}
+}
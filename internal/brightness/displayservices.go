@@ -0,0 +1,104 @@
+package brightness
+
+/*
+#cgo LDFLAGS: -framework CoreGraphics
+#include <CoreGraphics/CoreGraphics.h>
+#include <dlfcn.h>
+
+// DisplayServices is a private framework, so its functions are looked up at
+// runtime instead of being linked
+typedef int (*displayServicesGetBrightnessFunc)(CGDirectDisplayID, float *);
+typedef int (*displayServicesSetBrightnessFunc)(CGDirectDisplayID, float);
+
+static displayServicesGetBrightnessFunc brightnessGetFunc;
+static displayServicesSetBrightnessFunc brightnessSetFunc;
+
+// Returns 1 if the DisplayServices functions are available
+static int brightnessLoad(void) {
+	if (brightnessGetFunc != NULL && brightnessSetFunc != NULL) {
+		return 1;
+	}
+	void *handle = dlopen("/System/Library/PrivateFrameworks/DisplayServices.framework/DisplayServices", RTLD_LAZY);
+	if (handle == NULL) {
+		return 0;
+	}
+	brightnessGetFunc = (displayServicesGetBrightnessFunc)dlsym(handle, "DisplayServicesGetBrightness");
+	brightnessSetFunc = (displayServicesSetBrightnessFunc)dlsym(handle, "DisplayServicesSetBrightness");
+	return brightnessGetFunc != NULL && brightnessSetFunc != NULL;
+}
+
+// Writes up to max active display IDs to ids and returns how many there
+// are, or -1 on failure
+static int brightnessDisplays(CGDirectDisplayID *ids, uint32_t max) {
+	uint32_t count = 0;
+	if (CGGetActiveDisplayList(max, ids, &count) != kCGErrorSuccess) {
+		return -1;
+	}
+	return (int)count;
+}
+
+// Returns the brightness of display between 0 and 1, or -1 if unsupported
+static float brightnessGet(CGDirectDisplayID display) {
+	if (!brightnessLoad()) {
+		return -1;
+	}
+	float level = 0;
+	if (brightnessGetFunc(display, &level) != 0) {
+		return -1;
+	}
+	return level;
+}
+
+// Returns 1 if the brightness of display was changed
+static int brightnessSet(CGDirectDisplayID display, float level) {
+	if (!brightnessLoad()) {
+		return 0;
+	}
+	return brightnessSetFunc(display, level) == 0;
+}
+*/
+import "C"
+
+// maxDisplays is the most displays that are dimmed
+const maxDisplays = 16
+
+// displayServicesBackend changes the brightness of built-in and Apple
+// displays through the private DisplayServices framework
+type displayServicesBackend struct{}
+
+// NewDisplayServicesBackend creates a Backend backed by DisplayServices
+func NewDisplayServicesBackend() Backend {
+	return displayServicesBackend{}
+}
+
+// Displays lists the active displays from CoreGraphics
+func (displayServicesBackend) Displays() ([]DisplayID, error) {
+	var ids [maxDisplays]C.CGDirectDisplayID
+	count := C.brightnessDisplays(&ids[0], maxDisplays)
+	if count < 0 {
+		return nil, ErrNoDisplays
+	}
+
+	displays := make([]DisplayID, 0, int(count))
+	for _, id := range ids[:count] {
+		displays = append(displays, DisplayID(id))
+	}
+	return displays, nil
+}
+
+// Get reads the brightness of display
+func (displayServicesBackend) Get(display DisplayID) (float64, error) {
+	level := C.brightnessGet(C.CGDirectDisplayID(display))
+	if level < 0 {
+		return 0, ErrUnsupported
+	}
+	return float64(level), nil
+}
+
+// Set changes the brightness of display
+func (displayServicesBackend) Set(display DisplayID, level float64) error {
+	if C.brightnessSet(C.CGDirectDisplayID(display), C.float(level)) == 0 {
+		return ErrUnsupported
+	}
+	return nil
+}
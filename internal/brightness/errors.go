@@ -0,0 +1,12 @@
+package brightness
+
+import "errors"
+
+var (
+	// ErrUnsupported is returned when the brightness of a display can't be
+	// read or changed, e.g. for most external displays
+	ErrUnsupported = errors.New("display brightness not supported")
+
+	// ErrNoDisplays is returned when the active displays can't be listed
+	ErrNoDisplays = errors.New("no displays found")
+)
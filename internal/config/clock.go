@@ -0,0 +1,18 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeOfDayLayout is the format of times of day in the config, e.g. "18:00"
+const timeOfDayLayout = "15:04"
+
+// ParseTimeOfDay parses a time of day in HH:MM format
+func ParseTimeOfDay(s string) (hour, minute int, err error) {
+	t, err := time.Parse(timeOfDayLayout, s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time of day %q: %w", s, err)
+	}
+	return t.Hour(), t.Minute(), nil
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ParseHexColor parses a color in #RRGGBB format into its red, green and
+// blue components, each 0-1
+func ParseHexColor(s string) (r, g, b float64, err error) {
+	if len(s) != 7 || s[0] != '#' {
+		return 0, 0, 0, fmt.Errorf("invalid hex color %q: expected #RRGGBB", s)
+	}
+	value, err := strconv.ParseUint(s[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid hex color %q: %w", s, err)
+	}
+	return float64(value>>16&0xff) / 255, float64(value>>8&0xff) / 255, float64(value&0xff) / 255, nil
+}
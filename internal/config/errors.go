@@ -15,9 +15,151 @@ var (
 	// ErrInvalidOpacity is returned when overlay opacity is not between 0.0 and 1.0
 	ErrInvalidOpacity = errors.New("overlay opacity must be between 0.0 and 1.0")
 
+	// ErrInvalidBreakStyle is returned when the break style is unknown
+	ErrInvalidBreakStyle = errors.New("break style must be \"overlay\" or \"notification\"")
+
+	// ErrInvalidBreakType is returned when the break type is unknown
+	ErrInvalidBreakType = errors.New("break type must be \"standard\" or \"breathing\"")
+
+	// ErrInvalidBreathingPacing is returned when a breathing phase is shorter than 1 second
+	ErrInvalidBreathingPacing = errors.New("breathing phases must be at least 1 second")
+
+	// ErrInvalidBreakSequence is returned when a sequence step is empty or the fractions don't add up to 1.0
+	ErrInvalidBreakSequence = errors.New("break sequence steps need text and fractions that sum to 1.0")
+
+	// ErrInvalidOverlayWindowLevel is returned when the overlay window level is unknown
+	ErrInvalidOverlayWindowLevel = errors.New("overlay window level must be \"screensaver\", \"floating\" or \"normal\"")
+
+	// ErrInvalidOverlayMode is returned when the overlay mode is unknown
+	ErrInvalidOverlayMode = errors.New("overlay mode must be \"fullscreen\" or \"banner\"")
+
+	// ErrInvalidBannerCorner is returned when the banner corner is unknown
+	ErrInvalidBannerCorner = errors.New("banner corner must be \"top_left\", \"top_right\", \"bottom_left\" or \"bottom_right\"")
+
+	// ErrInvalidComplianceGoal is returned when the daily compliance goal is not between 0 and 100
+	ErrInvalidComplianceGoal = errors.New("daily compliance goal must be between 0 and 100")
+
+	// ErrInvalidRestGoal is returned when the daily rest goal is negative
+	ErrInvalidRestGoal = errors.New("daily rest goal must not be negative")
+
+	// ErrInvalidMinBreakFraction is returned when the minimum break fraction is not between 0.0 and 1.0
+	ErrInvalidMinBreakFraction = errors.New("min break fraction must be between 0.0 and 1.0")
+
+	// ErrInvalidPreBreakDim is returned when the pre-break dimming is negative
+	// or not shorter than the work duration
+	ErrInvalidPreBreakDim = errors.New("pre-break dim duration must be at least 0 and shorter than the work duration")
+
+	// ErrInvalidPreBreakWarning is returned when the pre-break warning is
+	// negative or not shorter than the work duration
+	ErrInvalidPreBreakWarning = errors.New("pre-break warning must be at least 0 and shorter than the work duration")
+
+	// ErrInvalidDailyReviewTime is returned when the daily review time is not in HH:MM format
+	ErrInvalidDailyReviewTime = errors.New("daily review time must be in HH:MM format")
+
+	// ErrInvalidMaxNotificationsPerHour is returned when the notification cap is negative
+	ErrInvalidMaxNotificationsPerHour = errors.New("max notifications per hour must not be negative")
+
+	// ErrInvalidAskRatingEvery is returned when the rating interval is negative
+	ErrInvalidAskRatingEvery = errors.New("ask rating every must not be negative")
+
+	// ErrInvalidPostponeCounts is returned when the postpone counting mode is unknown
+	ErrInvalidPostponeCounts = errors.New("postpone counts must be neutral, partial or skip")
+
+	// ErrInvalidComplianceMode is returned when the compliance mode is unknown
+	ErrInvalidComplianceMode = errors.New("compliance mode must be of_required or of_decided")
+
+	// ErrInvalidWeekStart is returned when the week doesn't start on Monday or Sunday
+	ErrInvalidWeekStart = errors.New("week starts on must be monday or sunday")
+
+	// ErrInvalidWeekendWorkDuration is returned when the weekend work duration is set but too short
+	ErrInvalidWeekendWorkDuration = errors.New("weekend work duration must be 0 or at least 1 minute")
+
+	// ErrInvalidInitialDelay is returned when the initial delay shortens the first work interval below the minimum
+	ErrInvalidInitialDelay = errors.New("initial delay must leave a first work interval of at least the minimum work duration")
+
+	// ErrInvalidMaxExtension is returned when the work interval extension cap is negative
+	ErrInvalidMaxExtension = errors.New("max extension must not be negative")
+
+	// ErrInvalidResetAfterPause is returned when the pause length that restarts the work interval is negative
+	ErrInvalidResetAfterPause = errors.New("reset after pause must not be negative")
+
+	// ErrInvalidIntervalJitter is returned when the interval jitter is
+	// negative or could shorten the work interval below the minimum
+	ErrInvalidIntervalJitter = errors.New("interval jitter must be at least 0 and keep the work duration above the minimum")
+
+	// ErrInvalidAdaptiveInterval is returned when the adaptive interval bounds are too short or reversed
+	ErrInvalidAdaptiveInterval = errors.New("adaptive interval bounds need a valid minimum work duration no larger than the maximum")
+
+	// ErrInvalidLongSessionThreshold is returned when the long session threshold is set but shorter than a work interval
+	ErrInvalidLongSessionThreshold = errors.New("long session threshold must be 0 or at least the work duration")
+
+	// ErrInvalidMaxSession is returned when the maximum session length is set but shorter than a work interval
+	ErrInvalidMaxSession = errors.New("maximum session length must be 0 or at least the work duration")
+
+	// ErrInvalidSessionCooldown is returned when the session cooldown is negative or longer than MaxSessionCooldown
+	ErrInvalidSessionCooldown = errors.New("session cooldown must be between 0 and 5 minutes")
+
+	// ErrInvalidSoundVolume is returned when the sound volume is outside 0-1
+	ErrInvalidSoundVolume = errors.New("sound volume must be between 0 and 1")
+
+	// ErrInvalidBreakBrightnessLevel is returned when the break brightness level is outside 0-1
+	ErrInvalidBreakBrightnessLevel = errors.New("break brightness level must be between 0 and 1")
+
+	// ErrInvalidSoundName is returned when the break start sound is neither the name of an installed sound nor the absolute path of a sound file
+	ErrInvalidSoundName = errors.New("break start sound must name an installed sound or be the absolute path of a sound file")
+
+	// ErrInvalidMessagesFile is returned when the overlay messages file is not an absolute path
+	ErrInvalidMessagesFile = errors.New("overlay messages file must be empty or an absolute path")
+
+	// ErrInvalidOverlayClickAction is returned when the overlay click action is unknown
+	ErrInvalidOverlayClickAction = errors.New("overlay click action must be \"none\", \"complete\" or \"postpone\"")
+
+	// ErrInvalidCadenceSchedule is returned when a cadence window has invalid hours or a too short work duration
+	ErrInvalidCadenceSchedule = errors.New("cadence windows need start hour < end hour within 0-24 and a valid work duration")
+
+	// ErrInvalidOverlayTheme is returned when an overlay theme color is not a #RRGGBB hex color
+	ErrInvalidOverlayTheme = errors.New("overlay theme backgrounds must be hex colors like #1a2b3c")
+
+	// ErrInvalidDeferInApps is returned when an app to defer breaks in has no bundle ID or the maximum deferral isn't positive
+	ErrInvalidDeferInApps = errors.New("apps to defer breaks in need a bundle id and a maximum deferral above 0")
+
+	// ErrInvalidPerAppWorkDuration is returned when a per-app work duration has no bundle ID or is too short
+	ErrInvalidPerAppWorkDuration = errors.New("per-app work durations need a bundle id and a valid work duration")
+
+	// ErrInvalidRemindersWindow is returned when only one end of the reminders window is set, either is not in HH:MM format or both are equal
+	ErrInvalidRemindersWindow = errors.New("start and stop reminders at must both be empty or different times in HH:MM format")
+
+	// ErrInvalidWebhookURL is returned when the webhook URL is set but not an http or https URL
+	ErrInvalidWebhookURL = errors.New("webhook url must be empty or an http or https url")
+
+	// ErrInvalidAPIPort is returned when the API port is not a valid TCP port
+	ErrInvalidAPIPort = errors.New("api port must be between 0 and 65535")
+
+	// ErrInvalidStreakFreezes is returned when the number of streak freezes is negative
+	ErrInvalidStreakFreezes = errors.New("streak freezes must not be negative")
+
+	// ErrInvalidRetentionDays is returned when the retention period is negative
+	ErrInvalidRetentionDays = errors.New("retention days must not be negative")
+
+	// ErrUnknownField is returned when a config patch contains an unknown key
+	ErrUnknownField = errors.New("unknown config field")
+
+	// ErrInvalidFieldType is returned when a config patch value has the wrong type
+	ErrInvalidFieldType = errors.New("invalid type for config field")
+
+	// ErrFileOnlyField is returned when a config patch changes a field that
+	// can only be set in config.json
+	ErrFileOnlyField = errors.New("config field can only be changed in config.json")
+
+	// ErrInvalidPatch is returned when a config patch is not a JSON object
+	ErrInvalidPatch = errors.New("config patch must be a JSON object")
+
 	// ErrConfigNotFound is returned when the config file doesn't exist
 	ErrConfigNotFound = errors.New("config file not found")
 
+	// ErrCorruptConfig is returned when the config file can't be parsed or holds invalid values
+	ErrCorruptConfig = errors.New("corrupt config file")
+
 	// ErrConfigDirCreation is returned when the config directory cannot be created
 	ErrConfigDirCreation = errors.New("failed to create config directory")
 )
@@ -0,0 +1,641 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// ToJSONMap converts the configuration into the JSON-friendly format used
+// by config.json, with durations stored as minutes or seconds
+func (c *Config) ToJSONMap() map[string]interface{} {
+	stepsDone := c.OnboardingStepsDone
+	if stepsDone == nil {
+		stepsDone = []string{}
+	}
+	deferInApps := c.DeferInApps
+	if deferInApps == nil {
+		deferInApps = []string{}
+	}
+
+	sequence := make([]map[string]interface{}, 0, len(c.BreakSequence))
+	for _, step := range c.BreakSequence {
+		sequence = append(sequence, map[string]interface{}{
+			"text":     step.Text,
+			"fraction": step.Fraction,
+		})
+	}
+
+	cadence := make([]map[string]interface{}, 0, len(c.CadenceSchedule))
+	for _, window := range c.CadenceSchedule {
+		cadence = append(cadence, map[string]interface{}{
+			"start_hour":            window.StartHour,
+			"end_hour":              window.EndHour,
+			"work_duration_minutes": durationToMinutes(window.WorkDuration),
+		})
+	}
+
+	themes := make(map[string]interface{}, len(c.OverlayThemes))
+	for kind, theme := range c.OverlayThemes {
+		themes[kind] = map[string]interface{}{
+			"background": theme.Background,
+			"message":    theme.Message,
+		}
+	}
+
+	perApp := make(map[string]float64, len(c.PerAppWorkDuration))
+	for bundleID, duration := range c.PerAppWorkDuration {
+		perApp[bundleID] = durationToMinutes(duration)
+	}
+
+	return map[string]interface{}{
+		"work_duration_minutes":   durationToMinutes(c.WorkDuration),
+		"break_duration_seconds":  durationToSeconds(c.BreakDuration),
+		"idle_threshold_minutes":  durationToMinutes(c.IdleThreshold),
+		"auto_start_on_login":     c.AutoStartOnLogin,
+		"pause_on_fullscreen_app": c.PauseOnFullscreen,
+		"notification_sound":      c.NotificationSound,
+		"overlay_opacity":         c.OverlayOpacity,
+		"first_run":               c.FirstRun,
+
+		"sound_volume":      c.SoundVolume,
+		"break_start_sound": c.BreakStartSound,
+
+		"break_style": c.BreakStyle,
+		"break_type":  c.BreakType,
+		"breathing_pacing_seconds": map[string]interface{}{
+			"inhale":   durationToSeconds(c.BreathingPacing.Inhale),
+			"hold_in":  durationToSeconds(c.BreathingPacing.HoldIn),
+			"exhale":   durationToSeconds(c.BreathingPacing.Exhale),
+			"hold_out": durationToSeconds(c.BreathingPacing.HoldOut),
+		},
+		"break_sequence":        sequence,
+		"overlay_window_level":  c.OverlayWindowLevel,
+		"pause_on_screen_share": c.PauseOnScreenShare,
+		"overlay_mode":          c.OverlayMode,
+		"banner_corner":         c.BannerCorner,
+
+		"overlay_show_next_break":    c.OverlayShowNextBreak,
+		"overlay_active_screen_only": c.OverlayActiveScreenOnly,
+		"gaze_target_exercise":       c.GazeTargetExercise,
+
+		"high_contrast": c.HighContrast,
+
+		"dim_more_at_night": c.DimMoreAtNight,
+
+		"dim_brightness_during_break": c.DimBrightnessDuringBreak,
+		"break_brightness_level":      c.BreakBrightnessLevel,
+
+		"overlay_themes": themes,
+
+		"overlay_messages_file": c.OverlayMessagesFile,
+
+		"overlay_click_action": c.OverlayClickAction,
+
+		"rebuild_overlay_on_screen_change": c.RebuildOverlayOnScreenChange,
+
+		"allow_early_dismiss": c.AllowEarlyDismiss,
+		"min_break_fraction":  c.MinBreakFraction,
+
+		"pre_break_dim_seconds": durationToSeconds(c.PreBreakDimDuration),
+
+		"pre_break_warning_seconds": durationToSeconds(c.PreBreakWarning),
+
+		"gentle_first_break":                c.GentleFirstBreak,
+		"defer_breaks_during_presentations": c.DeferBreaksDuringPresentations,
+
+		"defer_in_apps":            deferInApps,
+		"max_app_deferral_minutes": durationToMinutes(c.MaxAppDeferral),
+
+		"initial_delay_minutes": durationToMinutes(c.InitialDelay),
+
+		"request_permissions_on_start": c.RequestPermissionsOnStart,
+
+		"max_extension_minutes": durationToMinutes(c.MaxExtension),
+
+		"interval_jitter_minutes": durationToMinutes(c.IntervalJitter),
+
+		"adaptive_interval":             c.AdaptiveInterval,
+		"adaptive_min_interval_minutes": durationToMinutes(c.AdaptiveMinInterval),
+		"adaptive_max_interval_minutes": durationToMinutes(c.AdaptiveMaxInterval),
+
+		"long_session_threshold_minutes": durationToMinutes(c.LongSessionThreshold),
+
+		"max_session_hours":        c.MaxSessionHours,
+		"session_cooldown_minutes": durationToMinutes(c.SessionCooldown),
+
+		"idle_auto_pause_enabled": c.IdleAutoPauseEnabled,
+
+		"catch_up_breaks": c.CatchUpBreaks,
+
+		"reset_after_pause_minutes": durationToMinutes(c.ResetAfterPauseLongerThan),
+
+		"pause_on_battery": c.PauseOnBattery,
+
+		"cadence_schedule": cadence,
+
+		"per_app_work_duration_minutes": perApp,
+
+		"start_reminders_at": c.StartRemindersAt,
+		"stop_reminders_at":  c.StopRemindersAt,
+
+		"weekend_work_duration_minutes": durationToMinutes(c.WeekendWorkDuration),
+		"weekend_breaks_enabled":        c.WeekendBreaksEnabled,
+
+		"verify_gaze_away":               c.VerifyGazeAway,
+		"infer_completion_from_activity": c.InferCompletionFromActivity,
+
+		"api_port": c.APIPort,
+
+		"webhook_url": c.WebhookURL,
+
+		"on_break_start_command": c.OnBreakStartCommand,
+		"on_break_end_command":   c.OnBreakEndCommand,
+
+		"pause_hotkey": c.PauseHotkey,
+
+		"daily_review_time": c.DailyReviewTime,
+		"last_daily_review": c.LastDailyReview,
+
+		"notify_on_resume_from_idle": c.NotifyOnResumeFromIdle,
+		"max_notifications_per_hour": c.MaxNotificationsPerHour,
+		"show_catch_up_summary":      c.ShowCatchUpSummary,
+
+		"ask_rating_every": c.AskRatingEvery,
+		"ask_skip_reason":  c.AskSkipReason,
+
+		"daily_compliance_goal": c.DailyComplianceGoal,
+		"postpone_counts":       c.PostponeCounts,
+		"compliance_mode":       c.ComplianceMode,
+		"week_starts_on":        c.WeekStartsOn,
+
+		"daily_rest_goal_minutes": c.DailyRestGoalMinutes,
+
+		"streak_freezes": c.StreakFreezes,
+
+		"retention_days": c.RetentionDays,
+
+		"event_log_path": c.EventLogPath,
+
+		"onboarding_steps_done": stepsDone,
+	}
+}
+
+// fileOnlyFields are the keys Patch refuses to change because their values
+// are run as shell commands, name files to write or read, or receive every
+// event. They can only be set by editing config.json.
+var fileOnlyFields = map[string]bool{
+	"on_break_start_command": true,
+	"on_break_end_command":   true,
+	"event_log_path":         true,
+	"overlay_messages_file":  true,
+	"webhook_url":            true,
+}
+
+// nestedObject describes the objects nested in a config value by the type
+// of each of their keys
+type nestedObject struct {
+	byName bool // The value maps names to objects instead of being one
+	fields map[string]interface{}
+}
+
+// nestedObjects are the config values holding objects, directly or in a list
+// or a map, so Patch can check the keys inside them too
+var nestedObjects = map[string]nestedObject{
+	"breathing_pacing_seconds": {fields: map[string]interface{}{"inhale": 0.0, "hold_in": 0.0, "exhale": 0.0, "hold_out": 0.0}},
+	"break_sequence":           {fields: map[string]interface{}{"text": "", "fraction": 0.0}},
+	"cadence_schedule":         {fields: map[string]interface{}{"start_hour": 0.0, "end_hour": 0.0, "work_duration_minutes": 0.0}},
+	"overlay_themes":           {byName: true, fields: map[string]interface{}{"background": "", "message": ""}},
+}
+
+// Patch applies a partial JSON document using the same keys as config.json.
+// Unknown keys, also inside nested objects, fileOnlyFields and values of the
+// wrong type are rejected, and the result must pass validation with the
+// ActiveLimits. On error the config may be partially modified, so callers
+// should patch a Clone.
+func (c *Config) Patch(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	known := c.ToJSONMap()
+	for key, value := range raw {
+		current, ok := known[key]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownField, key)
+		}
+		if fileOnlyFields[key] {
+			return fmt.Errorf("%w: %s", ErrFileOnlyField, key)
+		}
+		if !sameJSONType(current, value) {
+			return fmt.Errorf("%w: %s", ErrInvalidFieldType, key)
+		}
+		if err := checkNestedObjects(key, value); err != nil {
+			return err
+		}
+	}
+
+	c.applyJSONMap(raw)
+	return c.ValidateWithLimits(ActiveLimits())
+}
+
+// Clone returns a deep copy of the configuration
+func (c *Config) Clone() *Config {
+	clone := *c
+	clone.OnboardingStepsDone = append([]string(nil), c.OnboardingStepsDone...)
+	clone.BreakSequence = append([]SequenceStep(nil), c.BreakSequence...)
+	clone.CadenceSchedule = append([]CadenceWindow(nil), c.CadenceSchedule...)
+	clone.DeferInApps = append([]string(nil), c.DeferInApps...)
+	clone.PerAppWorkDuration = maps.Clone(c.PerAppWorkDuration)
+	clone.OverlayThemes = maps.Clone(c.OverlayThemes)
+	return &clone
+}
+
+// applyJSONMap sets every field present in raw. Unknown keys and values of
+// the wrong type are ignored.
+func (c *Config) applyJSONMap(raw map[string]interface{}) {
+	// Parse duration fields (stored as minutes/seconds in JSON)
+	if v, ok := raw["work_duration_minutes"].(float64); ok {
+		c.WorkDuration = minutesToDuration(v)
+	}
+	if v, ok := raw["break_duration_seconds"].(float64); ok {
+		c.BreakDuration = secondsToDuration(v)
+	}
+	if v, ok := raw["idle_threshold_minutes"].(float64); ok {
+		c.IdleThreshold = minutesToDuration(v)
+	}
+	if v, ok := raw["auto_start_on_login"].(bool); ok {
+		c.AutoStartOnLogin = v
+	}
+	if v, ok := raw["pause_on_fullscreen_app"].(bool); ok {
+		c.PauseOnFullscreen = v
+	}
+	if v, ok := raw["notification_sound"].(bool); ok {
+		c.NotificationSound = v
+	}
+	if v, ok := raw["overlay_opacity"].(float64); ok {
+		c.OverlayOpacity = v
+	}
+	if v, ok := raw["first_run"].(bool); ok {
+		c.FirstRun = v
+	}
+	if v, ok := raw["sound_volume"].(float64); ok {
+		c.SoundVolume = v
+	}
+	if v, ok := raw["break_start_sound"].(string); ok {
+		c.BreakStartSound = v
+	}
+	if v, ok := raw["break_style"].(string); ok {
+		c.BreakStyle = v
+	}
+	if v, ok := raw["break_type"].(string); ok {
+		c.BreakType = v
+	}
+	if v, ok := raw["breathing_pacing_seconds"].(map[string]interface{}); ok {
+		if s, ok := v["inhale"].(float64); ok {
+			c.BreathingPacing.Inhale = secondsToDuration(s)
+		}
+		if s, ok := v["hold_in"].(float64); ok {
+			c.BreathingPacing.HoldIn = secondsToDuration(s)
+		}
+		if s, ok := v["exhale"].(float64); ok {
+			c.BreathingPacing.Exhale = secondsToDuration(s)
+		}
+		if s, ok := v["hold_out"].(float64); ok {
+			c.BreathingPacing.HoldOut = secondsToDuration(s)
+		}
+	}
+	if v, ok := raw["break_sequence"].([]interface{}); ok {
+		c.BreakSequence = nil
+		for _, item := range v {
+			item, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var step SequenceStep
+			step.Text, _ = item["text"].(string)
+			step.Fraction, _ = item["fraction"].(float64)
+			c.BreakSequence = append(c.BreakSequence, step)
+		}
+	}
+	if v, ok := raw["overlay_window_level"].(string); ok {
+		c.OverlayWindowLevel = v
+	}
+	if v, ok := raw["pause_on_screen_share"].(bool); ok {
+		c.PauseOnScreenShare = v
+	}
+	if v, ok := raw["overlay_mode"].(string); ok {
+		c.OverlayMode = v
+	}
+	if v, ok := raw["banner_corner"].(string); ok {
+		c.BannerCorner = v
+	}
+	if v, ok := raw["overlay_show_next_break"].(bool); ok {
+		c.OverlayShowNextBreak = v
+	}
+	if v, ok := raw["overlay_active_screen_only"].(bool); ok {
+		c.OverlayActiveScreenOnly = v
+	}
+	if v, ok := raw["gaze_target_exercise"].(bool); ok {
+		c.GazeTargetExercise = v
+	}
+	if v, ok := raw["high_contrast"].(bool); ok {
+		c.HighContrast = v
+	}
+	if v, ok := raw["dim_more_at_night"].(bool); ok {
+		c.DimMoreAtNight = v
+	}
+	if v, ok := raw["dim_brightness_during_break"].(bool); ok {
+		c.DimBrightnessDuringBreak = v
+	}
+	if v, ok := raw["break_brightness_level"].(float64); ok {
+		c.BreakBrightnessLevel = v
+	}
+	if v, ok := raw["overlay_themes"].(map[string]interface{}); ok {
+		c.OverlayThemes = make(map[string]OverlayTheme, len(v))
+		for kind, item := range v {
+			item, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var theme OverlayTheme
+			if background, ok := item["background"].(string); ok {
+				theme.Background = background
+			}
+			if message, ok := item["message"].(string); ok {
+				theme.Message = message
+			}
+			c.OverlayThemes[kind] = theme
+		}
+	}
+	if v, ok := raw["overlay_messages_file"].(string); ok {
+		c.OverlayMessagesFile = v
+	}
+	if v, ok := raw["overlay_click_action"].(string); ok {
+		c.OverlayClickAction = v
+	}
+	if v, ok := raw["rebuild_overlay_on_screen_change"].(bool); ok {
+		c.RebuildOverlayOnScreenChange = v
+	}
+	if v, ok := raw["allow_early_dismiss"].(bool); ok {
+		c.AllowEarlyDismiss = v
+	}
+	if v, ok := raw["min_break_fraction"].(float64); ok {
+		c.MinBreakFraction = v
+	}
+	if v, ok := raw["pre_break_dim_seconds"].(float64); ok {
+		c.PreBreakDimDuration = secondsToDuration(v)
+	}
+	if v, ok := raw["pre_break_warning_seconds"].(float64); ok {
+		c.PreBreakWarning = secondsToDuration(v)
+	}
+	if v, ok := raw["gentle_first_break"].(bool); ok {
+		c.GentleFirstBreak = v
+	}
+	if v, ok := raw["defer_breaks_during_presentations"].(bool); ok {
+		c.DeferBreaksDuringPresentations = v
+	}
+	if v, ok := raw["defer_in_apps"].([]interface{}); ok {
+		c.DeferInApps = nil
+		for _, bundleID := range v {
+			if bundleID, ok := bundleID.(string); ok {
+				c.DeferInApps = append(c.DeferInApps, bundleID)
+			}
+		}
+	}
+	if v, ok := raw["max_app_deferral_minutes"].(float64); ok {
+		c.MaxAppDeferral = minutesToDuration(v)
+	}
+	if v, ok := raw["initial_delay_minutes"].(float64); ok {
+		c.InitialDelay = minutesToDuration(v)
+	}
+	if v, ok := raw["request_permissions_on_start"].(bool); ok {
+		c.RequestPermissionsOnStart = v
+	}
+	if v, ok := raw["max_extension_minutes"].(float64); ok {
+		c.MaxExtension = minutesToDuration(v)
+	}
+	if v, ok := raw["interval_jitter_minutes"].(float64); ok {
+		c.IntervalJitter = minutesToDuration(v)
+	}
+	if v, ok := raw["adaptive_interval"].(bool); ok {
+		c.AdaptiveInterval = v
+	}
+	if v, ok := raw["adaptive_min_interval_minutes"].(float64); ok {
+		c.AdaptiveMinInterval = minutesToDuration(v)
+	}
+	if v, ok := raw["adaptive_max_interval_minutes"].(float64); ok {
+		c.AdaptiveMaxInterval = minutesToDuration(v)
+	}
+	if v, ok := raw["long_session_threshold_minutes"].(float64); ok {
+		c.LongSessionThreshold = minutesToDuration(v)
+	}
+	if v, ok := raw["max_session_hours"].(float64); ok {
+		c.MaxSessionHours = v
+	}
+	if v, ok := raw["session_cooldown_minutes"].(float64); ok {
+		c.SessionCooldown = minutesToDuration(v)
+	}
+	if v, ok := raw["idle_auto_pause_enabled"].(bool); ok {
+		c.IdleAutoPauseEnabled = v
+	}
+	if v, ok := raw["catch_up_breaks"].(bool); ok {
+		c.CatchUpBreaks = v
+	}
+	if v, ok := raw["reset_after_pause_minutes"].(float64); ok {
+		c.ResetAfterPauseLongerThan = minutesToDuration(v)
+	}
+	if v, ok := raw["pause_on_battery"].(bool); ok {
+		c.PauseOnBattery = v
+	}
+	if v, ok := raw["cadence_schedule"].([]interface{}); ok {
+		c.CadenceSchedule = nil
+		for _, item := range v {
+			item, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var window CadenceWindow
+			if hour, ok := item["start_hour"].(float64); ok {
+				window.StartHour = int(hour)
+			}
+			if hour, ok := item["end_hour"].(float64); ok {
+				window.EndHour = int(hour)
+			}
+			if minutes, ok := item["work_duration_minutes"].(float64); ok {
+				window.WorkDuration = minutesToDuration(minutes)
+			}
+			c.CadenceSchedule = append(c.CadenceSchedule, window)
+		}
+	}
+	if v, ok := raw["per_app_work_duration_minutes"].(map[string]interface{}); ok {
+		c.PerAppWorkDuration = make(map[string]time.Duration, len(v))
+		for bundleID, minutes := range v {
+			if minutes, ok := minutes.(float64); ok {
+				c.PerAppWorkDuration[bundleID] = minutesToDuration(minutes)
+			}
+		}
+	}
+	if v, ok := raw["start_reminders_at"].(string); ok {
+		c.StartRemindersAt = v
+	}
+	if v, ok := raw["stop_reminders_at"].(string); ok {
+		c.StopRemindersAt = v
+	}
+	if v, ok := raw["weekend_work_duration_minutes"].(float64); ok {
+		c.WeekendWorkDuration = minutesToDuration(v)
+	}
+	if v, ok := raw["weekend_breaks_enabled"].(bool); ok {
+		c.WeekendBreaksEnabled = v
+	}
+	if v, ok := raw["verify_gaze_away"].(bool); ok {
+		c.VerifyGazeAway = v
+	}
+	if v, ok := raw["infer_completion_from_activity"].(bool); ok {
+		c.InferCompletionFromActivity = v
+	}
+	if v, ok := raw["api_port"].(float64); ok {
+		c.APIPort = int(v)
+	}
+	if v, ok := raw["webhook_url"].(string); ok {
+		c.WebhookURL = v
+	}
+	if v, ok := raw["on_break_start_command"].(string); ok {
+		c.OnBreakStartCommand = v
+	}
+	if v, ok := raw["on_break_end_command"].(string); ok {
+		c.OnBreakEndCommand = v
+	}
+	if v, ok := raw["pause_hotkey"].(string); ok {
+		c.PauseHotkey = v
+	}
+	if v, ok := raw["daily_review_time"].(string); ok {
+		c.DailyReviewTime = v
+	}
+	if v, ok := raw["last_daily_review"].(string); ok {
+		c.LastDailyReview = v
+	}
+	if v, ok := raw["notify_on_resume_from_idle"].(bool); ok {
+		c.NotifyOnResumeFromIdle = v
+	}
+	if v, ok := raw["max_notifications_per_hour"].(float64); ok {
+		c.MaxNotificationsPerHour = int(v)
+	}
+	if v, ok := raw["show_catch_up_summary"].(bool); ok {
+		c.ShowCatchUpSummary = v
+	}
+	if v, ok := raw["ask_rating_every"].(float64); ok {
+		c.AskRatingEvery = int(v)
+	}
+	if v, ok := raw["ask_skip_reason"].(bool); ok {
+		c.AskSkipReason = v
+	}
+	if v, ok := raw["daily_compliance_goal"].(float64); ok {
+		c.DailyComplianceGoal = v
+	}
+	if v, ok := raw["daily_rest_goal_minutes"].(float64); ok {
+		c.DailyRestGoalMinutes = int(v)
+	}
+	if v, ok := raw["streak_freezes"].(float64); ok {
+		c.StreakFreezes = int(v)
+	}
+	if v, ok := raw["postpone_counts"].(string); ok {
+		c.PostponeCounts = v
+	}
+	if v, ok := raw["compliance_mode"].(string); ok {
+		c.ComplianceMode = v
+	}
+	if v, ok := raw["week_starts_on"].(string); ok {
+		c.WeekStartsOn = v
+	}
+	if v, ok := raw["retention_days"].(float64); ok {
+		c.RetentionDays = int(v)
+	}
+	if v, ok := raw["event_log_path"].(string); ok {
+		c.EventLogPath = v
+	}
+	if v, ok := raw["onboarding_steps_done"].([]interface{}); ok {
+		c.OnboardingStepsDone = nil
+		for _, step := range v {
+			if step, ok := step.(string); ok {
+				c.MarkStepDone(step)
+			}
+		}
+	}
+}
+
+// checkNestedObjects rejects unknown keys and values of the wrong type in
+// the objects nested in value, the patched value of key
+func checkNestedObjects(key string, value interface{}) error {
+	nested, ok := nestedObjects[key]
+	if !ok {
+		return nil
+	}
+
+	objects := make(map[string]interface{})
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			objects[fmt.Sprintf("%s[%d]", key, i)] = item
+		}
+	case map[string]interface{}:
+		if !nested.byName {
+			objects[key] = v
+			break
+		}
+		for name, item := range v {
+			objects[key+"."+name] = item
+		}
+	}
+
+	for path, item := range objects {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidFieldType, path)
+		}
+		for field, fieldValue := range object {
+			want, ok := nested.fields[field]
+			if !ok {
+				return fmt.Errorf("%w: %s.%s", ErrUnknownField, path, field)
+			}
+			if !sameJSONType(want, fieldValue) {
+				return fmt.Errorf("%w: %s.%s", ErrInvalidFieldType, path, field)
+			}
+		}
+	}
+	return nil
+}
+
+// sameJSONType reports whether a decoded JSON value has the same type as
+// the value ToJSONMap produces for that key
+func sameJSONType(current, value interface{}) bool {
+	switch current.(type) {
+	case float64, int:
+		_, ok := value.(float64)
+		return ok
+	case bool:
+		_, ok := value.(bool)
+		return ok
+	case string:
+		_, ok := value.(string)
+		return ok
+	case map[string]interface{}:
+		_, ok := value.(map[string]interface{})
+		return ok
+	case map[string]float64:
+		m, ok := value.(map[string]interface{})
+		for _, v := range m {
+			if _, isNumber := v.(float64); !isNumber {
+				return false
+			}
+		}
+		return ok
+	case []string, []map[string]interface{}:
+		_, ok := value.([]interface{})
+		return ok
+	default:
+		return false
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// devModeEnv enables DeveloperLimits when set to "1"
+const devModeEnv = "TWENTY_RULE_DEV_MODE"
+
+// Limits holds the minimum durations accepted by validation and the steps
+// durations are rounded to by normalization
+type Limits struct {
+	MinWorkDuration  time.Duration
+	MinBreakDuration time.Duration
+	MinIdleThreshold time.Duration
+
+	WorkStep  time.Duration // Work durations are rounded to multiples of this
+	BreakStep time.Duration // Break durations are rounded to multiples of this
+}
+
+// StrictLimits are the guardrails for normal use
+var StrictLimits = Limits{
+	MinWorkDuration:  1 * time.Minute,
+	MinBreakDuration: 1 * time.Second,
+	MinIdleThreshold: 1 * time.Minute,
+
+	WorkStep:  1 * time.Minute,
+	BreakStep: 5 * time.Second,
+}
+
+// DeveloperLimits allow seconds-scale durations for manual testing
+var DeveloperLimits = Limits{
+	MinWorkDuration:  1 * time.Second,
+	MinBreakDuration: 1 * time.Second,
+	MinIdleThreshold: 1 * time.Second,
+
+	WorkStep:  1 * time.Second,
+	BreakStep: 1 * time.Second,
+}
+
+// ActiveLimits returns DeveloperLimits if developer mode is enabled via the
+// TWENTY_RULE_DEV_MODE environment variable, StrictLimits otherwise
+func ActiveLimits() Limits {
+	if os.Getenv(devModeEnv) == "1" {
+		return DeveloperLimits
+	}
+	return StrictLimits
+}
+
+// ClampToLimits raises durations below the minimums of limits to them and
+// reports whether it changed any, so a config saved in developer mode still
+// loads without it. Durations that are switched off stay off, and the
+// initial delay and jitter shrink as far as the work duration needs.
+func (c *Config) ClampToLimits(limits Limits) bool {
+	changed := false
+	raise := func(d *time.Duration, floor time.Duration) {
+		if *d < floor {
+			*d = floor
+			changed = true
+		}
+	}
+
+	raise(&c.WorkDuration, limits.MinWorkDuration)
+	raise(&c.BreakDuration, limits.MinBreakDuration)
+	raise(&c.IdleThreshold, limits.MinIdleThreshold)
+	if c.WeekendWorkDuration != 0 {
+		raise(&c.WeekendWorkDuration, limits.MinWorkDuration)
+	}
+	raise(&c.AdaptiveMinInterval, limits.MinWorkDuration)
+	raise(&c.AdaptiveMaxInterval, c.AdaptiveMinInterval)
+	for i := range c.CadenceSchedule {
+		raise(&c.CadenceSchedule[i].WorkDuration, limits.MinWorkDuration)
+	}
+	for bundleID, duration := range c.PerAppWorkDuration {
+		if duration < limits.MinWorkDuration {
+			c.PerAppWorkDuration[bundleID] = limits.MinWorkDuration
+			changed = true
+		}
+	}
+	raise(&c.InitialDelay, limits.MinWorkDuration-c.WorkDuration)
+	if c.IntervalJitter > c.WorkDuration-limits.MinWorkDuration {
+		c.IntervalJitter = c.WorkDuration - limits.MinWorkDuration
+		changed = true
+	}
+
+	return changed
+}
+
+// Normalize rounds durations to the steps of StrictLimits
+func (c *Config) Normalize() {
+	c.NormalizeWithLimits(StrictLimits)
+}
+
+// NormalizeWithLimits rounds durations entered with arbitrary precision to
+// the steps of limits, e.g. 1m37s of work to 2m and a 22s break to 20s.
+// WorkDuration, WeekendWorkDuration and the adaptive interval bounds round
+// to WorkStep, BreakDuration to BreakStep. A positive duration never rounds
+// down to 0 but to one step, so it stays switched on.
+func (c *Config) NormalizeWithLimits(limits Limits) {
+	c.WorkDuration = roundToStep(c.WorkDuration, limits.WorkStep)
+	c.WeekendWorkDuration = roundToStep(c.WeekendWorkDuration, limits.WorkStep)
+	c.AdaptiveMinInterval = roundToStep(c.AdaptiveMinInterval, limits.WorkStep)
+	c.AdaptiveMaxInterval = roundToStep(c.AdaptiveMaxInterval, limits.WorkStep)
+	c.BreakDuration = roundToStep(c.BreakDuration, limits.BreakStep)
+}
+
+// roundToStep rounds d to the nearest multiple of step, but a positive d to
+// at least one step. A step of 0 or less leaves d as is.
+func roundToStep(d, step time.Duration) time.Duration {
+	if step <= 0 || d <= 0 {
+		return d
+	}
+	return max(d.Round(step), step)
+}
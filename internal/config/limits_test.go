@@ -0,0 +1,79 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundToStep(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		step time.Duration
+		want time.Duration
+	}{
+		{"exact multiple", 20 * time.Minute, time.Minute, 20 * time.Minute},
+		{"rounds down", 20*time.Minute + 29*time.Second, time.Minute, 20 * time.Minute},
+		{"rounds up", 20*time.Minute + 31*time.Second, time.Minute, 21 * time.Minute},
+		{"half rounds up", 22*time.Second + 500*time.Millisecond, 5 * time.Second, 25 * time.Second},
+		{"positive stays at least one step", 10 * time.Second, time.Minute, time.Minute},
+		{"zero stays off", 0, time.Minute, 0},
+		{"negative left alone", -90 * time.Second, time.Minute, -90 * time.Second},
+		{"no step", 97 * time.Second, 0, 97 * time.Second},
+		{"negative step", 97 * time.Second, -time.Second, 97 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roundToStep(tt.d, tt.step); got != tt.want {
+				t.Errorf("roundToStep(%s, %s) = %s, want %s", tt.d, tt.step, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WorkDuration = 97 * time.Second
+	cfg.WeekendWorkDuration = 0
+	cfg.AdaptiveMinInterval = 20 * time.Second
+	cfg.AdaptiveMaxInterval = 44*time.Minute + 45*time.Second
+	cfg.BreakDuration = 2 * time.Second
+	cfg.IdleThreshold = 97 * time.Second
+
+	cfg.Normalize()
+
+	checks := []struct {
+		field string
+		got   time.Duration
+		want  time.Duration
+	}{
+		{"WorkDuration", cfg.WorkDuration, 2 * time.Minute},
+		{"WeekendWorkDuration", cfg.WeekendWorkDuration, 0},
+		{"AdaptiveMinInterval", cfg.AdaptiveMinInterval, time.Minute},
+		{"AdaptiveMaxInterval", cfg.AdaptiveMaxInterval, 45 * time.Minute},
+		{"BreakDuration", cfg.BreakDuration, 5 * time.Second},
+		// Durations without a step are kept
+		{"IdleThreshold", cfg.IdleThreshold, 97 * time.Second},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %s after Normalize, want %s", c.field, c.got, c.want)
+		}
+	}
+}
+
+func TestNormalizeWithDeveloperLimits(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WorkDuration = 10*time.Second + 400*time.Millisecond
+	cfg.BreakDuration = 3 * time.Second
+
+	cfg.NormalizeWithLimits(DeveloperLimits)
+
+	if cfg.WorkDuration != 10*time.Second {
+		t.Errorf("WorkDuration = %s, want %s", cfg.WorkDuration, 10*time.Second)
+	}
+	if cfg.BreakDuration != 3*time.Second {
+		t.Errorf("BreakDuration = %s, want %s", cfg.BreakDuration, 3*time.Second)
+	}
+}
@@ -2,26 +2,36 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 )
 
 const (
 	appName        = "2020Rule"
 	configFileName = "config.json"
+	// backupSuffix is appended to the name of a corrupt config file kept for inspection
+	backupSuffix = ".bak"
+	// dataDirEnv overrides the data directory, e.g. for portable installs
+	dataDirEnv = "TWENTY_RULE_DATA_DIR"
 )
 
-// Manager handles loading and saving configuration
+// Manager handles loading and saving configuration. It is safe for
+// concurrent use by the menu, the HTTP API and the file watcher.
 type Manager struct {
+	mu         sync.Mutex
 	configPath string
 	config     *Config
+	limits     Limits
 }
 
 // NewManager creates a new config manager
 func NewManager() (*Manager, error) {
-	configDir, err := getConfigDir()
+	configDir, err := DataDir()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get config directory: %w", err)
 	}
@@ -35,17 +45,27 @@ func NewManager() (*Manager, error) {
 
 	m := &Manager{
 		configPath: configPath,
+		limits:     ActiveLimits(),
 	}
 
 	// Load or create default config
 	if err := m.Load(); err != nil {
-		if os.IsNotExist(err) {
+		switch {
+		case os.IsNotExist(err):
 			// Create default config
 			m.config = DefaultConfig()
 			if err := m.Save(); err != nil {
 				return nil, fmt.Errorf("failed to save default config: %w", err)
 			}
-		} else {
+		case errors.Is(err, ErrCorruptConfig):
+			log.Printf("Warning: %v - starting with the default config", err)
+			m.mu.Lock()
+			err := m.recoverCorrupt()
+			m.mu.Unlock()
+			if err != nil {
+				return nil, fmt.Errorf("failed to recover from corrupt config: %w", err)
+			}
+		default:
 			return nil, fmt.Errorf("failed to load config: %w", err)
 		}
 	}
@@ -55,6 +75,13 @@ func NewManager() (*Manager, error) {
 
 // Load reads the configuration from disk
 func (m *Manager) Load() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.load()
+}
+
+// load reads the configuration from disk. Must be called with m.mu held.
+func (m *Manager) load() error {
 	data, err := os.ReadFile(m.configPath)
 	if err != nil {
 		return err
@@ -63,40 +90,33 @@ func (m *Manager) Load() error {
 	// Parse JSON with custom unmarshaling for durations
 	var raw map[string]interface{}
 	if err := json.Unmarshal(data, &raw); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w", err)
+		return fmt.Errorf("%w: %v", ErrCorruptConfig, err)
 	}
 
 	config := DefaultConfig()
+	config.applyJSONMap(raw)
 
-	// Parse duration fields (stored as minutes/seconds in JSON)
-	if v, ok := raw["work_duration_minutes"].(float64); ok {
-		config.WorkDuration = minutesToDuration(v)
-	}
-	if v, ok := raw["break_duration_seconds"].(float64); ok {
-		config.BreakDuration = secondsToDuration(v)
-	}
-	if v, ok := raw["idle_threshold_minutes"].(float64); ok {
-		config.IdleThreshold = minutesToDuration(v)
+	// Configs written before onboarding steps existed only know whether
+	// the first run happened; don't onboard those users again
+	if _, ok := raw["onboarding_steps_done"]; !ok && !config.FirstRun {
+		config.OnboardingStepsDone = append([]string(nil), OnboardingSteps...)
 	}
-	if v, ok := raw["auto_start_on_login"].(bool); ok {
-		config.AutoStartOnLogin = v
-	}
-	if v, ok := raw["pause_on_fullscreen_app"].(bool); ok {
-		config.PauseOnFullscreen = v
-	}
-	if v, ok := raw["notification_sound"].(bool); ok {
-		config.NotificationSound = v
-	}
-	if v, ok := raw["overlay_opacity"].(float64); ok {
-		config.OverlayOpacity = v
+
+	// A sound deleted since the config was saved isn't worth discarding
+	// the whole config for
+	if config.BreakStartSound != "" && !validSoundName(config.BreakStartSound) {
+		log.Printf("Warning: break start sound %q not found - using the default", config.BreakStartSound)
+		config.BreakStartSound = DefaultConfig().BreakStartSound
 	}
-	if v, ok := raw["first_run"].(bool); ok {
-		config.FirstRun = v
+
+	// A config saved in developer mode isn't corrupt, it just goes further
+	if config.ClampToLimits(m.limits) {
+		log.Printf("Warning: %s has durations below the limits, e.g. from developer mode - raising them", configFileName)
 	}
 
 	// Validate the loaded config
-	if err := config.Validate(); err != nil {
-		return fmt.Errorf("invalid config: %w", err)
+	if err := config.ValidateWithLimits(m.limits); err != nil {
+		return fmt.Errorf("%w: %w", ErrCorruptConfig, err)
 	}
 
 	m.config = config
@@ -105,64 +125,123 @@ func (m *Manager) Load() error {
 
 // Save writes the configuration to disk
 func (m *Manager) Save() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.save()
+}
+
+// save writes the configuration to disk. Must be called with m.mu held.
+func (m *Manager) save() error {
 	if m.config == nil {
 		m.config = DefaultConfig()
 	}
 
 	// Validate before saving
-	if err := m.config.Validate(); err != nil {
+	if err := m.config.ValidateWithLimits(m.limits); err != nil {
 		return fmt.Errorf("invalid config: %w", err)
 	}
 
 	// Convert to JSON-friendly format
-	data := map[string]interface{}{
-		"work_duration_minutes":    durationToMinutes(m.config.WorkDuration),
-		"break_duration_seconds":   durationToSeconds(m.config.BreakDuration),
-		"idle_threshold_minutes":   durationToMinutes(m.config.IdleThreshold),
-		"auto_start_on_login":      m.config.AutoStartOnLogin,
-		"pause_on_fullscreen_app":  m.config.PauseOnFullscreen,
-		"notification_sound":       m.config.NotificationSound,
-		"overlay_opacity":          m.config.OverlayOpacity,
-		"first_run":                m.config.FirstRun,
-	}
+	data := m.config.ToJSONMap()
 
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(m.configPath, jsonData, 0644); err != nil {
+	if err := writeFileAtomic(m.configPath, jsonData, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
 	return nil
 }
 
-// Get returns the current configuration
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it over path, so readers see either the old or the new file but never a
+// truncated one
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, perm)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+	}
+	return err
+}
+
+// recoverCorrupt moves the corrupt config file aside to config.json.bak
+// and replaces it with the default config. Must be called with m.mu held.
+func (m *Manager) recoverCorrupt() error {
+	if err := os.Rename(m.configPath, m.configPath+backupSuffix); err != nil {
+		return fmt.Errorf("failed to back up config: %w", err)
+	}
+
+	m.config = DefaultConfig()
+	return m.save()
+}
+
+// Get returns a copy of the current configuration, which the caller may
+// change and pass to Update
 func (m *Manager) Get() *Config {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if m.config == nil {
 		m.config = DefaultConfig()
 	}
-	return m.config
+	return m.config.Clone()
 }
 
-// Update updates the configuration and saves it
+// Update rounds the durations of a copy of config to the steps of the
+// limits, validates it and saves it. config itself is left unchanged; Get
+// returns what was saved.
 func (m *Manager) Update(config *Config) error {
-	if err := config.Validate(); err != nil {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updated := config.Clone()
+	updated.NormalizeWithLimits(m.limits)
+	if err := updated.ValidateWithLimits(m.limits); err != nil {
 		return err
 	}
-	m.config = config
-	return m.Save()
+
+	m.config = updated
+	return m.save()
 }
 
-// getConfigDir returns the application's config directory
-// On macOS: ~/Library/Application Support/2020Rule
-func getConfigDir() (string, error) {
+// DataDir returns the application's data directory holding config.json
+// On macOS: ~/Library/Application Support/2020Rule, unless
+// TWENTY_RULE_DATA_DIR is set
+func DataDir() (string, error) {
+	if dir := os.Getenv(dataDirEnv); dir != "" {
+		return filepath.Abs(dir)
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, "Library", "Application Support", appName), nil
+	return dataDirIn(home), nil
+}
+
+// dataDirIn returns the data directory for the given home directory
+func dataDirIn(home string) string {
+	return filepath.Join(home, "Library", "Application Support", appName)
 }
 
 // Helper functions for duration conversion
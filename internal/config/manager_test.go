@@ -0,0 +1,265 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestManager creates a manager whose config.json lives in a temporary
+// data directory
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	t.Setenv(dataDirEnv, t.TempDir())
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	return m
+}
+
+func TestManagerConcurrentUpdates(t *testing.T) {
+	m := newTestManager(t)
+
+	const writers, updates = 8, 25
+	var wg sync.WaitGroup
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range updates {
+				cfg := m.Get()
+				cfg.WorkDuration = time.Duration(20+(w+i)%10) * time.Minute
+				cfg.DeferInApps = append(cfg.DeferInApps, "com.example.app")
+				if err := m.Update(cfg); err != nil {
+					t.Errorf("Update: %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Readers see whole configs and whole files while the writers run
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range updates {
+				if err := m.Get().Validate(); err != nil {
+					t.Errorf("Get returned an invalid config: %v", err)
+				}
+				data, err := os.ReadFile(m.configPath)
+				if err != nil {
+					t.Errorf("ReadFile: %v", err)
+					continue
+				}
+				var raw map[string]interface{}
+				if err := json.Unmarshal(data, &raw); err != nil {
+					t.Errorf("config.json is not valid JSON: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := m.Load(); err != nil {
+		t.Fatalf("Load after concurrent updates: %v", err)
+	}
+}
+
+func TestManagerGetReturnsCopy(t *testing.T) {
+	m := newTestManager(t)
+
+	cfg := m.Get()
+	want := cfg.WorkDuration
+	cfg.WorkDuration += time.Minute
+	cfg.DeferInApps = append(cfg.DeferInApps, "com.example.app")
+
+	got := m.Get()
+	if got.WorkDuration != want {
+		t.Errorf("changing the result of Get changed the work duration to %s", got.WorkDuration)
+	}
+	if len(got.DeferInApps) != 0 {
+		t.Errorf("changing the result of Get changed DeferInApps to %v", got.DeferInApps)
+	}
+}
+
+func TestManagerUpdateLeavesArgumentAlone(t *testing.T) {
+	m := newTestManager(t)
+
+	cfg := m.Get()
+	cfg.WorkDuration = 25*time.Minute + 20*time.Second
+	if err := m.Update(cfg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if cfg.WorkDuration != 25*time.Minute+20*time.Second {
+		t.Errorf("Update changed its argument to %s", cfg.WorkDuration)
+	}
+	want := cfg.Clone()
+	want.NormalizeWithLimits(m.limits)
+	if got := m.Get(); got.WorkDuration != want.WorkDuration {
+		t.Errorf("Get returned %s, want the normalized %s", got.WorkDuration, want.WorkDuration)
+	}
+}
+
+func TestManagerUpdateRejectsInvalid(t *testing.T) {
+	m := newTestManager(t)
+	before := m.Get().WorkDuration
+
+	cfg := m.Get()
+	cfg.WorkDuration = -time.Minute
+	if err := m.Update(cfg); err == nil {
+		t.Fatal("Update accepted a negative work duration")
+	}
+	if got := m.Get().WorkDuration; got != before {
+		t.Errorf("rejected update changed the work duration to %s", got)
+	}
+}
+
+func TestLoadFallsBackToDefaultSound(t *testing.T) {
+	m := newTestManager(t)
+	cfg := m.Get()
+	cfg.BreakStartSound = "Gong"
+	if err := m.Update(cfg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	SetSoundLookup(func(name string) error {
+		if name != "Glass" {
+			return errors.New("sound not found")
+		}
+		return nil
+	})
+	t.Cleanup(func() { SetSoundLookup(nil) })
+
+	if err := m.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := m.Get().BreakStartSound; got != "Glass" {
+		t.Errorf("break start sound = %q, want the default", got)
+	}
+}
+
+func TestNewManagerRecoversCorruptConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"malformed JSON", `{"work_duration_minutes": `},
+		{"invalid value", `{"overlay_opacity": 5}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv(dataDirEnv, dir)
+			path := filepath.Join(dir, configFileName)
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			m, err := NewManager()
+			if err != nil {
+				t.Fatalf("NewManager: %v", err)
+			}
+			if got := m.Get().OverlayOpacity; got != DefaultConfig().OverlayOpacity {
+				t.Errorf("overlay opacity = %v, want the default", got)
+			}
+
+			backup, err := os.ReadFile(path + backupSuffix)
+			if err != nil {
+				t.Fatalf("read backup: %v", err)
+			}
+			if string(backup) != tt.data {
+				t.Errorf("backup holds %q, want the corrupt config", backup)
+			}
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("no default config written: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadClampsDeveloperModeConfig(t *testing.T) {
+	const data = `{"work_duration_minutes": 0.25, "break_duration_seconds": 5, "idle_threshold_minutes": 0.1, "interval_jitter_minutes": 0.1}`
+
+	tests := []struct {
+		name     string
+		devMode  string
+		wantWork time.Duration
+		wantIdle time.Duration
+	}{
+		{"raised without developer mode", "", StrictLimits.MinWorkDuration, StrictLimits.MinIdleThreshold},
+		{"kept in developer mode", "1", 15 * time.Second, 6 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(devModeEnv, tt.devMode)
+			dir := t.TempDir()
+			t.Setenv(dataDirEnv, dir)
+			path := filepath.Join(dir, configFileName)
+			if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			m, err := NewManager()
+			if err != nil {
+				t.Fatalf("NewManager: %v", err)
+			}
+			if _, err := os.Stat(path + backupSuffix); err == nil {
+				t.Error("config was treated as corrupt")
+			}
+
+			cfg := m.Get()
+			if cfg.WorkDuration != tt.wantWork || cfg.IdleThreshold != tt.wantIdle {
+				t.Errorf("work %s, idle %s, want %s, %s", cfg.WorkDuration, cfg.IdleThreshold, tt.wantWork, tt.wantIdle)
+			}
+			if cfg.BreakDuration != 5*time.Second {
+				t.Errorf("break %s, want the saved %s", cfg.BreakDuration, 5*time.Second)
+			}
+			if err := cfg.ValidateWithLimits(m.limits); err != nil {
+				t.Errorf("loaded config is invalid: %v", err)
+			}
+		})
+	}
+}
+
+func TestDataDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	override := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		env  string
+		want string
+	}{
+		{"unset", "", filepath.Join(home, "Library", "Application Support", "2020Rule")},
+		{"absolute override", override, override},
+		{"relative override", "portable", filepath.Join(cwd, "portable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(dataDirEnv, tt.env)
+			got, err := DataDir()
+			if err != nil {
+				t.Fatalf("DataDir: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DataDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// FieldType is the JSON type of a config field as stored in config.json
+type FieldType string
+
+const (
+	FieldBool            FieldType = "bool"
+	FieldInteger         FieldType = "integer"
+	FieldNumber          FieldType = "number"
+	FieldString          FieldType = "string"
+	FieldDurationMinutes FieldType = "duration_minutes"
+	FieldDurationSeconds FieldType = "duration_seconds"
+	FieldList            FieldType = "list"
+	FieldObject          FieldType = "object"
+)
+
+// FieldSchema describes one config field for documentation and settings UIs.
+// Min and Max are in the units of the JSON value and nil if unbounded.
+type FieldSchema struct {
+	Name        string      `json:"name"`
+	Key         string      `json:"key"`
+	Type        FieldType   `json:"type"`
+	Default     interface{} `json:"default"`
+	Min         *float64    `json:"min,omitempty"`
+	Max         *float64    `json:"max,omitempty"`
+	Options     []string    `json:"options,omitempty"`
+	Description string      `json:"description"`
+}
+
+// fieldDoc holds the parts of a FieldSchema that can't be derived from the
+// Config struct
+type fieldDoc struct {
+	Description string
+	Min         *float64
+	Max         *float64
+	Options     []string
+}
+
+// bound returns a pointer to v for the Min and Max of a fieldDoc
+func bound(v float64) *float64 {
+	return &v
+}
+
+// The StrictLimits minimums in the units of their JSON values
+var (
+	minWorkMinutes  = bound(StrictLimits.MinWorkDuration.Minutes())
+	minBreakSeconds = bound(StrictLimits.MinBreakDuration.Seconds())
+	minIdleMinutes  = bound(StrictLimits.MinIdleThreshold.Minutes())
+)
+
+// fieldDocs documents every config field by JSON key
+var fieldDocs = map[string]fieldDoc{
+	"work_duration_minutes":   {Description: "Work time between breaks", Min: minWorkMinutes},
+	"break_duration_seconds":  {Description: "Length of a break", Min: minBreakSeconds},
+	"idle_threshold_minutes":  {Description: "Inactivity after which the timer pauses", Min: minIdleMinutes},
+	"auto_start_on_login":     {Description: "Start the app when logging in"},
+	"pause_on_fullscreen_app": {Description: "Pause while a fullscreen app is frontmost"},
+	"notification_sound":      {Description: "Play a sound when a break starts"},
+	"overlay_opacity":         {Description: "Opacity of the break overlay", Min: bound(0), Max: bound(1)},
+	"first_run":               {Description: "Whether the app has not been started before"},
+
+	"sound_volume":      {Description: "Volume the break chime fades in to", Min: bound(0), Max: bound(1)},
+	"break_start_sound": {Description: "System sound name or absolute path to a sound file"},
+
+	"break_style":              {Description: "How a break is presented", Options: []string{BreakStyleOverlay, BreakStyleNotification}},
+	"break_type":               {Description: "What the overlay shows during a break", Options: []string{BreakTypeStandard, BreakTypeBreathing}},
+	"breathing_pacing_seconds": {Description: "Inhale, hold, exhale and hold phases of the breathing guide, at least 1 second each"},
+	"break_sequence":           {Description: "Eye exercise steps shown during standard breaks, fractions add up to 1, empty = off"},
+	"overlay_window_level":     {Description: "How far the overlay floats above other windows", Options: []string{OverlayLevelScreenSaver, OverlayLevelFloating, OverlayLevelNormal}},
+	"pause_on_screen_share":    {Description: "Show a notification instead of the overlay while the screen is shared"},
+	"overlay_mode":             {Description: "How much of the screen a break covers", Options: []string{OverlayModeFullscreen, OverlayModeBanner}},
+	"banner_corner":            {Description: "Screen corner of the banner overlay", Options: []string{BannerCornerTopLeft, BannerCornerTopRight, BannerCornerBottomLeft, BannerCornerBottomRight}},
+
+	"overlay_show_next_break":    {Description: "Show when the following break is due"},
+	"overlay_active_screen_only": {Description: "Only cover the screen with the mouse cursor"},
+	"gaze_target_exercise":       {Description: "Show a dot to follow with the eyes through the screen corners during breaks"},
+
+	"high_contrast": {Description: "Use a solid overlay background and fully opaque text"},
+
+	"dim_more_at_night": {Description: "Darken the overlay background late in the evening and at night"},
+
+	"dim_brightness_during_break": {Description: "Lower the display brightness during breaks and restore it afterwards"},
+	"break_brightness_level":      {Description: "Display brightness during breaks", Min: bound(0), Max: bound(1)},
+
+	"overlay_themes": {Description: "Background color as #RRGGBB and message per break kind (regular, gentle, recovery, session_limit)"},
+
+	"overlay_messages_file": {Description: "Absolute path to a file with one message per line, empty = built-in messages"},
+
+	"overlay_click_action": {Description: "What a click on the overlay outside the buttons does", Options: []string{OverlayClickNone, OverlayClickComplete, OverlayClickPostpone}},
+
+	"rebuild_overlay_on_screen_change": {Description: "Recreate the overlay when displays change during a break"},
+
+	"allow_early_dismiss": {Description: "Show a button to end the break early"},
+	"min_break_fraction":  {Description: "Share of the break needed to count as completed", Min: bound(0), Max: bound(1)},
+
+	"pre_break_dim_seconds": {Description: "Dim the screens this long before a break, 0 = no dimming", Min: bound(0)},
+
+	"pre_break_warning_seconds": {Description: "Notify this long before a break with an option to postpone it, 0 = off", Min: bound(0)},
+
+	"gentle_first_break":                {Description: "Make the first break of the day shorter"},
+	"defer_breaks_during_presentations": {Description: "Hold breaks while a presentation is running"},
+
+	"defer_in_apps":            {Description: "Bundle IDs of apps, e.g. games, that hold due breaks while frontmost"},
+	"max_app_deferral_minutes": {Description: "Longest a break waits for an app from defer_in_apps", Min: bound(0)},
+
+	"initial_delay_minutes": {Description: "Added to the first work interval after launch, may be negative"},
+
+	"request_permissions_on_start": {Description: "Show the macOS prompts for missing permissions at launch"},
+
+	"max_extension_minutes": {Description: "Most a single work interval can be extended, 0 = off", Min: bound(0)},
+
+	"interval_jitter_minutes": {Description: "Make each work interval randomly up to this much shorter or longer, 0 = off", Min: bound(0)},
+
+	"adaptive_interval":             {Description: "Lengthen the work interval after skips and shorten it while breaks are kept"},
+	"adaptive_min_interval_minutes": {Description: "Shortest work duration the adaptive interval picks", Min: minWorkMinutes},
+	"adaptive_max_interval_minutes": {Description: "Longest work duration the adaptive interval picks", Min: minWorkMinutes},
+
+	"long_session_threshold_minutes": {Description: "Work time without a completed break after which a recovery break follows, 0 = off", Min: bound(0)},
+
+	"max_session_hours":        {Description: "Continuous work time, short breaks included, after which a longer break follows, 0 = off", Min: bound(0)},
+	"session_cooldown_minutes": {Description: "Length of the longer break, which then can't be ended early, 0 = no lockout", Min: bound(0), Max: bound(MaxSessionCooldown.Minutes())},
+
+	"idle_auto_pause_enabled": {Description: "Pause the timer while the user is idle"},
+
+	"catch_up_breaks": {Description: "Show one break on wake if the work interval ran out during sleep"},
+
+	"reset_after_pause_minutes": {Description: "Restart the work interval when resuming from a longer manual pause, 0 = always continue it", Min: bound(0)},
+
+	"pause_on_battery": {Description: "No breaks while running on battery"},
+
+	"cadence_schedule": {Description: "Work durations for ranges of hours of the day, the first matching window wins"},
+
+	"per_app_work_duration_minutes": {Description: "Work durations keyed by the bundle ID of the frontmost app"},
+
+	"start_reminders_at": {Description: "Time of day as HH:MM from which breaks are due, empty together with stop_reminders_at = all day"},
+	"stop_reminders_at":  {Description: "Time of day as HH:MM after which no breaks are due until start_reminders_at"},
+
+	"weekend_work_duration_minutes": {Description: "Work duration on weekends, 0 = same as weekdays", Min: bound(0)},
+	"weekend_breaks_enabled":        {Description: "Take breaks on weekends"},
+
+	"verify_gaze_away":               {Description: "Use the camera to check that breaks are spent looking away"},
+	"infer_completion_from_activity": {Description: "Count a break without any input as completed and one typed through as skipped"},
+
+	"api_port": {Description: "Port of the local HTTP API, 0 = disabled", Min: bound(0), Max: bound(65535)},
+
+	"webhook_url": {Description: "http or https URL that receives break outcomes and daily summaries as JSON, empty = off"},
+
+	"on_break_start_command": {Description: "Shell command run when a break starts, with HOOK_* environment variables, empty = off. Can only be set in config.json"},
+	"on_break_end_command":   {Description: "Shell command run when a break ends, with HOOK_* environment variables, empty = off. Can only be set in config.json"},
+
+	"pause_hotkey": {Description: "Global shortcut to pause and resume, e.g. cmd+opt+p, empty = disabled"},
+
+	"daily_review_time": {Description: "Time of day of the daily review as HH:MM, empty = off"},
+	"last_daily_review": {Description: "Date of the last daily review shown as YYYY-MM-DD"},
+
+	"notify_on_resume_from_idle": {Description: "Notify when the timer resumes after being away"},
+	"max_notifications_per_hour": {Description: "Most notifications per hour, less important ones are dropped first, 0 = no cap", Min: bound(0)},
+	"show_catch_up_summary":      {Description: "Notify how many breaks were missed while away when returning"},
+
+	"ask_rating_every": {Description: "Ask for an eye comfort rating every Nth break, 0 = never", Min: bound(0)},
+	"ask_skip_reason":  {Description: "Ask why after a break was skipped"},
+
+	"daily_compliance_goal": {Description: "Daily compliance goal in percent, 0 = no goal", Min: bound(0), Max: bound(100)},
+	"postpone_counts":       {Description: "How postponed breaks count towards compliance", Options: []string{PostponeCountsNeutral, PostponeCountsPartial, PostponeCountsSkip}},
+	"compliance_mode":       {Description: "How the compliance rate is calculated", Options: []string{ComplianceOfRequired, ComplianceOfDecided}},
+	"week_starts_on":        {Description: "First day of calendar week reports", Options: []string{WeekStartsMonday, WeekStartsSunday}},
+
+	"daily_rest_goal_minutes": {Description: "Minutes of completed breaks per day to aim for, 0 = no goal", Min: bound(0)},
+
+	"streak_freezes": {Description: "Days below the goal a streak survives", Min: bound(0)},
+
+	"retention_days": {Description: "Breaks and sessions older than this are pruned, 0 = keep forever", Min: bound(0)},
+
+	"event_log_path": {Description: "Path of the JSON lines timer event log, empty = off"},
+
+	"onboarding_steps_done": {Description: "Onboarding steps the user has completed"},
+}
+
+// durationType is the reflected type of time.Duration
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Schema describes every config field in the order of the Config struct,
+// with the defaults of DefaultConfig
+func Schema() []FieldSchema {
+	defaults := DefaultConfig().ToJSONMap()
+	configType := reflect.TypeOf(Config{})
+
+	fields := make([]FieldSchema, 0, configType.NumField())
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		doc := fieldDocs[key]
+		fields = append(fields, FieldSchema{
+			Name:        field.Name,
+			Key:         key,
+			Type:        fieldTypeOf(field.Type, key),
+			Default:     defaults[key],
+			Min:         doc.Min,
+			Max:         doc.Max,
+			Options:     doc.Options,
+			Description: doc.Description,
+		})
+	}
+	return fields
+}
+
+// fieldTypeOf returns the JSON type of a field of type t stored under key.
+// Durations carry their unit in the key.
+func fieldTypeOf(t reflect.Type, key string) FieldType {
+	switch {
+	case t == durationType && strings.HasSuffix(key, "_seconds"):
+		return FieldDurationSeconds
+	case t == durationType:
+		return FieldDurationMinutes
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return FieldBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return FieldInteger
+	case reflect.Float32, reflect.Float64:
+		return FieldNumber
+	case reflect.String:
+		return FieldString
+	case reflect.Slice, reflect.Array:
+		return FieldList
+	default:
+		return FieldObject
+	}
+}
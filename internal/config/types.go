@@ -1,6 +1,142 @@
 package config
 
-import "time"
+import (
+	"math"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Break types control what the overlay shows during a break
+const (
+	// BreakTypeStandard shows the plain "look into the distance" overlay
+	BreakTypeStandard = "standard"
+	// BreakTypeBreathing shows a paced box-breathing guide
+	BreakTypeBreathing = "breathing"
+)
+
+// Break styles control how a break is presented
+const (
+	// BreakStyleOverlay covers the screen with the break overlay
+	BreakStyleOverlay = "overlay"
+	// BreakStyleNotification only shows an actionable notification
+	BreakStyleNotification = "notification"
+)
+
+// Overlay window levels, from most to least intrusive
+const (
+	// OverlayLevelScreenSaver floats above everything, including fullscreen apps
+	OverlayLevelScreenSaver = "screensaver"
+	// OverlayLevelFloating floats above regular windows
+	OverlayLevelFloating = "floating"
+	// OverlayLevelNormal behaves like a regular window
+	OverlayLevelNormal = "normal"
+)
+
+// Overlay modes control how much of the screen a break covers
+const (
+	// OverlayModeFullscreen covers every screen completely
+	OverlayModeFullscreen = "fullscreen"
+	// OverlayModeBanner shows a small banner in a corner of each screen
+	OverlayModeBanner = "banner"
+)
+
+// Screen corners the banner overlay can be placed in
+const (
+	BannerCornerTopLeft     = "top_left"
+	BannerCornerTopRight    = "top_right"
+	BannerCornerBottomLeft  = "bottom_left"
+	BannerCornerBottomRight = "bottom_right"
+)
+
+// Actions taken when the user clicks the overlay
+const (
+	// OverlayClickNone ignores clicks on the overlay
+	OverlayClickNone = "none"
+	// OverlayClickComplete ends the break like the early dismiss button
+	OverlayClickComplete = "complete"
+	// OverlayClickPostpone postpones the break by a few minutes
+	OverlayClickPostpone = "postpone"
+)
+
+// Ways postponed breaks can count towards compliance
+const (
+	// PostponeCountsNeutral leaves postponed breaks out of the compliance rate
+	PostponeCountsNeutral = "neutral"
+	// PostponeCountsPartial counts a postponed break as half completed
+	PostponeCountsPartial = "partial"
+	// PostponeCountsSkip counts a postponed break like a skipped one
+	PostponeCountsSkip = "skip"
+)
+
+// Ways the compliance rate can be calculated
+const (
+	// ComplianceOfRequired divides the completed breaks by all required ones
+	ComplianceOfRequired = "of_required"
+	// ComplianceOfDecided divides the completed breaks by the completed and skipped ones
+	ComplianceOfDecided = "of_decided"
+)
+
+// Days a calendar week can start on
+const (
+	WeekStartsMonday = "monday"
+	WeekStartsSunday = "sunday"
+)
+
+// Onboarding steps presented on early launches, in order
+const (
+	OnboardingStepPermissions = "permissions"
+	OnboardingStepDurations   = "durations"
+	OnboardingStepLoginItem   = "login_item"
+)
+
+// OnboardingSteps lists all onboarding steps in the order they are presented
+var OnboardingSteps = []string{
+	OnboardingStepPermissions,
+	OnboardingStepDurations,
+	OnboardingStepLoginItem,
+}
+
+// SequenceStep is one instruction of an eye exercise sequence
+type SequenceStep struct {
+	Text     string  `json:"text"`
+	Fraction float64 `json:"fraction"` // Share of the break spent on this step
+}
+
+// breakSequenceTolerance is how far the step fractions may deviate from 1.0
+const breakSequenceTolerance = 0.01
+
+// MaxSessionCooldown is the longest SessionCooldown, the longest break the
+// overlay shows
+const MaxSessionCooldown = 5 * time.Minute
+
+// BreathingPacing defines the length of each phase of a box-breathing cycle
+type BreathingPacing struct {
+	Inhale  time.Duration `json:"inhale"`
+	HoldIn  time.Duration `json:"hold_in"`
+	Exhale  time.Duration `json:"exhale"`
+	HoldOut time.Duration `json:"hold_out"`
+}
+
+// Cycle returns the duration of one full breathing cycle
+func (p BreathingPacing) Cycle() time.Duration {
+	return p.Inhale + p.HoldIn + p.Exhale + p.HoldOut
+}
+
+// CadenceWindow sets the work duration for a range of hours of the day
+type CadenceWindow struct {
+	StartHour    int           `json:"start_hour"` // Inclusive, 0-23
+	EndHour      int           `json:"end_hour"`   // Exclusive, 1-24
+	WorkDuration time.Duration `json:"work_duration_minutes"`
+}
+
+// OverlayTheme overrides the look of the overlay for one kind of break.
+// Empty fields keep the default.
+type OverlayTheme struct {
+	Background string `json:"background"` // Hex color as #RRGGBB, shown with OverlayOpacity
+	Message    string `json:"message"`
+}
 
 // Config holds all user configuration for the application
 type Config struct {
@@ -12,6 +148,166 @@ type Config struct {
 	NotificationSound bool          `json:"notification_sound"`
 	OverlayOpacity    float64       `json:"overlay_opacity"`
 	FirstRun          bool          `json:"first_run"`
+
+	// Sound
+	SoundVolume     float64 `json:"sound_volume"`      // 0-1, the break chime fades in to this volume
+	BreakStartSound string  `json:"break_start_sound"` // System sound name or absolute path to a sound file
+
+	// Overlay behavior
+	BreakStyle         string          `json:"break_style"`
+	BreakType          string          `json:"break_type"`
+	BreathingPacing    BreathingPacing `json:"breathing_pacing_seconds"`
+	BreakSequence      []SequenceStep  `json:"break_sequence"` // Standard breaks only, empty = off
+	OverlayWindowLevel string          `json:"overlay_window_level"`
+	PauseOnScreenShare bool            `json:"pause_on_screen_share"`
+	OverlayMode        string          `json:"overlay_mode"`
+	BannerCorner       string          `json:"banner_corner"` // Banner mode only
+
+	// Overlay content
+	OverlayShowNextBreak    bool `json:"overlay_show_next_break"`    // Shows when the following break is due
+	OverlayActiveScreenOnly bool `json:"overlay_active_screen_only"` // Only the screen with the mouse cursor
+	GazeTargetExercise      bool `json:"gaze_target_exercise"`       // A dot to follow with the eyes along the screen corners, fullscreen only
+
+	// Accessibility
+	HighContrast bool `json:"high_contrast"` // Solid overlay background and fully opaque text
+
+	// Night
+	DimMoreAtNight bool `json:"dim_more_at_night"` // Darker overlay background late in the evening and at night
+
+	// Display brightness
+	DimBrightnessDuringBreak bool    `json:"dim_brightness_during_break"` // Lower the display brightness for the break and restore it after
+	BreakBrightnessLevel     float64 `json:"break_brightness_level"`      // 0-1, brightness during the break
+
+	// Overlay themes
+	OverlayThemes map[string]OverlayTheme `json:"overlay_themes"` // Keyed by break kind: regular, gentle, recovery or session_limit
+
+	// Overlay messages
+	OverlayMessagesFile string `json:"overlay_messages_file"` // Absolute path, one message per line, empty = built-in messages
+
+	// Overlay clicks
+	OverlayClickAction string `json:"overlay_click_action"` // What a click outside the buttons does
+
+	// Display changes
+	RebuildOverlayOnScreenChange bool `json:"rebuild_overlay_on_screen_change"` // Recreate the overlay when displays change mid-break
+
+	// Early dismissal
+	AllowEarlyDismiss bool    `json:"allow_early_dismiss"` // Shows a button to end the break early
+	MinBreakFraction  float64 `json:"min_break_fraction"`  // Share of the break needed to count as completed
+
+	// Pre-break cues
+	PreBreakDimDuration time.Duration `json:"pre_break_dim_seconds"` // 0 = no dimming
+
+	// Pre-break warning
+	PreBreakWarning time.Duration `json:"pre_break_warning_seconds"` // Notification offering to postpone, 0 = off
+
+	// Break scheduling
+	GentleFirstBreak               bool `json:"gentle_first_break"` // Shorter first break of the day
+	DeferBreaksDuringPresentations bool `json:"defer_breaks_during_presentations"`
+
+	// Deferral in apps
+	DeferInApps    []string      `json:"defer_in_apps"`            // Bundle IDs of apps that hold due breaks while frontmost
+	MaxAppDeferral time.Duration `json:"max_app_deferral_minutes"` // Longest a break waits for such an app
+
+	// Startup
+	InitialDelay time.Duration `json:"initial_delay_minutes"` // Added to the first work interval after launch, may be negative
+
+	// Permissions
+	RequestPermissionsOnStart bool `json:"request_permissions_on_start"` // Show the macOS prompts for missing permissions at launch
+
+	// Work interval extension
+	MaxExtension time.Duration `json:"max_extension_minutes"` // Most a single work interval can be extended, 0 = off
+
+	// Interval jitter
+	IntervalJitter time.Duration `json:"interval_jitter_minutes"` // Each work interval is randomly up to this much shorter or longer, 0 = off
+
+	// Adaptive interval
+	AdaptiveInterval    bool          `json:"adaptive_interval"`             // Lengthen the work interval after skips, shorten it while breaks are kept
+	AdaptiveMinInterval time.Duration `json:"adaptive_min_interval_minutes"` // Shortest adapted work duration
+	AdaptiveMaxInterval time.Duration `json:"adaptive_max_interval_minutes"` // Longest adapted work duration
+
+	// Long sessions
+	LongSessionThreshold time.Duration `json:"long_session_threshold_minutes"` // 0 = no recovery breaks
+
+	// Maximum session length
+	MaxSessionHours float64       `json:"max_session_hours"`        // Continuous work time across breaks before a longer break, 0 = off
+	SessionCooldown time.Duration `json:"session_cooldown_minutes"` // Length of the locked longer break, 0 = no lockout
+
+	// Idle detection
+	IdleAutoPauseEnabled bool `json:"idle_auto_pause_enabled"` // Pause the timer while the user is idle
+
+	// Sleep
+	CatchUpBreaks bool `json:"catch_up_breaks"` // One break on wake if the interval ran out during sleep
+
+	// Manual pause
+	ResetAfterPauseLongerThan time.Duration `json:"reset_after_pause_minutes"` // Longer manual pauses restart the work interval on resume, 0 = always continue it
+
+	// Power
+	PauseOnBattery bool `json:"pause_on_battery"` // No breaks while running on battery
+
+	// Time-of-day cadence
+	CadenceSchedule []CadenceWindow `json:"cadence_schedule"` // First matching window wins, empty = WorkDuration all day
+
+	// Per-application cadence
+	PerAppWorkDuration map[string]time.Duration `json:"per_app_work_duration_minutes"` // Keyed by bundle ID of the frontmost app, wins over cadence and weekend
+
+	// Workday
+	StartRemindersAt string `json:"start_reminders_at"` // HH:MM, breaks are only due from here to StopRemindersAt
+	StopRemindersAt  string `json:"stop_reminders_at"`  // HH:MM, before StartRemindersAt for overnight days, both empty = all day
+
+	// Weekend schedule
+	WeekendWorkDuration  time.Duration `json:"weekend_work_duration_minutes"` // 0 = same as weekdays
+	WeekendBreaksEnabled bool          `json:"weekend_breaks_enabled"`
+
+	// Break verification
+	VerifyGazeAway              bool `json:"verify_gaze_away"`               // Uses the camera, breaks spent staring at the screen don't count
+	InferCompletionFromActivity bool `json:"infer_completion_from_activity"` // No input during a break completes it, typing through it skips it
+
+	// Integrations
+	APIPort int `json:"api_port"` // Local HTTP API port, 0 = disabled
+
+	// Webhook
+	WebhookURL string `json:"webhook_url"` // Receives break outcomes and daily summaries as JSON, empty = off
+
+	// Break commands
+	OnBreakStartCommand string `json:"on_break_start_command"` // Shell command run when a break starts, empty = off
+	OnBreakEndCommand   string `json:"on_break_end_command"`   // Shell command run when a break ends, empty = off
+
+	// Shortcuts
+	PauseHotkey string `json:"pause_hotkey"` // e.g. "cmd+opt+p", empty = disabled
+
+	// Daily review
+	DailyReviewTime string `json:"daily_review_time"` // HH:MM, empty = off
+	LastDailyReview string `json:"last_daily_review"` // Date of the last review shown, YYYY-MM-DD
+
+	// Notifications
+	NotifyOnResumeFromIdle  bool `json:"notify_on_resume_from_idle"`
+	MaxNotificationsPerHour int  `json:"max_notifications_per_hour"` // Less important notifications are dropped first, 0 = no cap
+	ShowCatchUpSummary      bool `json:"show_catch_up_summary"`      // Sum up the breaks missed while away on return
+
+	// Self-reports
+	AskRatingEvery int  `json:"ask_rating_every"` // Ask for an eye comfort rating every Nth break, 0 = never
+	AskSkipReason  bool `json:"ask_skip_reason"`  // Ask why after a break was skipped
+
+	// Goals
+	DailyComplianceGoal float64 `json:"daily_compliance_goal"` // Percent, 0 = no goal
+	PostponeCounts      string  `json:"postpone_counts"`
+	ComplianceMode      string  `json:"compliance_mode"`
+	WeekStartsOn        string  `json:"week_starts_on"` // First day of calendar week reports
+
+	// Rest time goal
+	DailyRestGoalMinutes int `json:"daily_rest_goal_minutes"` // Total completed break time per day, 0 = no goal
+
+	// Streaks
+	StreakFreezes int `json:"streak_freezes"` // Days below the goal a streak survives, 0 = none
+
+	// Data retention
+	RetentionDays int `json:"retention_days"` // Breaks and sessions older than this are pruned, 0 = keep forever
+
+	// Diagnostics
+	EventLogPath string `json:"event_log_path"` // JSON lines timer event log, empty = off
+
+	// Onboarding
+	OnboardingStepsDone []string `json:"onboarding_steps_done"`
 }
 
 // DefaultConfig returns a new Config with sensible defaults
@@ -25,22 +321,395 @@ func DefaultConfig() *Config {
 		NotificationSound: true,
 		OverlayOpacity:    0.95,
 		FirstRun:          true,
+
+		SoundVolume:     0.5,
+		BreakStartSound: "Glass",
+
+		BreakStyle: BreakStyleOverlay,
+		BreakType:  BreakTypeStandard,
+		BreathingPacing: BreathingPacing{
+			Inhale:  4 * time.Second,
+			HoldIn:  4 * time.Second,
+			Exhale:  4 * time.Second,
+			HoldOut: 4 * time.Second,
+		},
+		BreakSequence:      nil,
+		OverlayWindowLevel: OverlayLevelScreenSaver,
+		PauseOnScreenShare: false,
+		OverlayMode:        OverlayModeFullscreen,
+		BannerCorner:       BannerCornerTopRight,
+
+		OverlayShowNextBreak:    false,
+		OverlayActiveScreenOnly: false,
+		GazeTargetExercise:      false,
+
+		HighContrast: false,
+
+		DimMoreAtNight: false,
+
+		DimBrightnessDuringBreak: false,
+		BreakBrightnessLevel:     0.3,
+
+		OverlayThemes: nil,
+
+		OverlayMessagesFile: "",
+
+		OverlayClickAction: OverlayClickNone,
+
+		RebuildOverlayOnScreenChange: true,
+
+		AllowEarlyDismiss: false,
+		MinBreakFraction:  0.5,
+
+		PreBreakDimDuration: 0,
+
+		PreBreakWarning: 0,
+
+		GentleFirstBreak:               false,
+		DeferBreaksDuringPresentations: false,
+
+		DeferInApps:    nil,
+		MaxAppDeferral: 15 * time.Minute,
+
+		InitialDelay: 0,
+
+		RequestPermissionsOnStart: true,
+
+		MaxExtension: 15 * time.Minute,
+
+		IntervalJitter: 0,
+
+		AdaptiveInterval:    false,
+		AdaptiveMinInterval: 15 * time.Minute,
+		AdaptiveMaxInterval: 30 * time.Minute,
+
+		LongSessionThreshold: 0,
+
+		MaxSessionHours: 0,
+		SessionCooldown: 0,
+
+		IdleAutoPauseEnabled: true,
+
+		CatchUpBreaks: false,
+
+		ResetAfterPauseLongerThan: 0,
+
+		PauseOnBattery: false,
+
+		CadenceSchedule: nil,
+
+		PerAppWorkDuration: nil,
+
+		StartRemindersAt: "",
+		StopRemindersAt:  "",
+
+		WeekendWorkDuration:  0,
+		WeekendBreaksEnabled: true,
+
+		VerifyGazeAway:              false,
+		InferCompletionFromActivity: false,
+
+		APIPort: 0,
+
+		WebhookURL: "",
+
+		OnBreakStartCommand: "",
+		OnBreakEndCommand:   "",
+
+		PauseHotkey: "",
+
+		DailyReviewTime: "",
+		LastDailyReview: "",
+
+		NotifyOnResumeFromIdle:  false,
+		MaxNotificationsPerHour: 0,
+		ShowCatchUpSummary:      false,
+
+		AskRatingEvery: 0,
+		AskSkipReason:  false,
+
+		DailyComplianceGoal: 80,
+		PostponeCounts:      PostponeCountsNeutral,
+		ComplianceMode:      ComplianceOfRequired,
+		WeekStartsOn:        WeekStartsMonday,
+
+		DailyRestGoalMinutes: 0,
+
+		StreakFreezes: 0,
+
+		RetentionDays: 0,
+
+		EventLogPath: "",
 	}
 }
 
-// Validate checks if the configuration values are valid
+// WeekStartDay returns the first day of the week for calendar week reports
+func (c *Config) WeekStartDay() time.Weekday {
+	if c.WeekStartsOn == WeekStartsSunday {
+		return time.Sunday
+	}
+	return time.Monday
+}
+
+// MaxSession returns the continuous work time after which a session limit
+// break follows, or 0 if sessions aren't limited
+func (c *Config) MaxSession() time.Duration {
+	return time.Duration(c.MaxSessionHours * float64(time.Hour))
+}
+
+// IsStepDone returns whether an onboarding step has been completed
+func (c *Config) IsStepDone(step string) bool {
+	for _, done := range c.OnboardingStepsDone {
+		if done == step {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkStepDone records an onboarding step as completed
+func (c *Config) MarkStepDone(step string) {
+	if c.IsStepDone(step) {
+		return
+	}
+	c.OnboardingStepsDone = append(c.OnboardingStepsDone, step)
+}
+
+// PendingOnboardingSteps returns the onboarding steps not yet completed, in order
+func (c *Config) PendingOnboardingSteps() []string {
+	var pending []string
+	for _, step := range OnboardingSteps {
+		if !c.IsStepDone(step) {
+			pending = append(pending, step)
+		}
+	}
+	return pending
+}
+
+// Validate checks if the configuration values are valid using StrictLimits
 func (c *Config) Validate() error {
-	if c.WorkDuration < 1*time.Minute {
+	return c.ValidateWithLimits(StrictLimits)
+}
+
+// ValidateWithLimits checks if the configuration values are valid, using
+// limits for the minimum durations
+func (c *Config) ValidateWithLimits(limits Limits) error {
+	if c.WorkDuration < limits.MinWorkDuration {
 		return ErrInvalidWorkDuration
 	}
-	if c.BreakDuration < 1*time.Second {
+	if c.BreakDuration < limits.MinBreakDuration {
 		return ErrInvalidBreakDuration
 	}
-	if c.IdleThreshold < 1*time.Minute {
+	if c.IdleThreshold < limits.MinIdleThreshold {
 		return ErrInvalidIdleThreshold
 	}
 	if c.OverlayOpacity < 0.0 || c.OverlayOpacity > 1.0 {
 		return ErrInvalidOpacity
 	}
+	if c.BreakStyle != BreakStyleOverlay && c.BreakStyle != BreakStyleNotification {
+		return ErrInvalidBreakStyle
+	}
+	if c.BreakType != BreakTypeStandard && c.BreakType != BreakTypeBreathing {
+		return ErrInvalidBreakType
+	}
+	for _, phase := range []time.Duration{
+		c.BreathingPacing.Inhale,
+		c.BreathingPacing.HoldIn,
+		c.BreathingPacing.Exhale,
+		c.BreathingPacing.HoldOut,
+	} {
+		if phase < 1*time.Second {
+			return ErrInvalidBreathingPacing
+		}
+	}
+	if len(c.BreakSequence) > 0 {
+		total := 0.0
+		for _, step := range c.BreakSequence {
+			if step.Text == "" || step.Fraction <= 0 {
+				return ErrInvalidBreakSequence
+			}
+			total += step.Fraction
+		}
+		if math.Abs(total-1.0) > breakSequenceTolerance {
+			return ErrInvalidBreakSequence
+		}
+	}
+	switch c.OverlayWindowLevel {
+	case OverlayLevelScreenSaver, OverlayLevelFloating, OverlayLevelNormal:
+	default:
+		return ErrInvalidOverlayWindowLevel
+	}
+	switch c.OverlayMode {
+	case OverlayModeFullscreen, OverlayModeBanner:
+	default:
+		return ErrInvalidOverlayMode
+	}
+	switch c.BannerCorner {
+	case BannerCornerTopLeft, BannerCornerTopRight, BannerCornerBottomLeft, BannerCornerBottomRight:
+	default:
+		return ErrInvalidBannerCorner
+	}
+	if c.SoundVolume < 0 || c.SoundVolume > 1 {
+		return ErrInvalidSoundVolume
+	}
+	if c.BreakBrightnessLevel < 0 || c.BreakBrightnessLevel > 1 {
+		return ErrInvalidBreakBrightnessLevel
+	}
+	if !validSoundName(c.BreakStartSound) {
+		return ErrInvalidSoundName
+	}
+	for _, theme := range c.OverlayThemes {
+		if theme.Background == "" {
+			continue
+		}
+		if _, _, _, err := ParseHexColor(theme.Background); err != nil {
+			return ErrInvalidOverlayTheme
+		}
+	}
+	if c.OverlayMessagesFile != "" && !filepath.IsAbs(c.OverlayMessagesFile) {
+		return ErrInvalidMessagesFile
+	}
+	switch c.OverlayClickAction {
+	case OverlayClickNone, OverlayClickComplete, OverlayClickPostpone:
+	default:
+		return ErrInvalidOverlayClickAction
+	}
+	if c.MinBreakFraction < 0 || c.MinBreakFraction > 1 {
+		return ErrInvalidMinBreakFraction
+	}
+	if c.PreBreakDimDuration < 0 || c.PreBreakDimDuration >= c.WorkDuration {
+		return ErrInvalidPreBreakDim
+	}
+	if c.PreBreakWarning < 0 || c.PreBreakWarning >= c.WorkDuration {
+		return ErrInvalidPreBreakWarning
+	}
+	if c.WorkDuration+c.InitialDelay < limits.MinWorkDuration {
+		return ErrInvalidInitialDelay
+	}
+	if c.MaxExtension < 0 {
+		return ErrInvalidMaxExtension
+	}
+	if c.ResetAfterPauseLongerThan < 0 {
+		return ErrInvalidResetAfterPause
+	}
+	if c.IntervalJitter < 0 || c.WorkDuration-c.IntervalJitter < limits.MinWorkDuration {
+		return ErrInvalidIntervalJitter
+	}
+	if c.AdaptiveInterval && (c.AdaptiveMinInterval < limits.MinWorkDuration || c.AdaptiveMinInterval > c.AdaptiveMaxInterval) {
+		return ErrInvalidAdaptiveInterval
+	}
+	if c.LongSessionThreshold != 0 && c.LongSessionThreshold < c.WorkDuration {
+		return ErrInvalidLongSessionThreshold
+	}
+	if c.MaxSessionHours != 0 && c.MaxSession() < c.WorkDuration {
+		return ErrInvalidMaxSession
+	}
+	if c.SessionCooldown < 0 || c.SessionCooldown > MaxSessionCooldown {
+		return ErrInvalidSessionCooldown
+	}
+	for _, window := range c.CadenceSchedule {
+		if window.StartHour < 0 || window.StartHour >= window.EndHour || window.EndHour > 24 {
+			return ErrInvalidCadenceSchedule
+		}
+		if window.WorkDuration < limits.MinWorkDuration {
+			return ErrInvalidCadenceSchedule
+		}
+	}
+	for _, bundleID := range c.DeferInApps {
+		if bundleID == "" || c.MaxAppDeferral <= 0 {
+			return ErrInvalidDeferInApps
+		}
+	}
+	for bundleID, duration := range c.PerAppWorkDuration {
+		if bundleID == "" || duration < limits.MinWorkDuration {
+			return ErrInvalidPerAppWorkDuration
+		}
+	}
+	if c.StartRemindersAt != "" || c.StopRemindersAt != "" {
+		if _, _, err := ParseTimeOfDay(c.StartRemindersAt); err != nil {
+			return ErrInvalidRemindersWindow
+		}
+		if _, _, err := ParseTimeOfDay(c.StopRemindersAt); err != nil {
+			return ErrInvalidRemindersWindow
+		}
+		if c.StartRemindersAt == c.StopRemindersAt {
+			return ErrInvalidRemindersWindow
+		}
+	}
+	if c.WeekendWorkDuration != 0 && c.WeekendWorkDuration < limits.MinWorkDuration {
+		return ErrInvalidWeekendWorkDuration
+	}
+	if c.APIPort < 0 || c.APIPort > 65535 {
+		return ErrInvalidAPIPort
+	}
+	if c.WebhookURL != "" && !validWebhookURL(c.WebhookURL) {
+		return ErrInvalidWebhookURL
+	}
+	if c.DailyComplianceGoal < 0 || c.DailyComplianceGoal > 100 {
+		return ErrInvalidComplianceGoal
+	}
+	if c.DailyRestGoalMinutes < 0 {
+		return ErrInvalidRestGoal
+	}
+	if c.StreakFreezes < 0 {
+		return ErrInvalidStreakFreezes
+	}
+	if c.DailyReviewTime != "" {
+		if _, _, err := ParseTimeOfDay(c.DailyReviewTime); err != nil {
+			return ErrInvalidDailyReviewTime
+		}
+	}
+	if c.MaxNotificationsPerHour < 0 {
+		return ErrInvalidMaxNotificationsPerHour
+	}
+	if c.AskRatingEvery < 0 {
+		return ErrInvalidAskRatingEvery
+	}
+	switch c.PostponeCounts {
+	case PostponeCountsNeutral, PostponeCountsPartial, PostponeCountsSkip:
+	default:
+		return ErrInvalidPostponeCounts
+	}
+	switch c.ComplianceMode {
+	case ComplianceOfRequired, ComplianceOfDecided:
+	default:
+		return ErrInvalidComplianceMode
+	}
+	if c.WeekStartsOn != WeekStartsMonday && c.WeekStartsOn != WeekStartsSunday {
+		return ErrInvalidWeekStart
+	}
+	if c.RetentionDays < 0 {
+		return ErrInvalidRetentionDays
+	}
 	return nil
 }
+
+// soundLookup checks that a sound name refers to an existing sound. The
+// sound package can't be imported here, so the app installs it with
+// SetSoundLookup.
+var soundLookup func(name string) error
+
+// SetSoundLookup makes validation reject break start sounds lookup can't
+// find. Call it before loading the config; without a lookup only the form
+// of sound names is checked.
+func SetSoundLookup(lookup func(name string) error) {
+	soundLookup = lookup
+}
+
+// validSoundName returns whether name can refer to a sound: an absolute path
+// or a plain sound name that the sound lookup, if any, finds
+func validSoundName(name string) bool {
+	if !filepath.IsAbs(name) && (strings.TrimSpace(name) == "" || strings.ContainsRune(name, filepath.Separator)) {
+		return false
+	}
+	return soundLookup == nil || soundLookup(name) == nil
+}
+
+// validWebhookURL returns whether raw is an absolute http or https URL
+func validWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
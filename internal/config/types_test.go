@@ -0,0 +1,109 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateBreathingPacing(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *BreathingPacing)
+		want   error
+	}{
+		{"default", func(p *BreathingPacing) {}, nil},
+		{"one second phases", func(p *BreathingPacing) { *p = BreathingPacing{time.Second, time.Second, time.Second, time.Second} }, nil},
+		{"short inhale", func(p *BreathingPacing) { p.Inhale = 500 * time.Millisecond }, ErrInvalidBreathingPacing},
+		{"no hold", func(p *BreathingPacing) { p.HoldOut = 0 }, ErrInvalidBreathingPacing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.BreakType = BreakTypeBreathing
+			tt.modify(&cfg.BreathingPacing)
+			if err := cfg.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBreakType(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BreakType = "yoga"
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidBreakType) {
+		t.Errorf("Validate() = %v, want %v", err, ErrInvalidBreakType)
+	}
+}
+
+func TestValidateBreakStartSound(t *testing.T) {
+	installed := map[string]bool{"Glass": true, "/Users/me/ding.mp3": true}
+	SetSoundLookup(func(name string) error {
+		if !installed[name] {
+			return errors.New("sound not found")
+		}
+		return nil
+	})
+	t.Cleanup(func() { SetSoundLookup(nil) })
+
+	tests := []struct {
+		sound string
+		want  error
+	}{
+		{"Glass", nil},
+		{"/Users/me/ding.mp3", nil},
+		{"Gong", ErrInvalidSoundName},
+		{"/Users/me/gone.mp3", ErrInvalidSoundName},
+		{"Sounds/Glass", ErrInvalidSoundName},
+		{" ", ErrInvalidSoundName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sound, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.BreakStartSound = tt.sound
+			if err := cfg.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBreakStartSoundWithoutLookup(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BreakStartSound = "Gong"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() = %v without a sound lookup, want nil", err)
+	}
+}
+
+func TestValidateMaxSessionHours(t *testing.T) {
+	tests := []struct {
+		hours float64
+		want  error
+	}{
+		{0, nil},
+		{3, nil},
+		{0.5, nil},
+		{0.25, ErrInvalidMaxSession},
+		{-1, ErrInvalidMaxSession},
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.MaxSessionHours = tt.hours
+		if err := cfg.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("Validate() with %.2f hours = %v, want %v", tt.hours, err, tt.want)
+		}
+	}
+}
+
+func TestMaxSession(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxSessionHours = 2.5
+	if got := cfg.MaxSession(); got != 150*time.Minute {
+		t.Errorf("MaxSession() = %s, want 2h30m", got)
+	}
+}
@@ -0,0 +1,108 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// Database is the part of the stats store the database check needs
+type Database interface {
+	CheckWritable() error
+	CheckSchema() error
+}
+
+// DatabaseCheck checks that the stats database accepts writes and has the
+// current schema
+func DatabaseCheck(db Database) Check {
+	return Check{
+		Name: "Datenbank",
+		Run: func() (string, error) {
+			if db == nil {
+				return "", errors.New("nicht geöffnet")
+			}
+			if err := db.CheckWritable(); err != nil {
+				return "", fmt.Errorf("nicht beschreibbar: %w", err)
+			}
+			if err := db.CheckSchema(); err != nil {
+				return "", fmt.Errorf("Schema veraltet: %w", err)
+			}
+			return "beschreibbar, Schema aktuell", nil
+		},
+	}
+}
+
+// ConfigCheck checks that the running configuration is valid
+func ConfigCheck(cfg *config.Config) Check {
+	return Check{
+		Name: "Konfiguration",
+		Run: func() (string, error) {
+			if cfg == nil {
+				return "", errors.New("nicht geladen")
+			}
+			if err := cfg.ValidateWithLimits(config.ActiveLimits()); err != nil {
+				return "", err
+			}
+			return "gültig", nil
+		},
+	}
+}
+
+// ScreensCheck checks that at least one screen is detected
+func ScreensCheck(screenCount func() int) Check {
+	return Check{
+		Name: "Bildschirme",
+		Run: func() (string, error) {
+			count := screenCount()
+			if count == 0 {
+				return "", errors.New("kein Bildschirm erkannt")
+			}
+			return fmt.Sprintf("%d erkannt", count), nil
+		},
+	}
+}
+
+// IdleCheck checks that the system reports the user's idle time
+func IdleCheck(idleTime func() (time.Duration, error)) Check {
+	return Check{
+		Name: "Inaktivitätserkennung",
+		Run: func() (string, error) {
+			idle, err := idleTime()
+			if err != nil {
+				return "", err
+			}
+			if idle < 0 {
+				return "", fmt.Errorf("ungültige Inaktivitätsdauer %s", idle)
+			}
+			return fmt.Sprintf("inaktiv seit %s", idle.Round(time.Second)), nil
+		},
+	}
+}
+
+// LaunchAgentCheck checks that the launch agent at path is installed when
+// the app should start on login, using exists to look for the file
+func LaunchAgentCheck(path string, autoStart bool, exists func(string) bool) Check {
+	return Check{
+		Name: "Autostart",
+		Run: func() (string, error) {
+			installed := exists(path)
+			switch {
+			case installed:
+				return "Launch Agent installiert", nil
+			case autoStart:
+				return "", fmt.Errorf("Start bei Anmeldung ist aktiviert, aber %s fehlt", path)
+			default:
+				return "deaktiviert", nil
+			}
+		},
+	}
+}
+
+// FileExists returns whether a file exists at path
+func FileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
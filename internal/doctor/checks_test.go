@@ -0,0 +1,90 @@
+package doctor
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// fakeDatabase fails the checks given an error
+type fakeDatabase struct {
+	writableErr error
+	schemaErr   error
+}
+
+func (f fakeDatabase) CheckWritable() error { return f.writableErr }
+func (f fakeDatabase) CheckSchema() error   { return f.schemaErr }
+
+// runOne runs check and returns its result
+func runOne(check Check) Result {
+	return Run([]Check{check}).Results[0]
+}
+
+func TestChecks(t *testing.T) {
+	invalidConfig := config.DefaultConfig()
+	invalidConfig.OverlayOpacity = 5
+	agentPath := filepath.Join(t.TempDir(), "com.example.2020rule.plist")
+
+	tests := []struct {
+		name       string
+		check      Check
+		wantOK     bool
+		wantDetail string
+	}{
+		{"database not open", DatabaseCheck(nil), false, "nicht geöffnet"},
+		{"database read-only", DatabaseCheck(fakeDatabase{writableErr: errors.New("readonly database")}), false, "nicht beschreibbar: readonly database"},
+		{"database schema outdated", DatabaseCheck(fakeDatabase{schemaErr: errors.New("version 3")}), false, "Schema veraltet: version 3"},
+		{"database fine", DatabaseCheck(fakeDatabase{}), true, "beschreibbar, Schema aktuell"},
+
+		{"config not loaded", ConfigCheck(nil), false, "nicht geladen"},
+		{"config invalid", ConfigCheck(invalidConfig), false, "opacity"},
+		{"config valid", ConfigCheck(config.DefaultConfig()), true, "gültig"},
+
+		{"no screens", ScreensCheck(func() int { return 0 }), false, "kein Bildschirm erkannt"},
+		{"two screens", ScreensCheck(func() int { return 2 }), true, "2 erkannt"},
+
+		{"idle time unavailable", IdleCheck(func() (time.Duration, error) { return 0, errors.New("no HID service") }), false, "no HID service"},
+		{"negative idle time", IdleCheck(func() (time.Duration, error) { return -time.Second, nil }), false, "ungültige Inaktivitätsdauer -1s"},
+		{"idle time", IdleCheck(func() (time.Duration, error) { return 1500 * time.Millisecond, nil }), true, "inaktiv seit 2s"},
+
+		{"launch agent missing", LaunchAgentCheck(agentPath, true, FileExists), false, agentPath + " fehlt"},
+		{"autostart off", LaunchAgentCheck(agentPath, false, FileExists), true, "deaktiviert"},
+		{"launch agent installed", LaunchAgentCheck(agentPath, true, func(string) bool { return true }), true, "Launch Agent installiert"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runOne(tt.check)
+			if result.OK != tt.wantOK {
+				t.Errorf("OK = %t, want %t (detail %q)", result.OK, tt.wantOK, result.Detail)
+			}
+			if !strings.Contains(result.Detail, tt.wantDetail) {
+				t.Errorf("detail = %q, want it to contain %q", result.Detail, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestRunSurvivesPanickingCheck(t *testing.T) {
+	report := Run([]Check{
+		{Name: "broken", Run: func() (string, error) { panic("nil map") }},
+		ScreensCheck(func() int { return 1 }),
+	})
+
+	if report.OK() {
+		t.Error("report with a panicking check is OK")
+	}
+	if len(report.Results) != 2 {
+		t.Fatalf("%d results, want 2", len(report.Results))
+	}
+	if got := report.Results[0]; got.OK || got.Detail != "panic: nil map" {
+		t.Errorf("panicking check = %+v, want a failure", got)
+	}
+	if !report.Results[1].OK {
+		t.Error("check after the panicking one didn't run")
+	}
+}
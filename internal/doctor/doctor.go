@@ -0,0 +1,83 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Check is a single diagnostic. Run returns a short description of what it
+// found, or an error if the check failed.
+type Check struct {
+	Name string
+	Run  func() (string, error)
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+// Report collects the results of all checks for a bug report
+type Report struct {
+	CreatedAt time.Time `json:"created_at"`
+	Results   []Result  `json:"results"`
+}
+
+// Run runs checks in order. A failing or panicking check doesn't stop the
+// others.
+func Run(checks []Check) Report {
+	report := Report{CreatedAt: time.Now()}
+	for _, check := range checks {
+		report.Results = append(report.Results, runCheck(check))
+	}
+	return report
+}
+
+// runCheck runs a single check, turning a panic into a failed result
+func runCheck(check Check) (result Result) {
+	result.Name = check.Name
+	defer func() {
+		if r := recover(); r != nil {
+			result.OK = false
+			result.Detail = fmt.Sprintf("panic: %v", r)
+		}
+	}()
+
+	detail, err := check.Run()
+	if err != nil {
+		return Result{Name: check.Name, OK: false, Detail: err.Error()}
+	}
+	return Result{Name: check.Name, OK: true, Detail: detail}
+}
+
+// OK returns whether all checks passed
+func (r Report) OK() bool {
+	for _, result := range r.Results {
+		if !result.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the report with one line per check
+func (r Report) String() string {
+	var b strings.Builder
+	for _, result := range r.Results {
+		mark := "✓"
+		if !result.OK {
+			mark = "✗"
+		}
+		fmt.Fprintf(&b, "%s %s: %s\n", mark, result.Name, result.Detail)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// JSON returns the report as indented JSON
+func (r Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
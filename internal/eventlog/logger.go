@@ -0,0 +1,112 @@
+package eventlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// DefaultMaxSize is the log file size at which Open rotates the log
+const DefaultMaxSize = 5 << 20
+
+// Logger writes records as JSON lines, one object per line
+type Logger struct {
+	w       io.Writer
+	file    *os.File // Only set for file-backed loggers
+	path    string
+	maxSize int64
+	size    int64
+	mu      sync.Mutex
+}
+
+// New creates a logger writing to w without rotation
+func New(w io.Writer) *Logger {
+	return &Logger{w: w}
+}
+
+// Open creates a logger appending to the file at path. Once the file would
+// exceed maxSize it is moved to path + ".1" and a new file is started.
+func Open(path string, maxSize int64) (*Logger, error) {
+	l := &Logger{
+		path:    path,
+		maxSize: maxSize,
+	}
+	if err := l.openFile(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Path returns the file the logger writes to, or "" if it isn't file-backed
+func (l *Logger) Path() string {
+	return l.path
+}
+
+// Log writes record as a single JSON line
+func (l *Logger) Log(record interface{}) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file != nil && l.maxSize > 0 && l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.w.Write(line)
+	l.size += int64(n)
+	return err
+}
+
+// Close closes the underlying file, if any
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	l.w = io.Discard
+	return err
+}
+
+// openFile opens the log file for appending and records its current size
+func (l *Logger) openFile() error {
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat event log: %w", err)
+	}
+
+	l.file = file
+	l.w = file
+	l.size = info.Size()
+	return nil
+}
+
+// rotate moves the current log aside and starts a new one.
+// Must be called with l.mu held.
+func (l *Logger) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("failed to close event log: %w", err)
+	}
+	if err := os.Rename(l.path, l.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate event log: %w", err)
+	}
+	return l.openFile()
+}
@@ -0,0 +1,126 @@
+package eventlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// record is what the tests log
+type record struct {
+	Event string `json:"event"`
+	N     int    `json:"n"`
+}
+
+// readLines decodes the JSON lines of a log
+func readLines(t *testing.T, data []byte) []record {
+	t.Helper()
+	var records []record
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var r record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestLogWritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	for i := range 3 {
+		if err := l.Log(record{Event: "tick", N: i}); err != nil {
+			t.Fatalf("Log: %v", err)
+		}
+	}
+
+	if n := strings.Count(buf.String(), "\n"); n != 3 {
+		t.Fatalf("%d lines written, want 3", n)
+	}
+	for i, r := range readLines(t, buf.Bytes()) {
+		if r.N != i {
+			t.Errorf("line %d holds record %d", i, r.N)
+		}
+	}
+	if l.Path() != "" {
+		t.Errorf("Path() = %q for a writer, want none", l.Path())
+	}
+}
+
+func TestLogRejectsUnmarshalableRecords(t *testing.T) {
+	var buf bytes.Buffer
+	if err := New(&buf).Log(func() {}); err == nil {
+		t.Error("Log(func) succeeded")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %q for a failed record", buf.String())
+	}
+}
+
+func TestOpenAppendsAndRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	line, _ := json.Marshal(record{Event: "tick"})
+	lineSize := int64(len(line) + 1)
+
+	l, err := Open(path, 3*lineSize)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	for i := range 2 {
+		if err := l.Log(record{Event: "tick", N: i}); err != nil {
+			t.Fatalf("Log: %v", err)
+		}
+	}
+	l.Close()
+
+	// Reopening continues the file and counts what is already in it
+	l, err = Open(path, 3*lineSize)
+	if err != nil {
+		t.Fatalf("Open again: %v", err)
+	}
+	defer l.Close()
+	for i := 2; i < 5; i++ {
+		if err := l.Log(record{Event: "tick", N: i}); err != nil {
+			t.Fatalf("Log: %v", err)
+		}
+	}
+
+	rotated, err := os.ReadFile(path + ".1")
+	if err != nil {
+		t.Fatalf("read rotated log: %v", err)
+	}
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if got := readLines(t, rotated); len(got) != 3 || got[0].N != 0 || got[2].N != 2 {
+		t.Errorf("rotated log holds %v, want records 0 to 2", got)
+	}
+	if got := readLines(t, current); len(got) != 2 || got[0].N != 3 || got[1].N != 4 {
+		t.Errorf("log holds %v, want records 3 and 4", got)
+	}
+}
+
+func TestLogAfterClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	l, err := Open(path, DefaultMaxSize)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := l.Log(record{Event: "late"}); err != nil {
+		t.Errorf("Log after Close: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if len(data) != 0 {
+		t.Errorf("wrote %q after Close", data)
+	}
+}
@@ -0,0 +1,190 @@
+package gaze
+
+/*
+#cgo CFLAGS: -x objective-c -fobjc-arc
+#cgo LDFLAGS: -framework Foundation -framework AVFoundation -framework CoreMedia -framework CoreVideo -framework Vision
+#import <AVFoundation/AVFoundation.h>
+#import <Vision/Vision.h>
+
+enum {
+	gazeOK = 0,
+	gazeDenied = 1,
+	gazeNoCamera = 2,
+};
+
+// Faces turned further than this (in radians) don't look at the screen
+static const double gazeMaxYaw = 0.35;
+
+// Keeps the most recent camera frame
+@interface GazeFrameGrabber : NSObject <AVCaptureVideoDataOutputSampleBufferDelegate> {
+	CVPixelBufferRef latest;
+}
+- (CVPixelBufferRef)copyLatest;
+@end
+
+@implementation GazeFrameGrabber
+- (void)captureOutput:(AVCaptureOutput *)output
+    didOutputSampleBuffer:(CMSampleBufferRef)sampleBuffer
+           fromConnection:(AVCaptureConnection *)connection {
+	CVPixelBufferRef frame = CMSampleBufferGetImageBuffer(sampleBuffer);
+	if (frame == NULL) {
+		return;
+	}
+	@synchronized (self) {
+		if (latest != NULL) {
+			CVPixelBufferRelease(latest);
+		}
+		latest = CVPixelBufferRetain(frame);
+	}
+}
+
+- (CVPixelBufferRef)copyLatest {
+	@synchronized (self) {
+		return latest != NULL ? CVPixelBufferRetain(latest) : NULL;
+	}
+}
+
+- (void)dealloc {
+	if (latest != NULL) {
+		CVPixelBufferRelease(latest);
+	}
+}
+@end
+
+static AVCaptureSession *gazeSession;
+static GazeFrameGrabber *gazeGrabber;
+static dispatch_queue_t gazeQueue;
+
+// Asks for camera access if the user hasn't decided yet. Blocks while the
+// permission prompt is shown.
+static int gazeAuthorize(void) {
+	AVAuthorizationStatus status = [AVCaptureDevice authorizationStatusForMediaType:AVMediaTypeVideo];
+	if (status == AVAuthorizationStatusNotDetermined) {
+		dispatch_semaphore_t answered = dispatch_semaphore_create(0);
+		__block BOOL granted = NO;
+		[AVCaptureDevice requestAccessForMediaType:AVMediaTypeVideo completionHandler:^(BOOL ok) {
+			granted = ok;
+			dispatch_semaphore_signal(answered);
+		}];
+		dispatch_semaphore_wait(answered, DISPATCH_TIME_FOREVER);
+		return granted ? gazeOK : gazeDenied;
+	}
+	return status == AVAuthorizationStatusAuthorized ? gazeOK : gazeDenied;
+}
+
+static int gazeStart(void) {
+	@autoreleasepool {
+		int auth = gazeAuthorize();
+		if (auth != gazeOK) {
+			return auth;
+		}
+
+		AVCaptureDevice *device = [AVCaptureDevice defaultDeviceWithMediaType:AVMediaTypeVideo];
+		if (device == nil) {
+			return gazeNoCamera;
+		}
+		AVCaptureDeviceInput *input = [AVCaptureDeviceInput deviceInputWithDevice:device error:nil];
+		if (input == nil) {
+			return gazeNoCamera;
+		}
+
+		AVCaptureSession *session = [[AVCaptureSession alloc] init];
+		session.sessionPreset = AVCaptureSessionPresetLow;
+		if (![session canAddInput:input]) {
+			return gazeNoCamera;
+		}
+		[session addInput:input];
+
+		if (gazeQueue == NULL) {
+			gazeQueue = dispatch_queue_create("com.siegfried.2020rule.gaze", DISPATCH_QUEUE_SERIAL);
+		}
+		GazeFrameGrabber *grabber = [[GazeFrameGrabber alloc] init];
+		AVCaptureVideoDataOutput *output = [[AVCaptureVideoDataOutput alloc] init];
+		output.alwaysDiscardsLateVideoFrames = YES;
+		[output setSampleBufferDelegate:grabber queue:gazeQueue];
+		if (![session canAddOutput:output]) {
+			return gazeNoCamera;
+		}
+		[session addOutput:output];
+
+		[session startRunning];
+		gazeSession = session;
+		gazeGrabber = grabber;
+		return gazeOK;
+	}
+}
+
+// Returns 1 if a face turned towards the screen is in the latest frame,
+// 0 if not and -1 if there is no frame to check
+static int gazeLookingAtScreen(void) {
+	@autoreleasepool {
+		CVPixelBufferRef frame = [gazeGrabber copyLatest];
+		if (frame == NULL) {
+			return -1;
+		}
+
+		VNDetectFaceRectanglesRequest *request = [[VNDetectFaceRectanglesRequest alloc] init];
+		VNImageRequestHandler *handler = [[VNImageRequestHandler alloc] initWithCVPixelBuffer:frame options:@{}];
+		BOOL ok = [handler performRequests:@[request] error:nil];
+		CVPixelBufferRelease(frame);
+		if (!ok) {
+			return -1;
+		}
+
+		for (VNFaceObservation *face in request.results) {
+			if (face.yaw == nil || fabs(face.yaw.doubleValue) < gazeMaxYaw) {
+				return 1;
+			}
+		}
+		return 0;
+	}
+}
+
+static void gazeStop(void) {
+	@autoreleasepool {
+		[gazeSession stopRunning];
+		gazeSession = nil;
+		gazeGrabber = nil;
+	}
+}
+*/
+import "C"
+
+// cameraDetector detects faces in the built-in camera's picture using the
+// Vision framework. Only face presence and head orientation are checked;
+// frames are never stored.
+type cameraDetector struct{}
+
+// NewCameraDetector creates a Detector backed by the default camera
+func NewCameraDetector() Detector {
+	return cameraDetector{}
+}
+
+// Start opens the camera, asking for permission on first use
+func (cameraDetector) Start() error {
+	switch C.gazeStart() {
+	case C.gazeOK:
+		return nil
+	case C.gazeDenied:
+		return ErrPermissionDenied
+	default:
+		return ErrNoCamera
+	}
+}
+
+// LookingAtScreen checks the latest camera frame for a face turned towards the screen
+func (cameraDetector) LookingAtScreen() (bool, error) {
+	switch C.gazeLookingAtScreen() {
+	case 1:
+		return true, nil
+	case 0:
+		return false, nil
+	default:
+		return false, ErrNoFrame
+	}
+}
+
+// Stop closes the camera
+func (cameraDetector) Stop() {
+	C.gazeStop()
+}
@@ -0,0 +1,14 @@
+package gaze
+
+import "errors"
+
+var (
+	// ErrPermissionDenied is returned when the user denied camera access
+	ErrPermissionDenied = errors.New("camera access denied")
+
+	// ErrNoCamera is returned when no camera is available
+	ErrNoCamera = errors.New("no camera available")
+
+	// ErrNoFrame is returned when the camera hasn't delivered a frame yet
+	ErrNoFrame = errors.New("no camera frame available")
+)
@@ -0,0 +1,139 @@
+package gaze
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// sampleInterval is how often the camera is checked during a break
+	sampleInterval = 2 * time.Second
+	// maxLookingShare is the share of samples the user may spend looking at
+	// the screen for the break to still count as looked away
+	maxLookingShare = 0.5
+)
+
+// Detector reports whether the user is looking at the screen
+type Detector interface {
+	// Start opens the camera. It returns ErrPermissionDenied if the user
+	// denied camera access.
+	Start() error
+	// LookingAtScreen reports whether a face turned towards the screen is in view
+	LookingAtScreen() (bool, error)
+	// Stop closes the camera
+	Stop()
+}
+
+// lookedAway decides whether a break counts as looked away given how many
+// of total samples saw the user looking at the screen. Without any samples
+// the user gets the benefit of the doubt.
+func lookedAway(looking, total int) bool {
+	if total == 0 {
+		return true
+	}
+	return float64(looking)/float64(total) <= maxLookingShare
+}
+
+// Verifier samples a Detector during a break to decide whether the user
+// actually looked away from the screen
+type Verifier struct {
+	detector Detector
+	current  *session
+	mu       sync.Mutex
+	camera   sync.Mutex // Held by the session using the detector
+}
+
+// session holds the samples taken during one break
+type session struct {
+	stop    chan struct{}
+	looking int
+	total   int
+	mu      sync.Mutex
+}
+
+// NewVerifier creates a verifier using detector
+func NewVerifier(detector Detector) *Verifier {
+	return &Verifier{detector: detector}
+}
+
+// Begin starts sampling for a new break, discarding a previous break that
+// was never ended. It doesn't block while the camera starts.
+func (v *Verifier) Begin() {
+	s := &session{stop: make(chan struct{})}
+
+	v.mu.Lock()
+	previous := v.current
+	v.current = s
+	v.mu.Unlock()
+
+	if previous != nil {
+		close(previous.stop)
+	}
+	go v.sample(s)
+}
+
+// End stops sampling and reports whether the user looked away during the
+// break. If the camera couldn't be used, e.g. because access was denied,
+// the break counts as looked away.
+func (v *Verifier) End() bool {
+	v.mu.Lock()
+	s := v.current
+	v.current = nil
+	v.mu.Unlock()
+
+	if s == nil {
+		return true
+	}
+	close(s.stop)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return lookedAway(s.looking, s.total)
+}
+
+// sample checks the detector every sampleInterval until the session stops
+func (v *Verifier) sample(s *session) {
+	// Wait for the previous session to release the camera
+	v.camera.Lock()
+	defer v.camera.Unlock()
+
+	select {
+	case <-s.stop:
+		return
+	default:
+	}
+
+	if err := v.detector.Start(); err != nil {
+		if errors.Is(err, ErrPermissionDenied) {
+			log.Println("Camera access denied - not verifying breaks")
+		} else {
+			log.Printf("Warning: failed to start camera: %v", err)
+		}
+		return
+	}
+	defer v.detector.Stop()
+
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			looking, err := v.detector.LookingAtScreen()
+			if err != nil {
+				continue
+			}
+			s.mu.Lock()
+			s.total++
+			if looking {
+				s.looking++
+			}
+			s.mu.Unlock()
+
+		case <-s.stop:
+			return
+		}
+	}
+}
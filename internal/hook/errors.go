@@ -0,0 +1,11 @@
+package hook
+
+import "errors"
+
+var (
+	// ErrTimeout is returned when a command didn't finish within its timeout
+	ErrTimeout = errors.New("command timed out")
+
+	// ErrCommandFailed is returned when a command exits unsuccessfully
+	ErrCommandFailed = errors.New("command failed")
+)
@@ -0,0 +1,123 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// commandTimeout limits how long a single command may run
+	commandTimeout = 10 * time.Second
+	// waitDelay is how long a killed command may hold on to its output
+	// before it is abandoned, e.g. because a child process kept it open
+	waitDelay = 1 * time.Second
+	// maxOutput is how much of a failing command's output is logged
+	maxOutput = 200
+)
+
+// Hook names passed to commands in HOOK_EVENT
+const (
+	// EventBreakStart runs the command when a break starts
+	EventBreakStart = "break_start"
+	// EventBreakEnd runs the command when a break ends, however it ended
+	EventBreakEnd = "break_end"
+)
+
+// Event describes what a command is run for
+type Event struct {
+	Name    string // EventBreakStart or EventBreakEnd
+	Time    time.Time
+	BreakID int64  // 0 if the break wasn't recorded
+	Outcome string // How the break ended, empty for EventBreakStart
+}
+
+// Env returns the environment variables describing event to a command
+func (e Event) Env() []string {
+	return []string{
+		"HOOK_EVENT=" + e.Name,
+		"HOOK_TIME=" + e.Time.Format(time.RFC3339),
+		"HOOK_BREAK_ID=" + strconv.FormatInt(e.BreakID, 10),
+		"HOOK_OUTCOME=" + e.Outcome,
+	}
+}
+
+// Runner runs a shell command with extra environment variables until it
+// exits or ctx is done
+type Runner interface {
+	Run(ctx context.Context, command string, env []string) error
+}
+
+// shellRunner runs commands with /bin/sh
+type shellRunner struct{}
+
+// NewShellRunner creates a Runner passing commands to /bin/sh
+func NewShellRunner() Runner {
+	return shellRunner{}
+}
+
+// Run runs command with /bin/sh, adding env to the app's environment
+func (shellRunner) Run(ctx context.Context, command string, env []string) error {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.WaitDelay = waitDelay
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return ErrTimeout
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v: %s", ErrCommandFailed, err, truncate(strings.TrimSpace(string(output)), maxOutput))
+	}
+	return nil
+}
+
+// Executor runs the commands configured for break events in the
+// background, so slow, hanging or failing commands never hold up the caller
+type Executor struct {
+	runner  Runner
+	timeout time.Duration
+}
+
+// NewExecutor creates an executor running commands with runner
+func NewExecutor(runner Runner) *Executor {
+	return &Executor{runner: runner, timeout: commandTimeout}
+}
+
+// Fire runs command for event in a new goroutine and returns right away.
+// An empty command does nothing.
+func (e *Executor) Fire(command string, event Event) {
+	if command == "" {
+		return
+	}
+	go func() {
+		if err := e.Run(command, event); err != nil {
+			if errors.Is(err, ErrTimeout) {
+				log.Printf("Warning: %s command timed out after %s", event.Name, e.timeout)
+			} else {
+				log.Printf("Warning: %s command failed: %v", event.Name, err)
+			}
+		}
+	}()
+}
+
+// Run runs command for event and waits until it exits or times out
+func (e *Executor) Run(command string, event Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	return e.runner.Run(ctx, command, event.Env())
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "…"
+}
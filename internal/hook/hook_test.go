@@ -0,0 +1,75 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeRunner records the command it runs and returns err, or blocks until
+// the context is done if block is set
+type fakeRunner struct {
+	command string
+	env     []string
+	block   bool
+	err     error
+}
+
+func (r *fakeRunner) Run(ctx context.Context, command string, env []string) error {
+	r.command = command
+	r.env = env
+	if r.block {
+		<-ctx.Done()
+		return ErrTimeout
+	}
+	return r.err
+}
+
+func TestEventEnv(t *testing.T) {
+	event := Event{
+		Name:    EventBreakEnd,
+		Time:    time.Date(2025, time.June, 4, 9, 20, 0, 0, time.UTC),
+		BreakID: 42,
+		Outcome: "skipped",
+	}
+	want := []string{
+		"HOOK_EVENT=break_end",
+		"HOOK_TIME=2025-06-04T09:20:00Z",
+		"HOOK_BREAK_ID=42",
+		"HOOK_OUTCOME=skipped",
+	}
+	if got := event.Env(); !slices.Equal(got, want) {
+		t.Errorf("Env() = %v, want %v", got, want)
+	}
+}
+
+func TestExecutorRun(t *testing.T) {
+	runner := &fakeRunner{}
+	e := NewExecutor(runner)
+	event := Event{Name: EventBreakStart, BreakID: 1}
+	if err := e.Run("say hi", event); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if runner.command != "say hi" || !slices.Equal(runner.env, event.Env()) {
+		t.Errorf("ran %q with %v, want %q with %v", runner.command, runner.env, "say hi", event.Env())
+	}
+}
+
+func TestExecutorRunTimesOut(t *testing.T) {
+	e := NewExecutor(&fakeRunner{block: true})
+	e.timeout = 10 * time.Millisecond
+	if err := e.Run("sleep 60", Event{Name: EventBreakStart}); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Run() = %v, want %v", err, ErrTimeout)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("short", 10); got != "short" {
+		t.Errorf("truncate() = %q, want it unchanged", got)
+	}
+	if got := truncate("a long line", 6); got != "a long…" {
+		t.Errorf("truncate() = %q, want %q", got, "a long…")
+	}
+}
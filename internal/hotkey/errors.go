@@ -0,0 +1,15 @@
+package hotkey
+
+import "errors"
+
+var (
+	// ErrInvalidHotkey is returned when a hotkey string cannot be parsed
+	ErrInvalidHotkey = errors.New("invalid hotkey")
+
+	// ErrNoModifier is returned when a hotkey has no modifier key, which
+	// would swallow ordinary typing
+	ErrNoModifier = errors.New("hotkey needs at least one modifier")
+
+	// ErrRegistrationFailed is returned when the system refuses the hotkey
+	ErrRegistrationFailed = errors.New("failed to register hotkey")
+)
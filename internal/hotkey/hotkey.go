@@ -0,0 +1,96 @@
+package hotkey
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Modifier is a set of modifier keys
+type Modifier uint
+
+const (
+	// ModCommand is the ⌘ key
+	ModCommand Modifier = 1 << iota
+	// ModOption is the ⌥ key
+	ModOption
+	// ModControl is the ⌃ key
+	ModControl
+	// ModShift is the ⇧ key
+	ModShift
+)
+
+// modifierNames maps the accepted spellings to modifiers
+var modifierNames = map[string]Modifier{
+	"cmd":     ModCommand,
+	"command": ModCommand,
+	"opt":     ModOption,
+	"option":  ModOption,
+	"alt":     ModOption,
+	"ctrl":    ModControl,
+	"control": ModControl,
+	"shift":   ModShift,
+}
+
+// keySpace is the name used for the space bar
+const keySpace = "space"
+
+// Hotkey is a key pressed together with modifier keys
+type Hotkey struct {
+	Modifiers Modifier
+	Key       string // Lowercase character, or "space"
+}
+
+// String returns the canonical form of the hotkey, e.g. "cmd+opt+p"
+func (h Hotkey) String() string {
+	var parts []string
+	for _, mod := range []struct {
+		flag Modifier
+		name string
+	}{
+		{ModCommand, "cmd"},
+		{ModOption, "opt"},
+		{ModControl, "ctrl"},
+		{ModShift, "shift"},
+	} {
+		if h.Modifiers&mod.flag != 0 {
+			parts = append(parts, mod.name)
+		}
+	}
+	return strings.Join(append(parts, h.Key), "+")
+}
+
+// Parse parses a hotkey string such as "cmd+opt+p". Parts are separated by
+// "+" and case-insensitive; the last part is the key, either a single
+// character or "space".
+func Parse(s string) (Hotkey, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(s)), "+")
+
+	var h Hotkey
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return Hotkey{}, fmt.Errorf("%w: %q", ErrInvalidHotkey, s)
+		}
+
+		if i < len(parts)-1 {
+			mod, ok := modifierNames[part]
+			if !ok || h.Modifiers&mod != 0 {
+				return Hotkey{}, fmt.Errorf("%w: %q", ErrInvalidHotkey, s)
+			}
+			h.Modifiers |= mod
+			continue
+		}
+
+		if part != keySpace && utf8.RuneCountInString(part) != 1 {
+			return Hotkey{}, fmt.Errorf("%w: %q", ErrInvalidHotkey, s)
+		}
+		h.Key = part
+	}
+
+	if h.Modifiers == 0 {
+		return Hotkey{}, fmt.Errorf("%w: %q", ErrNoModifier, s)
+	}
+
+	return h, nil
+}
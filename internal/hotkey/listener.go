@@ -0,0 +1,98 @@
+package hotkey
+
+import (
+	"sync"
+)
+
+// Backend delivers key presses from the system
+type Backend interface {
+	// Register starts reporting every key press to handler
+	Register(handler func(Hotkey)) error
+	// Unregister stops reporting key presses
+	Unregister()
+}
+
+// Listener calls a callback whenever its bound hotkey is pressed
+type Listener struct {
+	backend    Backend
+	hotkey     Hotkey
+	bound      bool
+	registered bool
+	onPressed  func()
+	mu         sync.Mutex
+}
+
+// NewListener creates a new hotkey listener on top of backend
+func NewListener(backend Backend) *Listener {
+	return &Listener{
+		backend: backend,
+	}
+}
+
+// SetOnPressed sets the callback for when the bound hotkey is pressed
+func (l *Listener) SetOnPressed(callback func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onPressed = callback
+}
+
+// Bind parses spec and listens for it, replacing any previous hotkey.
+// An empty spec unbinds the hotkey. On error the listener is left unbound.
+func (l *Listener) Bind(spec string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.bound = false
+
+	if spec == "" {
+		l.unregisterLocked()
+		return nil
+	}
+
+	h, err := Parse(spec)
+	if err != nil {
+		l.unregisterLocked()
+		return err
+	}
+
+	if !l.registered {
+		if err := l.backend.Register(l.handle); err != nil {
+			return err
+		}
+		l.registered = true
+	}
+
+	l.hotkey = h
+	l.bound = true
+	return nil
+}
+
+// Close stops listening for the hotkey
+func (l *Listener) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.bound = false
+	l.unregisterLocked()
+}
+
+// unregisterLocked stops the backend if it is running.
+// Must be called with l.mu held.
+func (l *Listener) unregisterLocked() {
+	if l.registered {
+		l.backend.Unregister()
+		l.registered = false
+	}
+}
+
+// handle is called by the backend for every key press
+func (l *Listener) handle(pressed Hotkey) {
+	l.mu.Lock()
+	matches := l.bound && pressed == l.hotkey
+	callback := l.onPressed
+	l.mu.Unlock()
+
+	if matches && callback != nil {
+		callback()
+	}
+}
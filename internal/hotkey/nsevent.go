@@ -0,0 +1,82 @@
+package hotkey
+
+import (
+	"strings"
+
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/objc"
+)
+
+// eventMonitorBackend receives key presses through NSEvent monitors. The
+// global monitor needs the accessibility permission and only sees events
+// sent to other apps, so a local monitor covers our own windows.
+type eventMonitorBackend struct {
+	globalMonitor objc.Object
+	localMonitor  objc.Object
+}
+
+// NewEventMonitorBackend creates a backend based on NSEvent key monitors
+func NewEventMonitorBackend() Backend {
+	return &eventMonitorBackend{}
+}
+
+// Register installs the global and local key down monitors
+func (b *eventMonitorBackend) Register(handler func(Hotkey)) error {
+	b.globalMonitor = appkit.Event_AddGlobalMonitorForEventsMatchingMaskHandler(
+		appkit.EventMaskKeyDown,
+		func(event appkit.Event) {
+			handler(hotkeyFromEvent(event))
+		},
+	)
+	if b.globalMonitor.IsNil() {
+		return ErrRegistrationFailed
+	}
+
+	b.localMonitor = appkit.Event_AddLocalMonitorForEventsMatchingMaskHandler(
+		appkit.EventMaskKeyDown,
+		func(event appkit.Event) appkit.Event {
+			handler(hotkeyFromEvent(event))
+			return event
+		},
+	)
+
+	return nil
+}
+
+// Unregister removes the key down monitors
+func (b *eventMonitorBackend) Unregister() {
+	if !b.globalMonitor.IsNil() {
+		appkit.Event_RemoveMonitor(b.globalMonitor)
+		b.globalMonitor = objc.Object{}
+	}
+	if !b.localMonitor.IsNil() {
+		appkit.Event_RemoveMonitor(b.localMonitor)
+		b.localMonitor = objc.Object{}
+	}
+}
+
+// hotkeyFromEvent converts a key down event into a Hotkey
+func hotkeyFromEvent(event appkit.Event) Hotkey {
+	flags := objc.Call[appkit.EventModifierFlags](event, objc.Sel("modifierFlags"))
+
+	var h Hotkey
+	if flags&appkit.EventModifierFlagCommand != 0 {
+		h.Modifiers |= ModCommand
+	}
+	if flags&appkit.EventModifierFlagOption != 0 {
+		h.Modifiers |= ModOption
+	}
+	if flags&appkit.EventModifierFlagControl != 0 {
+		h.Modifiers |= ModControl
+	}
+	if flags&appkit.EventModifierFlagShift != 0 {
+		h.Modifiers |= ModShift
+	}
+
+	h.Key = strings.ToLower(event.CharactersIgnoringModifiers())
+	if h.Key == " " {
+		h.Key = keySpace
+	}
+
+	return h
+}
@@ -0,0 +1,8 @@
+package instance
+
+import "errors"
+
+var (
+	// ErrAlreadyRunning is returned when another instance holds the lock
+	ErrAlreadyRunning = errors.New("another instance is already running")
+)
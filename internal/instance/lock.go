@@ -0,0 +1,100 @@
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const (
+	appName      = "2020Rule"
+	lockFileName = "2020rule.lock"
+
+	// dataDirEnv overrides the data directory, e.g. for portable installs
+	dataDirEnv = "TWENTY_RULE_DATA_DIR"
+)
+
+// Lock is a single-instance lock backed by an flock on a file holding the
+// owner's PID. The kernel drops the flock when its owner exits, so a lock
+// file left behind by a crashed instance is simply taken over.
+type Lock struct {
+	file *os.File
+}
+
+// Acquire takes the lock at path, creating its directory if needed. If
+// another process holds the lock ErrAlreadyRunning is returned.
+func Acquire(path string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, fmt.Errorf("failed to lock file: %w", err)
+		}
+		if pid, err := readPID(path); err == nil {
+			return nil, fmt.Errorf("%w (pid %d)", ErrAlreadyRunning, pid)
+		}
+		return nil, ErrAlreadyRunning
+	}
+
+	// The PID is only informational, the flock is what counts
+	if err := writePID(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return &Lock{file: file}, nil
+}
+
+// Release drops the lock so another instance can start. The file stays, so
+// a process that opened it just before can't end up locking a removed file
+// while a third one creates a new one.
+func (l *Lock) Release() error {
+	return l.file.Close()
+}
+
+// DefaultLockPath returns the path of the lock file in the app's data directory
+// On macOS: ~/Library/Application Support/2020Rule/2020rule.lock, unless
+// TWENTY_RULE_DATA_DIR is set
+func DefaultLockPath() (string, error) {
+	if dir := os.Getenv(dataDirEnv); dir != "" {
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, lockFileName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "Application Support", appName, lockFileName), nil
+}
+
+// writePID replaces the contents of the lock file with the owner's PID
+func writePID(file *os.File) error {
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	return err
+}
+
+// readPID reads the owner's PID from a lock file
+func readPID(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,91 @@
+package instance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", lockFileName)
+
+	lock, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if pid, err := readPID(path); err != nil || pid != os.Getpid() {
+		t.Errorf("lock file holds pid %d (%v), want %d", pid, err, os.Getpid())
+	}
+
+	if _, err := Acquire(path); !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("second Acquire error = %v, want %v", err, ErrAlreadyRunning)
+	}
+
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	lock, err = Acquire(path)
+	if err != nil {
+		t.Fatalf("Acquire after Release: %v", err)
+	}
+	lock.Release()
+}
+
+func TestAcquireStaleLock(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{"dead owner", "999999999\n"},
+		{"garbage", "not a pid"},
+		{"empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), lockFileName)
+			if err := os.WriteFile(path, []byte(tt.contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			lock, err := Acquire(path)
+			if err != nil {
+				t.Fatalf("Acquire over a stale lock: %v", err)
+			}
+			defer lock.Release()
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := strconv.Itoa(os.Getpid()) + "\n"; string(data) != want {
+				t.Errorf("lock file = %q, want %q", data, want)
+			}
+		})
+	}
+}
+
+func TestDefaultLockPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(dataDirEnv, dir)
+	path, err := DefaultLockPath()
+	if err != nil {
+		t.Fatalf("DefaultLockPath: %v", err)
+	}
+	if want := filepath.Join(dir, lockFileName); path != want {
+		t.Errorf("DefaultLockPath() = %q, want %q", path, want)
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(dataDirEnv, "")
+	path, err = DefaultLockPath()
+	if err != nil {
+		t.Fatalf("DefaultLockPath: %v", err)
+	}
+	if want := filepath.Join(home, "Library", "Application Support", appName, lockFileName); path != want {
+		t.Errorf("DefaultLockPath() without override = %q, want %q", path, want)
+	}
+}
@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/caseymrm/menuet"
+)
+
+// window is the period the notification cap applies to
+const window = time.Hour
+
+// Priority decides which notifications are dropped first once the cap is near
+type Priority int
+
+const (
+	// PriorityLow is for nice-to-know notifications such as goals and
+	// reviews. They may only use half of the hourly cap.
+	PriorityLow Priority = iota
+	// PriorityNormal is for notifications about the app's state. They may
+	// use the whole hourly cap.
+	PriorityNormal
+	// PriorityHigh is for notifications that are a break or ask about one.
+	// They are never dropped but count towards the cap.
+	PriorityHigh
+)
+
+// Sender delivers notifications; *menuet.Application implements it
+type Sender interface {
+	Notification(notification menuet.Notification)
+}
+
+// Limiter caps how many notifications reach the user per hour. Excess
+// notifications are dropped, lowest priority first.
+type Limiter struct {
+	sender     Sender
+	maxPerHour int         // 0 = unlimited
+	sent       []time.Time // Delivery times within the last window, oldest first
+	now        func() time.Time
+	mu         sync.Mutex
+}
+
+// NewLimiter creates a limiter delivering at most maxPerHour notifications
+// through sender, 0 meaning no cap
+func NewLimiter(sender Sender, maxPerHour int) *Limiter {
+	return &Limiter{sender: sender, maxPerHour: maxPerHour, now: time.Now}
+}
+
+// SetMaxPerHour changes the hourly cap, 0 meaning no cap
+func (l *Limiter) SetMaxPerHour(maxPerHour int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxPerHour = maxPerHour
+}
+
+// Notify delivers notification unless the hourly cap leaves no room for its
+// priority, and reports whether it was delivered
+func (l *Limiter) Notify(notification menuet.Notification, priority Priority) bool {
+	l.mu.Lock()
+	now := l.now()
+	l.prune(now)
+	if !allowed(len(l.sent), l.maxPerHour, priority) {
+		l.mu.Unlock()
+		log.Printf("Notification cap reached - dropping %q", notification.Title)
+		return false
+	}
+	l.sent = append(l.sent, now)
+	l.mu.Unlock()
+
+	l.sender.Notification(notification)
+	return true
+}
+
+// prune forgets deliveries older than window. Must be called with l.mu held.
+func (l *Limiter) prune(now time.Time) {
+	keep := 0
+	for keep < len(l.sent) && now.Sub(l.sent[keep]) >= window {
+		keep++
+	}
+	l.sent = l.sent[keep:]
+}
+
+// allowed reports whether a notification of priority fits after sent
+// deliveries in the last window under a cap of maxPerHour
+func allowed(sent, maxPerHour int, priority Priority) bool {
+	if maxPerHour <= 0 {
+		return true
+	}
+	switch priority {
+	case PriorityHigh:
+		return true
+	case PriorityNormal:
+		return sent < maxPerHour
+	default:
+		return sent < max(maxPerHour/2, 1)
+	}
+}
@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caseymrm/menuet"
+)
+
+// fakeSender records the titles of delivered notifications
+type fakeSender struct {
+	titles []string
+}
+
+func (f *fakeSender) Notification(notification menuet.Notification) {
+	f.titles = append(f.titles, notification.Title)
+}
+
+// newTestLimiter creates a limiter with a cap of maxPerHour whose clock is
+// moved by advancing the returned time
+func newTestLimiter(maxPerHour int) (*Limiter, *fakeSender, *time.Time) {
+	sender := &fakeSender{}
+	now := time.Date(2025, time.June, 4, 9, 0, 0, 0, time.Local)
+	l := NewLimiter(sender, maxPerHour)
+	l.now = func() time.Time { return now }
+	return l, sender, &now
+}
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		name       string
+		sent       int
+		maxPerHour int
+		priority   Priority
+		want       bool
+	}{
+		{"no cap", 100, 0, PriorityLow, true},
+		{"normal below the cap", 3, 4, PriorityNormal, true},
+		{"normal at the cap", 4, 4, PriorityNormal, false},
+		{"low below half the cap", 1, 4, PriorityLow, true},
+		{"low at half the cap", 2, 4, PriorityLow, false},
+		{"low with a cap of one", 0, 1, PriorityLow, true},
+		{"high past the cap", 10, 4, PriorityHigh, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allowed(tt.sent, tt.maxPerHour, tt.priority); got != tt.want {
+				t.Errorf("allowed(%d, %d, %d) = %t, want %t", tt.sent, tt.maxPerHour, tt.priority, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimiterCap(t *testing.T) {
+	l, sender, _ := newTestLimiter(3)
+
+	for range 3 {
+		if !l.Notify(menuet.Notification{Title: "normal"}, PriorityNormal) {
+			t.Fatal("notification below the cap was dropped")
+		}
+	}
+	if l.Notify(menuet.Notification{Title: "over"}, PriorityNormal) {
+		t.Error("notification over the cap was delivered")
+	}
+	if !l.Notify(menuet.Notification{Title: "break"}, PriorityHigh) {
+		t.Error("break notification was dropped")
+	}
+
+	want := []string{"normal", "normal", "normal", "break"}
+	if len(sender.titles) != len(want) {
+		t.Fatalf("delivered %v, want %v", sender.titles, want)
+	}
+	for i := range want {
+		if sender.titles[i] != want[i] {
+			t.Errorf("delivered %v, want %v", sender.titles, want)
+			break
+		}
+	}
+}
+
+func TestLimiterWindowExpiry(t *testing.T) {
+	l, _, now := newTestLimiter(2)
+
+	l.Notify(menuet.Notification{Title: "first"}, PriorityNormal)
+	*now = now.Add(30 * time.Minute)
+	l.Notify(menuet.Notification{Title: "second"}, PriorityNormal)
+	if l.Notify(menuet.Notification{Title: "third"}, PriorityNormal) {
+		t.Fatal("notification over the cap was delivered")
+	}
+
+	// An hour after the first delivery it no longer counts
+	*now = now.Add(30*time.Minute - time.Second)
+	if l.Notify(menuet.Notification{Title: "early"}, PriorityNormal) {
+		t.Error("delivered before the first one left the window")
+	}
+	*now = now.Add(time.Second)
+	if !l.Notify(menuet.Notification{Title: "later"}, PriorityNormal) {
+		t.Error("dropped after the first one left the window")
+	}
+}
+
+func TestLimiterDropsLowPriorityFirst(t *testing.T) {
+	l, _, _ := newTestLimiter(4)
+
+	l.Notify(menuet.Notification{Title: "state"}, PriorityNormal)
+	l.Notify(menuet.Notification{Title: "goal"}, PriorityLow)
+	if l.Notify(menuet.Notification{Title: "review"}, PriorityLow) {
+		t.Error("low priority notification past half the cap was delivered")
+	}
+	if !l.Notify(menuet.Notification{Title: "paused"}, PriorityNormal) {
+		t.Error("normal notification below the cap was dropped")
+	}
+
+	// Without a cap nothing is dropped
+	l.SetMaxPerHour(0)
+	if !l.Notify(menuet.Notification{Title: "review"}, PriorityLow) {
+		t.Error("notification dropped without a cap")
+	}
+}
@@ -0,0 +1,120 @@
+package overlay
+
+import (
+	"fmt"
+
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/macos/foundation"
+	"github.com/progrium/darwinkit/objc"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// Banner dimensions in points
+const (
+	bannerWidth        = 340.0
+	bannerHeight       = 110.0
+	bannerMargin       = 20.0
+	bannerCornerRadius = 14.0
+)
+
+// bannerFrame returns the frame of the banner overlay in the given corner of
+// a screen with the given visible frame. Unknown corners fall back to the
+// top right. The banner shrinks to fit screens smaller than it.
+func bannerFrame(screen foundation.Rect, corner string) foundation.Rect {
+	width := bannerWidth
+	if limit := screen.Size.Width - 2*bannerMargin; width > limit {
+		width = limit
+	}
+	height := bannerHeight
+	if limit := screen.Size.Height - 2*bannerMargin; height > limit {
+		height = limit
+	}
+
+	left := screen.Origin.X + bannerMargin
+	right := screen.Origin.X + screen.Size.Width - width - bannerMargin
+	bottom := screen.Origin.Y + bannerMargin
+	top := screen.Origin.Y + screen.Size.Height - height - bannerMargin
+
+	origin := foundation.Point{X: right, Y: top}
+	switch corner {
+	case config.BannerCornerTopLeft:
+		origin = foundation.Point{X: left, Y: top}
+	case config.BannerCornerBottomLeft:
+		origin = foundation.Point{X: left, Y: bottom}
+	case config.BannerCornerBottomRight:
+		origin = foundation.Point{X: right, Y: bottom}
+	}
+
+	return foundation.Rect{
+		Origin: origin,
+		Size:   foundation.Size{Width: width, Height: height},
+	}
+}
+
+// createBannerWindow creates the banner overlay on a screen. It shares the
+// message and countdown labels with the fullscreen overlay, so the countdown
+// updates both alike.
+func (w *Window) createBannerWindow(screen appkit.Screen) appkit.Window {
+	frame := bannerFrame(screen.VisibleFrame(), w.config.BannerCorner)
+
+	win := appkit.NewWindowWithContentRectStyleMaskBackingDefer(
+		frame,
+		0, // Borderless
+		appkit.BackingStoreBuffered,
+		false,
+	)
+	objc.Retain(&win)
+
+	win.SetOpaque(false)
+	win.SetHasShadow(true)
+	win.SetBackgroundColor(appkit.Color_ClearColor())
+	win.SetIgnoresMouseEvents(true)
+	win.SetLevel(windowLevel(w.config.OverlayWindowLevel))
+	win.SetCollectionBehavior(
+		appkit.WindowCollectionBehaviorCanJoinAllSpaces |
+			appkit.WindowCollectionBehaviorStationary |
+			appkit.WindowCollectionBehaviorFullScreenAuxiliary,
+	)
+
+	// Rounded background
+	bounds := foundation.Rect{Size: frame.Size}
+	view := appkit.NewViewWithFrame(bounds)
+	view.SetWantsLayer(true)
+	view.Layer().SetCornerRadius(bannerCornerRadius)
+	view.Layer().SetBackgroundColor(w.palette().Background.color().CGColor())
+
+	messageLabel := bannerLabel(w.openingMessage(), 18, appkit.FontWeightBold)
+	messageLabel.SetFrame(foundation.Rect{
+		Origin: foundation.Point{X: 12, Y: frame.Size.Height - 40},
+		Size:   foundation.Size{Width: frame.Size.Width - 24, Height: 26},
+	})
+
+	countdownLabel := bannerLabel(fmt.Sprintf("%d", w.remainingSecs), 44, appkit.FontWeightLight)
+	countdownLabel.SetFrame(foundation.Rect{
+		Origin: foundation.Point{X: 12, Y: 10},
+		Size:   foundation.Size{Width: frame.Size.Width - 24, Height: frame.Size.Height - 54},
+	})
+
+	view.AddSubview(messageLabel)
+	view.AddSubview(countdownLabel)
+	win.SetContentView(view)
+	win.OrderFrontRegardless()
+
+	w.labels = append(w.labels, countdownLabel)
+	w.messageLabels = append(w.messageLabels, messageLabel)
+
+	return win
+}
+
+// bannerLabel creates a centered, non-editable white label
+func bannerLabel(text string, size float64, weight appkit.FontWeight) appkit.TextField {
+	label := appkit.NewLabel(text)
+	label.SetAlignment(appkit.TextAlignmentCenter)
+	label.SetTextColor(appkit.Color_WhiteColor())
+	label.SetFont(appkit.Font_SystemFontOfSizeWeight(size, weight))
+	label.SetBackgroundColor(appkit.Color_ClearColor())
+	label.SetBezeled(false)
+	label.SetEditable(false)
+	return label
+}
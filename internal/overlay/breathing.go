@@ -0,0 +1,63 @@
+package overlay
+
+import (
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// breathingPhase is one step of a box-breathing cycle
+type breathingPhase int
+
+const (
+	phaseInhale breathingPhase = iota
+	phaseHoldIn
+	phaseExhale
+	phaseHoldOut
+)
+
+// breathingMinScale is the circle scale at the end of an exhale
+const breathingMinScale = 0.4
+
+// Cue returns the text shown to the user during the phase
+func (p breathingPhase) Cue() string {
+	switch p {
+	case phaseInhale:
+		return "Einatmen"
+	case phaseExhale:
+		return "Ausatmen"
+	default:
+		return "Halten"
+	}
+}
+
+// targetScale returns the circle scale the phase animates towards
+func (p breathingPhase) targetScale() float64 {
+	switch p {
+	case phaseInhale, phaseHoldIn:
+		return 1.0
+	default:
+		return breathingMinScale
+	}
+}
+
+// breathingPhaseAt returns the phase active after elapsed time into the break
+// and how much of that phase remains. The cycle repeats for the whole break.
+func breathingPhaseAt(pacing config.BreathingPacing, elapsed time.Duration) (breathingPhase, time.Duration) {
+	cycle := pacing.Cycle()
+	if cycle <= 0 || elapsed < 0 {
+		return phaseInhale, 0
+	}
+
+	offset := elapsed % cycle
+	phases := []time.Duration{pacing.Inhale, pacing.HoldIn, pacing.Exhale, pacing.HoldOut}
+	for i, length := range phases {
+		if offset < length {
+			return breathingPhase(i), length - offset
+		}
+		offset -= length
+	}
+
+	// Unreachable as long as the phases add up to the cycle
+	return phaseInhale, pacing.Inhale
+}
@@ -0,0 +1,68 @@
+package overlay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+func TestBreathingPhaseAt(t *testing.T) {
+	pacing := config.BreathingPacing{
+		Inhale:  4 * time.Second,
+		HoldIn:  2 * time.Second,
+		Exhale:  6 * time.Second,
+		HoldOut: 2 * time.Second,
+	}
+
+	tests := []struct {
+		name          string
+		elapsed       time.Duration
+		wantPhase     breathingPhase
+		wantRemaining time.Duration
+	}{
+		{"start", 0, phaseInhale, 4 * time.Second},
+		{"inhaling", time.Second, phaseInhale, 3 * time.Second},
+		{"holding in", 4 * time.Second, phaseHoldIn, 2 * time.Second},
+		{"exhaling", 7 * time.Second, phaseExhale, 5 * time.Second},
+		{"holding out", 13 * time.Second, phaseHoldOut, time.Second},
+		{"next cycle", 14 * time.Second, phaseInhale, 4 * time.Second},
+		{"later cycle", 3*14*time.Second + 5*time.Second, phaseHoldIn, time.Second},
+		{"before the break", -time.Second, phaseInhale, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase, remaining := breathingPhaseAt(pacing, tt.elapsed)
+			if phase != tt.wantPhase || remaining != tt.wantRemaining {
+				t.Errorf("breathingPhaseAt(%s) = %d with %s left, want %d with %s left",
+					tt.elapsed, phase, remaining, tt.wantPhase, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestBreathingPhaseAtWithoutCycle(t *testing.T) {
+	phase, remaining := breathingPhaseAt(config.BreathingPacing{}, 5*time.Second)
+	if phase != phaseInhale || remaining != 0 {
+		t.Errorf("breathingPhaseAt() = %d with %s left, want inhale with nothing left", phase, remaining)
+	}
+}
+
+func TestBreathingPhaseScale(t *testing.T) {
+	tests := []struct {
+		phase breathingPhase
+		want  float64
+	}{
+		{phaseInhale, 1.0},
+		{phaseHoldIn, 1.0},
+		{phaseExhale, breathingMinScale},
+		{phaseHoldOut, breathingMinScale},
+	}
+
+	for _, tt := range tests {
+		if got := tt.phase.targetScale(); got != tt.want {
+			t.Errorf("phase %d scales to %.1f, want %.1f", tt.phase, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package overlay
+
+import (
+	"github.com/progrium/darwinkit/helper/action"
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/macos/foundation"
+	"github.com/progrium/darwinkit/objc"
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// rectContains returns whether p lies within rect
+func rectContains(rect foundation.Rect, p foundation.Point) bool {
+	return p.X >= rect.Origin.X && p.X < rect.Origin.X+rect.Size.Width &&
+		p.Y >= rect.Origin.Y && p.Y < rect.Origin.Y+rect.Size.Height
+}
+
+// installClickAction makes clicks on view outside the buttons trigger the
+// configured OverlayClickAction
+func (w *Window) installClickAction(view appkit.View, layout screenLayout) {
+	if w.config.OverlayClickAction == "" || w.config.OverlayClickAction == config.OverlayClickNone {
+		return
+	}
+
+	buttons := []foundation.Rect{layout.AddTime}
+	if w.canEndEarly() {
+		buttons = append(buttons, layout.Dismiss)
+	}
+
+	recognizer := appkit.NewClickGestureRecognizer()
+	recognizer.SetDelaysPrimaryMouseButtonEvents(false)
+	action.Set(recognizer, func(sender objc.Object) {
+		location := recognizer.LocationInView(view)
+		for _, button := range buttons {
+			if rectContains(button, location) {
+				return
+			}
+		}
+		w.handleClick()
+	})
+	view.AddGestureRecognizer(recognizer)
+}
+
+// handleClick runs the configured OverlayClickAction
+func (w *Window) handleClick() {
+	w.mu.Lock()
+	clickAction := clickActionFor(w.config, w.variant)
+	w.mu.Unlock()
+
+	switch clickAction {
+	case config.OverlayClickComplete:
+		w.dismiss()
+	case config.OverlayClickPostpone:
+		w.postpone()
+	}
+}
+
+// clickActionFor returns what a click on the overlay of a break of variant
+// does with cfg. Like the button to end the break early, clicks only end or
+// postpone breaks that may end early, so strict mode and lockouts ignore
+// them.
+func clickActionFor(cfg *config.Config, variant breakVariant) string {
+	if !cfg.AllowEarlyDismiss || variant == variantLockout {
+		return config.OverlayClickNone
+	}
+	switch cfg.OverlayClickAction {
+	case config.OverlayClickComplete, config.OverlayClickPostpone:
+		return cfg.OverlayClickAction
+	default:
+		return config.OverlayClickNone
+	}
+}
@@ -0,0 +1,39 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+func TestClickActionFor(t *testing.T) {
+	tests := []struct {
+		name         string
+		clickAction  string
+		earlyDismiss bool
+		variant      breakVariant
+		want         string
+	}{
+		{"complete", config.OverlayClickComplete, true, variantRegular, config.OverlayClickComplete},
+		{"postpone", config.OverlayClickPostpone, true, variantRecovery, config.OverlayClickPostpone},
+		{"none", config.OverlayClickNone, true, variantRegular, config.OverlayClickNone},
+		{"unset", "", true, variantRegular, config.OverlayClickNone},
+		{"complete in strict mode", config.OverlayClickComplete, false, variantRegular, config.OverlayClickNone},
+		{"postpone in strict mode", config.OverlayClickPostpone, false, variantRegular, config.OverlayClickNone},
+		{"complete during a lockout", config.OverlayClickComplete, true, variantLockout, config.OverlayClickNone},
+		{"postpone during a lockout", config.OverlayClickPostpone, true, variantLockout, config.OverlayClickNone},
+		{"complete after a session limit", config.OverlayClickComplete, true, variantSessionLimit, config.OverlayClickComplete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.OverlayClickAction = tt.clickAction
+			cfg.AllowEarlyDismiss = tt.earlyDismiss
+
+			if got := clickActionFor(cfg, tt.variant); got != tt.want {
+				t.Errorf("clickActionFor(%q) = %q, want %q", tt.clickAction, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package overlay
+
+import (
+	"math"
+	"time"
+)
+
+// extendCountdown adds d to a running countdown of remaining out of total
+// seconds. A countdown that already reached zero is about to complete and
+// can't be extended, so ok is false. Both values grow by the same amount so
+// the elapsed time stays consistent.
+func extendCountdown(remaining, total int, d time.Duration) (newRemaining, newTotal int, ok bool) {
+	secs := int(d.Seconds())
+	if remaining <= 0 || secs <= 0 {
+		return remaining, total, false
+	}
+	return remaining + secs, total + secs, true
+}
+
+// remainingSecsAt returns the whole seconds left until endsAt at now,
+// rounded up so the display only shows 0 once the end time has passed.
+// Deriving the display from a fixed end time keeps tick and dispatch delays
+// from adding up over long breaks.
+func remainingSecsAt(endsAt, now time.Time) int {
+	left := endsAt.Sub(now)
+	if left <= 0 {
+		return 0
+	}
+	return int(math.Ceil(left.Seconds()))
+}
+
+// maxOverlayDuration is the longest break the overlay shows, so a
+// misconfigured break duration can't lock the user out
+const maxOverlayDuration = 5 * time.Minute
+
+// clampOverlayDuration limits d to maxOverlayDuration and reports whether
+// it had to be shortened
+func clampOverlayDuration(d time.Duration) (time.Duration, bool) {
+	if d > maxOverlayDuration {
+		return maxOverlayDuration, true
+	}
+	return d, false
+}
+
+// formatNextBreak formats when the following break is due in local time
+func formatNextBreak(t time.Time) string {
+	return "Nächste Pause: " + t.Local().Format("15:04")
+}
@@ -0,0 +1,86 @@
+package overlay
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRemainingSecsAt(t *testing.T) {
+	endsAt := time.Date(2025, time.June, 4, 9, 20, 20, 0, time.Local)
+
+	tests := []struct {
+		name string
+		left time.Duration
+		want int
+	}{
+		{"full break", 20 * time.Second, 20},
+		{"just started", 20*time.Second - time.Millisecond, 20},
+		{"between ticks", 10*time.Second + 500*time.Millisecond, 11},
+		{"last fraction", time.Millisecond, 1},
+		{"ended", 0, 0},
+		{"overdue", -5 * time.Second, 0},
+		{"long break", 5 * time.Minute, 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remainingSecsAt(endsAt, endsAt.Add(-tt.left)); got != tt.want {
+				t.Errorf("remainingSecsAt() with %s left = %d, want %d", tt.left, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClampOverlayDuration(t *testing.T) {
+	tests := []struct {
+		name        string
+		d           time.Duration
+		want        time.Duration
+		wantClamped bool
+	}{
+		{"regular break", 20 * time.Second, 20 * time.Second, false},
+		{"at the maximum", maxOverlayDuration, maxOverlayDuration, false},
+		{"just over the maximum", maxOverlayDuration + time.Second, maxOverlayDuration, true},
+		{"misconfigured", 2 * time.Hour, maxOverlayDuration, true},
+		{"zero", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, clamped := clampOverlayDuration(tt.d)
+			if got != tt.want || clamped != tt.wantClamped {
+				t.Errorf("clampOverlayDuration(%s) = %s, %t, want %s, %t", tt.d, got, clamped, tt.want, tt.wantClamped)
+			}
+		})
+	}
+}
+
+func TestExtendCountdown(t *testing.T) {
+	tests := []struct {
+		name          string
+		remaining     int
+		total         int
+		d             time.Duration
+		wantRemaining int
+		wantTotal     int
+		wantOK        bool
+	}{
+		{"running countdown", 12, 20, 30 * time.Second, 42, 50, true},
+		{"last second", 1, 20, time.Minute, 61, 80, true},
+		{"fractions dropped", 12, 20, 1500 * time.Millisecond, 13, 21, true},
+		{"countdown over", 0, 20, 30 * time.Second, 0, 20, false},
+		{"nothing added", 12, 20, 0, 12, 20, false},
+		{"under a second", 12, 20, 500 * time.Millisecond, 12, 20, false},
+		{"negative", 12, 20, -30 * time.Second, 12, 20, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining, total, ok := extendCountdown(tt.remaining, tt.total, tt.d)
+			if remaining != tt.wantRemaining || total != tt.wantTotal || ok != tt.wantOK {
+				t.Errorf("extendCountdown(%d, %d, %s) = %d, %d, %t, want %d, %d, %t",
+					tt.remaining, tt.total, tt.d, remaining, total, ok, tt.wantRemaining, tt.wantTotal, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package overlay
+
+import (
+	"time"
+
+	"github.com/progrium/darwinkit/dispatch"
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/objc"
+)
+
+const (
+	// maxDimOpacity is the opacity the warm-up dimming reaches when the break starts
+	maxDimOpacity = 0.4
+	// dimStepInterval is how often the dimming opacity is updated
+	dimStepInterval = 100 * time.Millisecond
+	// dimGracePeriod is how long the dimming waits for a late break before giving up
+	dimGracePeriod = 2 * time.Second
+)
+
+// dimOpacityAt returns the dimming opacity after elapsed time of a ramp of
+// the given duration. It rises linearly from 0 to maxDimOpacity.
+func dimOpacityAt(elapsed, duration time.Duration) float64 {
+	if duration <= 0 || elapsed >= duration {
+		return maxDimOpacity
+	}
+	if elapsed <= 0 {
+		return 0
+	}
+	return maxDimOpacity * float64(elapsed) / float64(duration)
+}
+
+// StartDim gradually dims all screens over duration, which should end when
+// the break starts. Show replaces the dimming with the full overlay.
+func (w *Window) StartDim(duration time.Duration) {
+	w.mu.Lock()
+	if w.isShowing || w.dimDone != nil || duration <= 0 {
+		w.mu.Unlock()
+		return
+	}
+	done := make(chan struct{})
+	w.dimDone = done
+	w.mu.Unlock()
+
+	dispatch.MainQueue().DispatchAsync(func() {
+		w.createDimWindows()
+		go w.rampDim(duration, done)
+	})
+}
+
+// StopDim removes the dimming, e.g. when the timer is paused before the break
+func (w *Window) StopDim() {
+	w.mu.Lock()
+	stopped := w.stopDimLocked()
+	w.mu.Unlock()
+
+	if stopped {
+		dispatch.MainQueue().DispatchAsync(func() {
+			w.closeDimWindows()
+		})
+	}
+}
+
+// stopDimLocked signals the dimming ramp to stop and reports whether it was
+// running. Must be called with w.mu held.
+func (w *Window) stopDimLocked() bool {
+	if w.dimDone == nil {
+		return false
+	}
+	close(w.dimDone)
+	w.dimDone = nil
+	return true
+}
+
+// rampDim raises the dim windows' opacity until done is closed. If no break
+// arrives shortly after the ramp ends, the dimming removes itself.
+func (w *Window) rampDim(duration time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(dimStepInterval)
+	defer ticker.Stop()
+	start := time.Now()
+
+	for {
+		select {
+		case <-ticker.C:
+			elapsed := time.Since(start)
+			if elapsed > duration+dimGracePeriod {
+				w.StopDim()
+				return
+			}
+			opacity := dimOpacityAt(elapsed, duration)
+
+			dispatch.MainQueue().DispatchAsync(func() {
+				for _, win := range w.dimWindows {
+					win.SetAlphaValue(opacity)
+				}
+			})
+
+		case <-done:
+			return
+		}
+	}
+}
+
+// createDimWindows creates a transparent, click-through window on each screen to cover
+func (w *Window) createDimWindows() {
+	screens := w.overlayScreens()
+
+	w.dimWindows = make([]appkit.Window, 0, len(screens))
+
+	for _, screen := range screens {
+		win := appkit.NewWindowWithContentRectStyleMaskBackingDefer(
+			screen.Frame(),
+			0, // Borderless
+			appkit.BackingStoreBuffered,
+			false,
+		)
+		objc.Retain(&win)
+
+		win.SetOpaque(false)
+		win.SetHasShadow(false)
+		win.SetBackgroundColor(appkit.Color_BlackColor())
+		win.SetAlphaValue(0)
+		win.SetIgnoresMouseEvents(true)
+		win.SetLevel(windowLevel(w.config.OverlayWindowLevel))
+		win.SetCollectionBehavior(
+			appkit.WindowCollectionBehaviorCanJoinAllSpaces |
+				appkit.WindowCollectionBehaviorStationary |
+				appkit.WindowCollectionBehaviorFullScreenAuxiliary,
+		)
+		win.OrderFrontRegardless()
+
+		w.dimWindows = append(w.dimWindows, win)
+	}
+}
+
+// closeDimWindows closes all dim windows
+func (w *Window) closeDimWindows() {
+	for _, win := range w.dimWindows {
+		win.OrderOut(nil)
+		win.Close()
+	}
+	w.dimWindows = nil
+}
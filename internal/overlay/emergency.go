@@ -0,0 +1,138 @@
+package overlay
+
+import (
+	"log"
+	"time"
+
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/objc"
+)
+
+const (
+	// emergencyHold is how long Esc must be held to force the overlay closed
+	emergencyHold = 2 * time.Second
+	// escapeKeyCode is the virtual key code of the Esc key
+	escapeKeyCode = 53
+)
+
+// holdDetector tracks how long a key has been held down. Key repeats while
+// the key is down don't restart the hold.
+type holdDetector struct {
+	pressedAt time.Time // Zero while the key is up
+}
+
+// press records the key going down at now and reports whether this started
+// a new hold
+func (d *holdDetector) press(now time.Time) bool {
+	if !d.pressedAt.IsZero() {
+		return false
+	}
+	d.pressedAt = now
+	return true
+}
+
+// release records the key going up
+func (d *holdDetector) release() {
+	d.pressedAt = time.Time{}
+}
+
+// held reports whether the key has been down for at least hold at now
+func (d *holdDetector) held(now time.Time, hold time.Duration) bool {
+	return !d.pressedAt.IsZero() && now.Sub(d.pressedAt) >= hold
+}
+
+// SetOnEmergencyExit sets the callback for when the user forces the overlay
+// closed by holding Esc
+func (w *Window) SetOnEmergencyExit(callback func()) {
+	w.onEmergency = callback
+}
+
+// startEmergencyExit listens for Esc being held while the overlay shows.
+// The global monitor sees the keys while another app is active, the local
+// one while ours is. Must be called on the main thread.
+func (w *Window) startEmergencyExit() {
+	if len(w.escapeMonitors) > 0 {
+		return
+	}
+
+	mask := appkit.EventMaskKeyDown | appkit.EventMaskKeyUp
+	global := appkit.Event_AddGlobalMonitorForEventsMatchingMaskHandler(mask, func(event appkit.Event) {
+		w.handleEscape(event)
+	})
+	local := appkit.Event_AddLocalMonitorForEventsMatchingMaskHandler(mask, func(event appkit.Event) appkit.Event {
+		w.handleEscape(event)
+		return event
+	})
+	for _, monitor := range []objc.Object{global, local} {
+		if !monitor.IsNil() {
+			w.escapeMonitors = append(w.escapeMonitors, monitor)
+		}
+	}
+}
+
+// stopEmergencyExit removes the Esc monitors. Must be called on the main
+// thread.
+func (w *Window) stopEmergencyExit() {
+	for _, monitor := range w.escapeMonitors {
+		appkit.Event_RemoveMonitor(monitor)
+	}
+	w.escapeMonitors = nil
+
+	w.mu.Lock()
+	w.releaseEscapeLocked()
+	w.mu.Unlock()
+}
+
+// handleEscape starts the hold timer when Esc goes down and cancels it when
+// Esc goes up
+func (w *Window) handleEscape(event appkit.Event) {
+	if event.KeyCode() != escapeKeyCode {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if event.Type() == appkit.EventTypeKeyUp {
+		w.releaseEscapeLocked()
+		return
+	}
+	if !w.isShowing || !w.escape.press(time.Now()) {
+		return
+	}
+	w.escapeTimer = time.AfterFunc(emergencyHold, func() {
+		w.mu.Lock()
+		held := w.escape.held(time.Now(), emergencyHold)
+		w.mu.Unlock()
+		if held {
+			w.emergencyExit()
+		}
+	})
+}
+
+// releaseEscapeLocked ends the current Esc hold. Must be called with w.mu
+// held.
+func (w *Window) releaseEscapeLocked() {
+	w.escape.release()
+	if w.escapeTimer != nil {
+		w.escapeTimer.Stop()
+		w.escapeTimer = nil
+	}
+}
+
+// emergencyExit force-hides the overlay regardless of the dismiss settings
+func (w *Window) emergencyExit() {
+	w.mu.Lock()
+	showing := w.isShowing
+	w.releaseEscapeLocked()
+	w.mu.Unlock()
+	if !showing {
+		return
+	}
+
+	log.Println("Emergency exit used - hiding the overlay")
+	w.Hide()
+	if w.onEmergency != nil {
+		w.onEmergency()
+	}
+}
@@ -0,0 +1,45 @@
+package overlay
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHoldDetector(t *testing.T) {
+	start := time.Date(2025, time.June, 4, 10, 0, 0, 0, time.Local)
+	var d holdDetector
+
+	if d.held(start, emergencyHold) {
+		t.Error("held before the key went down")
+	}
+	if !d.press(start) {
+		t.Error("first press didn't start a hold")
+	}
+
+	// Key repeats don't restart the hold
+	if d.press(start.Add(time.Second)) {
+		t.Error("key repeat started a new hold")
+	}
+	if d.held(start.Add(emergencyHold-time.Millisecond), emergencyHold) {
+		t.Error("held before the hold time")
+	}
+	if !d.held(start.Add(emergencyHold), emergencyHold) {
+		t.Error("not held after the hold time despite key repeats")
+	}
+
+	// Releasing ends the hold, the next press starts over
+	d.release()
+	if d.held(start.Add(3*time.Second), emergencyHold) {
+		t.Error("held after release")
+	}
+	later := start.Add(5 * time.Second)
+	if !d.press(later) {
+		t.Error("press after release didn't start a new hold")
+	}
+	if d.held(later.Add(time.Second), emergencyHold) {
+		t.Error("new hold counted the earlier one")
+	}
+	if !d.held(later.Add(emergencyHold), emergencyHold) {
+		t.Error("new hold not held after the hold time")
+	}
+}
@@ -0,0 +1,34 @@
+package overlay
+
+import (
+	"fmt"
+
+	"github.com/caseymrm/menuet"
+)
+
+// Notification shown instead of the overlay when it can't be created
+const (
+	fallbackTitle   = "Zeit für eine Pause!"
+	fallbackMessage = "👀 Schau in die Ferne, bis die Pause vorbei ist."
+)
+
+// createWindowsSafely runs the window factory and turns a panic in AppKit
+// into an error so a failing overlay doesn't take down the whole app
+func (w *Window) createWindowsSafely() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("overlay creation panicked: %v", r)
+		}
+	}()
+
+	w.createWindows()
+	return nil
+}
+
+// menuetNotify shows a notification through menuet
+func menuetNotify(title, message string) {
+	menuet.App().Notification(menuet.Notification{
+		Title:   title,
+		Message: message,
+	})
+}
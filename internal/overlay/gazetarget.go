@@ -0,0 +1,39 @@
+package overlay
+
+import (
+	"time"
+)
+
+// gazeTargetInset is how far the gaze target's path stays from the screen
+// edges, as a fraction of the screen size
+const gazeTargetInset = 0.1
+
+// gazeTargetCorners are the corners the gaze target visits in order, in
+// unit coordinates with the origin at the bottom left like AppKit. The path
+// starts and ends top left, so it is a closed loop.
+var gazeTargetCorners = [...][2]float64{
+	{gazeTargetInset, 1 - gazeTargetInset},
+	{1 - gazeTargetInset, 1 - gazeTargetInset},
+	{1 - gazeTargetInset, gazeTargetInset},
+	{gazeTargetInset, gazeTargetInset},
+	{gazeTargetInset, 1 - gazeTargetInset},
+}
+
+// gazeTargetAt returns where the gaze target is after elapsed time into a
+// break lasting total, in unit coordinates. The target travels from corner
+// to corner at a steady pace so the loop finishes exactly when the break
+// ends, and stays at the start before and after it.
+func gazeTargetAt(elapsed, total time.Duration) (x, y float64) {
+	start := gazeTargetCorners[0]
+	if total <= 0 || elapsed <= 0 || elapsed >= total {
+		return start[0], start[1]
+	}
+
+	edges := len(gazeTargetCorners) - 1
+	progress := float64(elapsed) / float64(total) * float64(edges)
+	edge := int(progress)
+	t := progress - float64(edge)
+
+	from, to := gazeTargetCorners[edge], gazeTargetCorners[edge+1]
+	return from[0] + (to[0]-from[0])*t, from[1] + (to[1]-from[1])*t
+}
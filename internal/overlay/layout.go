@@ -0,0 +1,92 @@
+package overlay
+
+import (
+	"math"
+
+	"github.com/progrium/darwinkit/macos/foundation"
+)
+
+// Reference screen size (in points) the overlay layout was designed for
+const (
+	referenceWidth  = 1440.0
+	referenceHeight = 900.0
+)
+
+// Bounds for the layout scale so text stays readable on tiny screens and
+// doesn't become absurdly large on huge ones
+const (
+	minLayoutScale = 0.6
+	maxLayoutScale = 2.0
+)
+
+// screenLayout holds the frames and font sizes of the overlay elements for
+// a single screen. Frames are relative to the screen's content view.
+type screenLayout struct {
+	Message   foundation.Rect
+	Countdown foundation.Rect
+	Subtitle  foundation.Rect
+	AddTime   foundation.Rect
+	Dismiss   foundation.Rect
+	NextBreak foundation.Rect
+
+	MessageFontSize   float64
+	CountdownFontSize float64
+	SubtitleFontSize  float64
+
+	CircleDiameter     float64
+	GazeTargetDiameter float64
+}
+
+// layoutForScreen computes the overlay layout for a screen with the given
+// frame (in points) and backing scale factor. Element sizes scale with the
+// screen size relative to the reference screen, and origins are snapped to
+// the device pixel grid so text renders crisply on every display.
+func layoutForScreen(frame foundation.Rect, backingScale float64) screenLayout {
+	if backingScale <= 0 {
+		backingScale = 1
+	}
+
+	scale := math.Min(frame.Size.Width/referenceWidth, frame.Size.Height/referenceHeight)
+	scale = math.Max(minLayoutScale, math.Min(maxLayoutScale, scale))
+
+	centered := func(width, height, centerY float64) foundation.Rect {
+		x := (frame.Size.Width - width) / 2
+		y := centerY - height/2
+		return foundation.Rect{
+			Origin: foundation.Point{
+				X: snapToPixel(x, backingScale),
+				Y: snapToPixel(y, backingScale),
+			},
+			Size: foundation.Size{Width: width, Height: height},
+		}
+	}
+
+	// Countdown in the center, message in the upper third, subtitle,
+	// add-time and dismiss buttons below, the next break time at the bottom
+	countdownHeight := 140 * scale
+	countdown := centered(300*scale, countdownHeight, frame.Size.Height/2)
+	message := centered(800*scale, 60*scale, frame.Size.Height*0.6)
+	subtitle := centered(400*scale, 30*scale, countdown.Origin.Y-35*scale)
+	addTime := centered(120*scale, 32*scale, subtitle.Origin.Y-40*scale)
+	dismiss := centered(120*scale, 32*scale, addTime.Origin.Y-30*scale)
+	nextBreak := centered(400*scale, 30*scale, dismiss.Origin.Y-40*scale)
+
+	return screenLayout{
+		Message:            message,
+		Countdown:          countdown,
+		Subtitle:           subtitle,
+		AddTime:            addTime,
+		Dismiss:            dismiss,
+		NextBreak:          nextBreak,
+		MessageFontSize:    48 * scale,
+		CountdownFontSize:  120 * scale,
+		SubtitleFontSize:   24 * scale,
+		CircleDiameter:     320 * scale,
+		GazeTargetDiameter: 28 * scale,
+	}
+}
+
+// snapToPixel rounds a point coordinate to the nearest device pixel
+func snapToPixel(v, backingScale float64) float64 {
+	return math.Round(v*backingScale) / backingScale
+}
@@ -0,0 +1,95 @@
+package overlay
+
+import (
+	"math"
+	"testing"
+
+	"github.com/progrium/darwinkit/macos/foundation"
+)
+
+// screenFrame returns the frame of a screen of width by height points
+func screenFrame(width, height float64) foundation.Rect {
+	return foundation.Rect{Size: foundation.Size{Width: width, Height: height}}
+}
+
+func TestLayoutForScreenScale(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height float64
+		wantFontSize  float64
+	}{
+		{"reference screen", referenceWidth, referenceHeight, 120},
+		{"larger screen", 2160, 1350, 180},
+		{"ultrawide scales by height", 3440, 900, 120},
+		{"tiny screen at the minimum", 640, 400, 120 * minLayoutScale},
+		{"huge screen at the maximum", 6016, 3384, 120 * maxLayoutScale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := layoutForScreen(screenFrame(tt.width, tt.height), 1)
+			if math.Abs(layout.CountdownFontSize-tt.wantFontSize) > 1e-9 {
+				t.Errorf("CountdownFontSize = %v, want %v", layout.CountdownFontSize, tt.wantFontSize)
+			}
+
+			// The countdown is centered on the screen
+			countdown := layout.Countdown
+			if centerX := countdown.Origin.X + countdown.Size.Width/2; math.Abs(centerX-tt.width/2) > 0.5 {
+				t.Errorf("countdown centered at x %v, want %v", centerX, tt.width/2)
+			}
+			if centerY := countdown.Origin.Y + countdown.Size.Height/2; math.Abs(centerY-tt.height/2) > 0.5 {
+				t.Errorf("countdown centered at y %v, want %v", centerY, tt.height/2)
+			}
+		})
+	}
+}
+
+func TestLayoutForScreenOrder(t *testing.T) {
+	layout := layoutForScreen(screenFrame(referenceWidth, referenceHeight), 2)
+
+	// From top to bottom, with y growing upwards
+	elements := []struct {
+		name string
+		rect foundation.Rect
+	}{
+		{"message", layout.Message},
+		{"countdown", layout.Countdown},
+		{"subtitle", layout.Subtitle},
+		{"add time", layout.AddTime},
+		{"dismiss", layout.Dismiss},
+		{"next break", layout.NextBreak},
+	}
+	for i := 1; i < len(elements); i++ {
+		above, below := elements[i-1], elements[i]
+		if below.rect.Origin.Y >= above.rect.Origin.Y {
+			t.Errorf("%s at y %v isn't below %s at y %v", below.name, below.rect.Origin.Y, above.name, above.rect.Origin.Y)
+		}
+	}
+	if y := layout.NextBreak.Origin.Y; y < 0 {
+		t.Errorf("next break at y %v is off screen", y)
+	}
+}
+
+func TestLayoutForScreenSnapsToPixels(t *testing.T) {
+	// An odd width centers the countdown on a half point
+	frame := screenFrame(1441, referenceHeight)
+
+	tests := []struct {
+		name         string
+		backingScale float64
+		wantX        float64
+	}{
+		{"retina keeps half points", 2, 570.5},
+		{"standard display rounds to points", 1, 571},
+		{"unknown scale as standard", 0, 571},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := layoutForScreen(frame, tt.backingScale)
+			if x := layout.Countdown.Origin.X; x != tt.wantX {
+				t.Errorf("countdown at x %v, want %v", x, tt.wantX)
+			}
+		})
+	}
+}
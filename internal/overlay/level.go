@@ -0,0 +1,20 @@
+package overlay
+
+import (
+	"github.com/progrium/darwinkit/macos/appkit"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// windowLevel maps a configured overlay level to the AppKit window level.
+// Unknown values fall back to the screensaver level.
+func windowLevel(level string) appkit.WindowLevel {
+	switch level {
+	case config.OverlayLevelFloating:
+		return appkit.FloatingWindowLevel
+	case config.OverlayLevelNormal:
+		return appkit.NormalWindowLevel
+	default:
+		return appkit.ScreenSaverWindowLevel
+	}
+}
@@ -0,0 +1,28 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/progrium/darwinkit/macos/appkit"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+func TestWindowLevel(t *testing.T) {
+	tests := []struct {
+		level string
+		want  appkit.WindowLevel
+	}{
+		{config.OverlayLevelScreenSaver, appkit.ScreenSaverWindowLevel},
+		{config.OverlayLevelFloating, appkit.FloatingWindowLevel},
+		{config.OverlayLevelNormal, appkit.NormalWindowLevel},
+		{"", appkit.ScreenSaverWindowLevel},
+		{"topmost", appkit.ScreenSaverWindowLevel},
+	}
+
+	for _, tt := range tests {
+		if got := windowLevel(tt.level); got != tt.want {
+			t.Errorf("windowLevel(%q) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package overlay
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MessageProvider supplies the message shown when the overlay appears
+type MessageProvider interface {
+	Next() string
+}
+
+// builtinMessages are shown when no messages file is configured
+var builtinMessages = []string{
+	defaultMessage,
+	"🌳 Such dir etwas Grünes in der Ferne!",
+	"🪟 Ein Blick aus dem Fenster tut gut!",
+	"😌 Entspann deine Augen – du machst das super!",
+	"☁️ Schau in die Wolken und atme durch!",
+}
+
+// ListProvider cycles through a fixed list of messages
+type ListProvider struct {
+	mu       sync.Mutex
+	messages []string
+	next     int
+}
+
+// NewListProvider creates a provider cycling through messages, or through
+// the default message alone if messages is empty
+func NewListProvider(messages []string) *ListProvider {
+	if len(messages) == 0 {
+		messages = []string{defaultMessage}
+	}
+	return &ListProvider{messages: messages}
+}
+
+// NewBuiltinProvider creates a provider cycling through the built-in messages
+func NewBuiltinProvider() *ListProvider {
+	return NewListProvider(builtinMessages)
+}
+
+// Next returns the next message, starting over after the last one
+func (p *ListProvider) Next() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	message := p.messages[p.next%len(p.messages)]
+	p.next = (p.next + 1) % len(p.messages)
+	return message
+}
+
+// FileProvider cycles through the messages of a text file, one per line.
+// The file is read again whenever it changed; while it is missing or has no
+// messages the fallback provider is used.
+type FileProvider struct {
+	path     string
+	fallback MessageProvider
+
+	mu       sync.Mutex
+	modTime  time.Time
+	size     int64
+	messages *ListProvider
+}
+
+// NewFileProvider creates a provider reading messages from path
+func NewFileProvider(path string, fallback MessageProvider) *FileProvider {
+	return &FileProvider{path: path, fallback: fallback}
+}
+
+// Next returns the next message of the file, or of the fallback provider
+func (p *FileProvider) Next() string {
+	p.mu.Lock()
+	p.reloadIfChanged()
+	messages := p.messages
+	p.mu.Unlock()
+
+	if messages == nil {
+		return p.fallback.Next()
+	}
+	return messages.Next()
+}
+
+// reloadIfChanged reads the file again if its size or modification time
+// changed since it was last read. Must be called with p.mu held.
+func (p *FileProvider) reloadIfChanged() {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		p.messages = nil
+		p.modTime = time.Time{}
+		p.size = 0
+		return
+	}
+	if info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return
+	}
+
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		p.messages = nil
+		return
+	}
+	if lines := parseMessages(data); len(lines) > 0 {
+		p.messages = NewListProvider(lines)
+	} else {
+		p.messages = nil
+	}
+}
+
+// parseMessages returns the non-empty lines of data. Lines starting with #
+// are comments.
+func parseMessages(data []byte) []string {
+	var messages []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		messages = append(messages, line)
+	}
+	return messages
+}
+
+// messageProviderFor returns the provider for the messages file, or the
+// built-in messages without one
+func messageProviderFor(path string) MessageProvider {
+	if path == "" {
+		return NewBuiltinProvider()
+	}
+	return NewFileProvider(path, NewBuiltinProvider())
+}
@@ -0,0 +1,93 @@
+package overlay
+
+import (
+	"time"
+
+	"github.com/progrium/darwinkit/macos/appkit"
+)
+
+const (
+	// defaultOpacity is the background opacity used when OverlayOpacity is unset
+	defaultOpacity = 0.95
+	// nightOpacityBoost is the share of the remaining transparency that
+	// DimMoreAtNight takes away at night
+	nightOpacityBoost = 0.6
+	// Night runs from nightStartHour to nightEndHour, with an hour of
+	// transition before it starts and after it ends
+	nightStartHour = 21
+	nightEndHour   = 6
+)
+
+// rgba is an sRGB color with alpha, each component 0-1
+type rgba struct {
+	R, G, B, A float64
+}
+
+// color converts c into an NSColor
+func (c rgba) color() appkit.Color {
+	return appkit.Color_ColorWithSRGBRedGreenBlueAlpha(c.R, c.G, c.B, c.A)
+}
+
+// palette holds the colors of the overlay
+type palette struct {
+	Background rgba
+	Text       rgba // Message and countdown
+	Subtitle   rgba // Subtitle and next break time
+}
+
+// paletteFor returns the overlay colors. The regular palette lets the
+// screen shine through at opacity and fades the subtitle; the high contrast
+// palette uses a solid black background and pure white text throughout.
+func paletteFor(highContrast bool, opacity float64) palette {
+	if highContrast {
+		return palette{
+			Background: rgba{0, 0, 0, 1},
+			Text:       rgba{1, 1, 1, 1},
+			Subtitle:   rgba{1, 1, 1, 1},
+		}
+	}
+
+	if opacity <= 0 {
+		opacity = defaultOpacity
+	}
+	return palette{
+		Background: rgba{0, 0, 0, opacity},
+		Text:       rgba{1, 1, 1, 1},
+		Subtitle:   rgba{1, 1, 1, 0.7},
+	}
+}
+
+// nightFactor returns how far into the night now is: 0 during the day, 1 at
+// night and in between during the transition hours
+func nightFactor(now time.Time) float64 {
+	hours := float64(now.Hour()) + float64(now.Minute())/60
+	switch {
+	case hours >= nightStartHour || hours < nightEndHour:
+		return 1
+	case hours >= nightStartHour-1:
+		return hours - (nightStartHour - 1)
+	case hours < nightEndHour+1:
+		return nightEndHour + 1 - hours
+	default:
+		return 0
+	}
+}
+
+// nightAdjustedOpacity raises the background opacity base at night so the
+// overlay is darker when the eyes are adjusted to a dim room
+func nightAdjustedOpacity(base float64, now time.Time) float64 {
+	if base <= 0 {
+		base = defaultOpacity
+	}
+	return base + (1-base)*nightOpacityBoost*nightFactor(now)
+}
+
+// palette returns the overlay colors for the current configuration and
+// break kind
+func (w *Window) palette() palette {
+	opacity := w.config.OverlayOpacity
+	if w.config.DimMoreAtNight {
+		opacity = nightAdjustedOpacity(opacity, time.Now())
+	}
+	return applyTheme(paletteFor(w.config.HighContrast, opacity), w.theme(), w.config.HighContrast)
+}
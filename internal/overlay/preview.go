@@ -0,0 +1,54 @@
+package overlay
+
+import (
+	"time"
+
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/macos/foundation"
+	"github.com/progrium/darwinkit/objc"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// previewBackingScale is the backing scale the preview is laid out for,
+// so text stays crisp when the preview is shown on a Retina display
+const previewBackingScale = 2.0
+
+// RenderPreview renders the fullscreen overlay for cfg into an image of the
+// given size, e.g. for a settings panel. The content is laid out for a
+// screen of the reference width with the aspect ratio of size and scaled
+// down, so the preview shows the same proportions as the real overlay. No
+// window is created; the buttons are drawn but inactive. Banner mode
+// previews show the fullscreen content too. Must be called on the main
+// thread.
+func RenderPreview(cfg *config.Config, size foundation.Size) appkit.Image {
+	if size.Width <= 0 || size.Height <= 0 {
+		return appkit.NewImageWithSize(size)
+	}
+
+	w := NewWindow(cfg)
+	w.remainingSecs = int(cfg.BreakDuration.Seconds())
+	w.totalSecs = w.remainingSecs
+	w.message = defaultMessage
+	w.nextBreakAt = time.Now().Add(cfg.WorkDuration)
+
+	frame := foundation.Rect{
+		Size: foundation.Size{
+			Width:  referenceWidth,
+			Height: referenceWidth * size.Height / size.Width,
+		},
+	}
+	view := w.createContentView(frame, previewBackingScale)
+	rep := view.BitmapImageRepForCachingDisplayInRect(frame)
+	view.CacheDisplayInRectToBitmapImageRep(frame, rep)
+	background := w.palette().Background.color()
+
+	// The image draws lazily, so keep both alive for its drawing handler
+	objc.Retain(&rep)
+	objc.Retain(&background)
+	return appkit.Image_ImageWithSizeFlippedDrawingHandler(size, false, func(dst foundation.Rect) bool {
+		background.SetFill()
+		appkit.BezierPath_FillRect(dst)
+		return rep.DrawInRect(dst)
+	})
+}
@@ -0,0 +1,66 @@
+package overlay
+
+import (
+	"log"
+	"time"
+
+	"github.com/progrium/darwinkit/macos/foundation"
+)
+
+// screenParametersChanged is posted when displays are connected,
+// disconnected or rearranged
+const screenParametersChanged foundation.NotificationName = "NSApplicationDidChangeScreenParametersNotification"
+
+// rebuildCountdown returns the seconds to show on overlay windows rebuilt
+// at now. A running countdown continues from its end time; one that hasn't
+// started yet keeps its full length.
+func rebuildCountdown(remaining int, endsAt, now time.Time) int {
+	if endsAt.IsZero() {
+		return remaining
+	}
+	return remainingSecsAt(endsAt, now)
+}
+
+// observeScreenChanges subscribes to display configuration changes once.
+// Must be called on the main thread.
+func (w *Window) observeScreenChanges() {
+	if w.observingScreens {
+		return
+	}
+	w.observingScreens = true
+
+	foundation.NotificationCenter_DefaultCenter().AddObserverForNameObjectQueueUsingBlock(
+		screenParametersChanged,
+		nil,
+		foundation.OperationQueue_MainQueue(),
+		func(notification foundation.Notification) {
+			w.rebuildWindows()
+		},
+	)
+}
+
+// rebuildWindows recreates the overlay windows for the current screens
+// while an overlay is showing, without resetting the countdown. The lock is
+// released before the windows are created, since that sets the breathing
+// phase, sequence step and gaze target under it. Countdown label updates
+// run on the main thread as well, so they never interleave with the
+// rebuild. Must be called on the main thread.
+func (w *Window) rebuildWindows() {
+	w.mu.Lock()
+	if !w.isShowing || len(w.windows) == 0 || !w.config.RebuildOverlayOnScreenChange {
+		w.mu.Unlock()
+		return
+	}
+
+	log.Println("Screen configuration changed - rebuilding overlay")
+	w.remainingSecs = rebuildCountdown(w.remainingSecs, w.endsAt, time.Now())
+	// Creating the windows restores the current breathing phase and sequence step
+	w.breathingPhase = -1
+	w.sequenceStep = -1
+	w.mu.Unlock()
+
+	w.closeOverlayWindows()
+	if err := w.createWindowsSafely(); err != nil {
+		log.Printf("Warning: failed to rebuild overlay: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package overlay
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRebuildCountdown(t *testing.T) {
+	now := time.Date(2025, time.June, 4, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		remaining int
+		endsAt    time.Time
+		want      int
+	}{
+		{"not started keeps its length", 20, time.Time{}, 20},
+		{"running continues from its end", 20, now.Add(12 * time.Second), 12},
+		{"partial second rounds up", 20, now.Add(12*time.Second + 300*time.Millisecond), 13},
+		{"stale remaining is ignored", 3, now.Add(15 * time.Second), 15},
+		{"ended shows zero", 5, now.Add(-time.Second), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rebuildCountdown(tt.remaining, tt.endsAt, now); got != tt.want {
+				t.Errorf("rebuildCountdown(%d) = %d, want %d", tt.remaining, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package overlay
+
+import (
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/macos/foundation"
+)
+
+// screenContainingCursor returns the index of the frame containing cursor,
+// or 0 (the main screen) if none does. Frames and cursor are in the global
+// screen coordinates used by NSScreen and NSEvent.
+func screenContainingCursor(cursor foundation.Point, frames []foundation.Rect) int {
+	for i, frame := range frames {
+		if rectContains(frame, cursor) {
+			return i
+		}
+	}
+	return 0
+}
+
+// overlayScreens returns the screens to cover: all of them, or only the one
+// with the mouse cursor when OverlayActiveScreenOnly is enabled
+func (w *Window) overlayScreens() []appkit.Screen {
+	screens := appkit.Screen_Screens()
+	if !w.config.OverlayActiveScreenOnly || len(screens) < 2 {
+		return screens
+	}
+
+	frames := make([]foundation.Rect, len(screens))
+	for i, screen := range screens {
+		frames[i] = screen.Frame()
+	}
+	active := screenContainingCursor(appkit.Event_MouseLocation(), frames)
+	return screens[active : active+1]
+}
@@ -0,0 +1,40 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/progrium/darwinkit/macos/foundation"
+)
+
+func TestScreenContainingCursor(t *testing.T) {
+	// The main screen with a second one to its right and a third above it
+	frames := []foundation.Rect{
+		{Size: foundation.Size{Width: 1440, Height: 900}},
+		{Origin: foundation.Point{X: 1440, Y: 0}, Size: foundation.Size{Width: 2560, Height: 1440}},
+		{Origin: foundation.Point{X: 0, Y: 900}, Size: foundation.Size{Width: 1920, Height: 1080}},
+	}
+
+	tests := []struct {
+		name   string
+		cursor foundation.Point
+		frames []foundation.Rect
+		want   int
+	}{
+		{"main screen", foundation.Point{X: 700, Y: 400}, frames, 0},
+		{"right screen", foundation.Point{X: 2000, Y: 1200}, frames, 1},
+		{"screen above", foundation.Point{X: 100, Y: 1500}, frames, 2},
+		{"left edge belongs to the right screen", foundation.Point{X: 1440, Y: 10}, frames, 1},
+		{"top edge belongs to the screen above", foundation.Point{X: 100, Y: 900}, frames, 2},
+		{"origin", foundation.Point{}, frames, 0},
+		{"outside every screen", foundation.Point{X: -50, Y: -50}, frames, 0},
+		{"no screens", foundation.Point{X: 700, Y: 400}, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := screenContainingCursor(tt.cursor, tt.frames); got != tt.want {
+				t.Errorf("screenContainingCursor(%v) = %d, want %d", tt.cursor, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package overlay
+
+/*
+#cgo LDFLAGS: -framework CoreGraphics
+#include <stdbool.h>
+
+// Private CoreGraphics call that reports whether anything is currently
+// capturing the screen (screen sharing, recording, remote control).
+extern bool CGSIsScreenWatcherPresent(void);
+*/
+import "C"
+
+// ScreenShareDetector reports whether the screen is currently being shared
+type ScreenShareDetector interface {
+	IsScreenShared() bool
+}
+
+// cgScreenShareDetector detects screen sharing via CoreGraphics
+type cgScreenShareDetector struct{}
+
+// IsScreenShared returns true while another process is watching the screen
+func (cgScreenShareDetector) IsScreenShared() bool {
+	return bool(C.CGSIsScreenWatcherPresent())
+}
+
+// shouldSuppressOverlay decides whether a break overlay must not be shown
+func shouldSuppressOverlay(pauseOnScreenShare bool, detector ScreenShareDetector) bool {
+	if !pauseOnScreenShare || detector == nil {
+		return false
+	}
+	return detector.IsScreenShared()
+}
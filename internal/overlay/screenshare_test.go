@@ -0,0 +1,30 @@
+package overlay
+
+import "testing"
+
+// fakeScreenShare reports a fixed screen sharing state
+type fakeScreenShare bool
+
+func (f fakeScreenShare) IsScreenShared() bool { return bool(f) }
+
+func TestShouldSuppressOverlay(t *testing.T) {
+	tests := []struct {
+		name               string
+		pauseOnScreenShare bool
+		detector           ScreenShareDetector
+		want               bool
+	}{
+		{"disabled while sharing", false, fakeScreenShare(true), false},
+		{"enabled while sharing", true, fakeScreenShare(true), true},
+		{"enabled without sharing", true, fakeScreenShare(false), false},
+		{"enabled without detector", true, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldSuppressOverlay(tt.pauseOnScreenShare, tt.detector); got != tt.want {
+				t.Errorf("shouldSuppressOverlay() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package overlay
+
+import (
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// stepAtElapsed returns the index of the sequence step active after elapsed
+// time into a break lasting total. Each step covers its fraction of the
+// break; once all fractions are used up the last step stays active.
+// Returns -1 if there are no steps.
+func stepAtElapsed(steps []config.SequenceStep, elapsed, total time.Duration) int {
+	if len(steps) == 0 {
+		return -1
+	}
+
+	end := time.Duration(0)
+	for i, step := range steps {
+		end += time.Duration(step.Fraction * float64(total))
+		if elapsed < end {
+			return i
+		}
+	}
+
+	return len(steps) - 1
+}
@@ -0,0 +1,38 @@
+package overlay
+
+import "github.com/siegfried/2020rule/internal/config"
+
+// themeFor returns the overlay theme configured for breaks of kind. Kinds
+// without a theme, including unknown ones, get the zero theme, which keeps
+// the default look.
+func themeFor(themes map[string]config.OverlayTheme, kind string) config.OverlayTheme {
+	return themes[kind]
+}
+
+// applyTheme replaces the background color of p with the theme's, keeping
+// its opacity. The high contrast palette and themes without a valid
+// background are left as they are.
+func applyTheme(p palette, theme config.OverlayTheme, highContrast bool) palette {
+	if highContrast || theme.Background == "" {
+		return p
+	}
+	r, g, b, err := config.ParseHexColor(theme.Background)
+	if err != nil {
+		return p
+	}
+	p.Background = rgba{r, g, b, p.Background.A}
+	return p
+}
+
+// SetBreakKind sets the kind of the upcoming break, which picks its theme
+// from OverlayThemes
+func (w *Window) SetBreakKind(kind string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.breakKind = kind
+}
+
+// theme returns the overlay theme of the current break
+func (w *Window) theme() config.OverlayTheme {
+	return themeFor(w.config.OverlayThemes, w.breakKind)
+}
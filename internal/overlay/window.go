@@ -2,17 +2,42 @@ package overlay
 
 import (
 	"fmt"
+	"log"
 	"sync"
 	"time"
 
 	"github.com/progrium/darwinkit/dispatch"
+	"github.com/progrium/darwinkit/helper/action"
 	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/macos/corefoundation"
+	"github.com/progrium/darwinkit/macos/coregraphics"
 	"github.com/progrium/darwinkit/macos/foundation"
+	"github.com/progrium/darwinkit/macos/quartzcore"
 	"github.com/progrium/darwinkit/objc"
 
 	"github.com/siegfried/2020rule/internal/config"
 )
 
+// Messages shown when the overlay appears
+const (
+	defaultMessage      = "👀 Schau in die Ferne!"
+	recoveryMessage     = "🛋️ Lange ohne Pause – gönn dir eine doppelte Erholungspause!"
+	sessionLimitMessage = "🛑 Mach eine längere Pause!"
+	gazeTargetMessage   = "🎯 Folge dem Punkt mit den Augen!"
+)
+
+// breakVariant selects the opening message and controls of the overlay
+type breakVariant int
+
+const (
+	variantRegular breakVariant = iota
+	variantRecovery
+	variantSessionLimit
+	// variantLockout is a session limit break that can't be ended early or
+	// postponed, only the emergency exit works
+	variantLockout
+)
+
 // Window manages the fullscreen overlay for breaks
 type Window struct {
 	config        *config.Config
@@ -23,26 +48,147 @@ type Window struct {
 	ticker        *time.Ticker
 	stopChan      chan struct{}
 	onComplete    func()
+	onAddTime     func()
+	onDismiss     func(elapsed time.Duration)
+	onPostpone    func()
+	onEmergency   func()
+	createWindows func()                      // Window factory, replaceable for testing
+	notify        func(title, message string) // Used when the overlay can't be shown
 	remainingSecs int
+	totalSecs     int
+	variant       breakVariant
+	endsAt        time.Time // Zero until the countdown starts
+	breakKind     string    // Kind of the current break, picks its theme
+	nextBreakAt   time.Time // Shown with OverlayShowNextBreak, zero if unknown
+	screenShare   ScreenShareDetector
+	messages      MessageProvider
+	messagesFile  string // File the message provider reads, empty for the built-in messages
+	message       string // Message of the current break
+
+	// Set on the main thread once the screen change observer is registered
+	observingScreens bool
+
+	// Emergency exit state, the monitors are only touched on the main thread
+	escapeMonitors []objc.Object
+	escape         holdDetector
+	escapeTimer    *time.Timer
+
+	messageLabels   []appkit.TextField
+	nextBreakLabels []appkit.TextField
+
+	// Breathing guide state (only used for BreakTypeBreathing)
+	breathingCircles []quartzcore.Layer
+	breathingPhase   breathingPhase
+
+	// Gaze target state (only used with GazeTargetExercise)
+	gazeTargets []quartzcore.Layer
+
+	// Eye exercise sequence state (only used with a BreakSequence)
+	sequenceStep int
+
+	// Warm-up dimming state (only used with a PreBreakDimDuration)
+	dimWindows []appkit.Window
+	dimDone    chan struct{}
 }
 
 // NewWindow creates a new overlay window manager
 func NewWindow(cfg *config.Config) *Window {
-	return &Window{
-		config:   cfg,
-		stopChan: make(chan struct{}, 1),
+	w := &Window{
+		config:       cfg,
+		stopChan:     make(chan struct{}, 1),
+		screenShare:  cgScreenShareDetector{},
+		notify:       menuetNotify,
+		messages:     messageProviderFor(cfg.OverlayMessagesFile),
+		messagesFile: cfg.OverlayMessagesFile,
 	}
+	w.createWindows = w.createOverlayWindows
+	return w
+}
+
+// ShouldSuppress reports whether the overlay must not be shown right now,
+// e.g. because the screen is being shared and PauseOnScreenShare is enabled
+func (w *Window) ShouldSuppress() bool {
+	w.mu.Lock()
+	pauseOnScreenShare := w.config.PauseOnScreenShare
+	detector := w.screenShare
+	w.mu.Unlock()
+
+	return shouldSuppressOverlay(pauseOnScreenShare, detector)
+}
+
+// SetScreenShareDetector replaces the screen sharing detector
+func (w *Window) SetScreenShareDetector(detector ScreenShareDetector) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.screenShare = detector
+}
+
+// SetMessageProvider replaces the source of the messages shown when the
+// overlay appears
+func (w *Window) SetMessageProvider(provider MessageProvider) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = provider
 }
 
-// Show displays the overlay on all screens
+// SetNotify replaces how the break is announced when the overlay can't be
+// shown
+func (w *Window) SetNotify(notify func(title, message string)) {
+	w.notify = notify
+}
+
+// SetNextBreakAt sets when the break after the upcoming one is due, shown
+// on the overlay if OverlayShowNextBreak is enabled
+func (w *Window) SetNextBreakAt(t time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.nextBreakAt = t
+}
+
+// Show displays the overlay on all screens. The duration is capped at
+// maxOverlayDuration regardless of the configuration.
 func (w *Window) Show(duration time.Duration) {
+	w.show(duration, variantRegular)
+}
+
+// ShowRecovery displays the overlay for a recovery break after a long
+// session, with its own message
+func (w *Window) ShowRecovery(duration time.Duration) {
+	w.show(duration, variantRecovery)
+}
+
+// ShowSessionLimit displays the overlay for the longer break after
+// MaxSession of continuous work. With lockout the break can't be ended
+// early or postponed.
+func (w *Window) ShowSessionLimit(duration time.Duration, lockout bool) {
+	if lockout {
+		w.show(duration, variantLockout)
+		return
+	}
+	w.show(duration, variantSessionLimit)
+}
+
+// show displays the overlay with the opening message and controls of variant
+func (w *Window) show(duration time.Duration, variant breakVariant) {
+	if capped, clamped := clampOverlayDuration(duration); clamped {
+		log.Printf("Warning: break duration %s exceeds the overlay limit - showing %s", duration, capped)
+		duration = capped
+	}
+
 	w.mu.Lock()
 	if w.isShowing {
 		w.mu.Unlock()
 		return
 	}
 	w.isShowing = true
+	w.variant = variant
+	w.message = w.messages.Next()
 	w.remainingSecs = int(duration.Seconds())
+	w.totalSecs = w.remainingSecs
+	w.endsAt = time.Time{}
+	w.breathingPhase = -1
+	w.sequenceStep = -1
+	dimming := w.stopDimLocked()
 
 	// Drain any leftover stop signal from previous countdown
 	select {
@@ -53,7 +199,16 @@ func (w *Window) Show(duration time.Duration) {
 
 	// Create overlay windows on main thread
 	dispatch.MainQueue().DispatchAsync(func() {
-		w.createOverlayWindows()
+		w.observeScreenChanges()
+		if dimming {
+			w.closeDimWindows()
+		}
+		// Without windows the countdown still completes the break on time
+		if err := w.createWindowsSafely(); err != nil {
+			log.Printf("Warning: %v - falling back to a notification", err)
+			w.notify(fallbackTitle, fallbackMessage)
+		}
+		w.startEmergencyExit()
 		w.startCountdown()
 	})
 }
@@ -82,10 +237,101 @@ func (w *Window) Hide() {
 
 	// Close windows on main thread
 	dispatch.MainQueue().DispatchAsync(func() {
+		w.stopEmergencyExit()
 		w.closeOverlayWindows()
 	})
 }
 
+// AddTime extends the running countdown by d and reports whether it did.
+// A countdown that already reached zero can't be extended.
+func (w *Window) AddTime(d time.Duration) bool {
+	w.mu.Lock()
+	if !w.isShowing {
+		w.mu.Unlock()
+		return false
+	}
+	remaining, total, ok := extendCountdown(w.remainingSecs, w.totalSecs, d)
+	if ok && !w.endsAt.IsZero() {
+		w.endsAt = w.endsAt.Add(time.Duration(remaining-w.remainingSecs) * time.Second)
+	}
+	extended := time.Duration(remaining-w.remainingSecs) * time.Second
+	if ok && !w.nextBreakAt.IsZero() {
+		w.nextBreakAt = w.nextBreakAt.Add(extended)
+	}
+	w.remainingSecs = remaining
+	w.totalSecs = total
+	labels := w.labels
+	nextBreakLabels := w.nextBreakLabels
+	nextBreak := formatNextBreak(w.nextBreakAt)
+	w.mu.Unlock()
+
+	if ok {
+		dispatch.MainQueue().DispatchAsync(func() {
+			for _, label := range labels {
+				label.SetStringValue(fmt.Sprintf("%d", remaining))
+			}
+			for _, label := range nextBreakLabels {
+				label.SetStringValue(nextBreak)
+			}
+		})
+	}
+	return ok
+}
+
+// SetOnAddTime sets the callback for when the user asks for more break time
+func (w *Window) SetOnAddTime(callback func()) {
+	w.onAddTime = callback
+}
+
+// SetOnDismiss sets the callback for when the user ends the break early.
+// It receives how long the overlay was shown.
+func (w *Window) SetOnDismiss(callback func(elapsed time.Duration)) {
+	w.onDismiss = callback
+}
+
+// dismiss hides the overlay before the countdown ends
+func (w *Window) dismiss() {
+	w.mu.Lock()
+	if !w.isShowing || w.endsAt.IsZero() || w.variant == variantLockout {
+		w.mu.Unlock()
+		return
+	}
+	elapsed := time.Duration(w.totalSecs)*time.Second - time.Until(w.endsAt)
+	w.mu.Unlock()
+
+	w.Hide()
+	if w.onDismiss != nil {
+		w.onDismiss(elapsed)
+	}
+}
+
+// canEndEarly returns whether the current break shows the button to end it
+// early
+func (w *Window) canEndEarly() bool {
+	return w.config.AllowEarlyDismiss && w.variant != variantLockout
+}
+
+// SetOnPostpone sets the callback for when the user postpones the break by
+// clicking the overlay
+func (w *Window) SetOnPostpone(callback func()) {
+	w.onPostpone = callback
+}
+
+// postpone hides the overlay so the break can happen later
+func (w *Window) postpone() {
+	w.mu.Lock()
+	if !w.isShowing || w.variant == variantLockout {
+		w.mu.Unlock()
+		return
+	}
+	w.mu.Unlock()
+
+	w.Hide()
+	if w.onPostpone != nil {
+		w.onPostpone()
+	}
+}
+
 // SetOnComplete sets the callback for when the countdown completes
 func (w *Window) SetOnComplete(callback func()) {
 	w.onComplete = callback
@@ -96,16 +342,30 @@ func (w *Window) UpdateConfig(cfg *config.Config) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	w.config = cfg
+	if cfg.OverlayMessagesFile != w.messagesFile {
+		w.messages = messageProviderFor(cfg.OverlayMessagesFile)
+		w.messagesFile = cfg.OverlayMessagesFile
+	}
 }
 
-// createOverlayWindows creates a fullscreen overlay on each screen
+// createOverlayWindows creates a fullscreen overlay, or a banner in banner
+// mode, on each screen to cover
 func (w *Window) createOverlayWindows() {
-	screens := appkit.Screen_Screens()
+	screens := w.overlayScreens()
 
 	w.windows = make([]appkit.Window, 0, len(screens))
 	w.labels = make([]appkit.TextField, 0, len(screens))
+	w.breathingCircles = make([]quartzcore.Layer, 0, len(screens))
+	w.gazeTargets = make([]quartzcore.Layer, 0, len(screens))
+	w.messageLabels = make([]appkit.TextField, 0, len(screens))
+	w.nextBreakLabels = make([]appkit.TextField, 0, len(screens))
 
 	for _, screen := range screens {
+		if w.config.OverlayMode == config.OverlayModeBanner {
+			w.windows = append(w.windows, w.createBannerWindow(screen))
+			continue
+		}
+
 		frame := screen.Frame()
 
 		// Create borderless window (styleMask = 0)
@@ -122,15 +382,10 @@ func (w *Window) createOverlayWindows() {
 		win.SetHasShadow(false)
 
 		// Set background color with configured opacity
-		opacity := w.config.OverlayOpacity
-		if opacity <= 0 {
-			opacity = 0.95
-		}
-		bgColor := appkit.Color_ColorWithSRGBRedGreenBlueAlpha(0.0, 0.0, 0.0, opacity)
-		win.SetBackgroundColor(bgColor)
+		win.SetBackgroundColor(w.palette().Background.color())
 
-		// Set window level to float above everything
-		win.SetLevel(appkit.ScreenSaverWindowLevel)
+		// Set window level (screensaver level floats above everything)
+		win.SetLevel(windowLevel(w.config.OverlayWindowLevel))
 
 		// Allow window to appear on all spaces including fullscreen apps
 		win.SetCollectionBehavior(
@@ -140,7 +395,7 @@ func (w *Window) createOverlayWindows() {
 		)
 
 		// Create content view with countdown label
-		contentView := w.createContentView(frame)
+		contentView := w.createContentView(frame, screen.BackingScaleFactor())
 		win.SetContentView(contentView)
 
 		// Show window
@@ -148,81 +403,248 @@ func (w *Window) createOverlayWindows() {
 
 		w.windows = append(w.windows, win)
 	}
+
+	if w.config.BreakType == config.BreakTypeBreathing {
+		w.updateBreathing(0)
+	} else if len(w.config.BreakSequence) > 0 {
+		w.updateSequence(0)
+	}
+	if w.config.GazeTargetExercise {
+		w.updateGazeTarget(0)
+	}
+}
+
+// openingMessage returns the message shown when the overlay appears. The
+// breathing guide and eye exercise sequence start with their first cue.
+func (w *Window) openingMessage() string {
+	switch {
+	case w.config.BreakType == config.BreakTypeBreathing:
+		return phaseInhale.Cue()
+	case len(w.config.BreakSequence) > 0:
+		return w.config.BreakSequence[0].Text
+	case w.config.GazeTargetExercise:
+		return gazeTargetMessage
+	case w.theme().Message != "":
+		return w.theme().Message
+	case w.variant == variantRecovery:
+		return recoveryMessage
+	case w.variant == variantSessionLimit || w.variant == variantLockout:
+		return sessionLimitMessage
+	default:
+		return w.message
+	}
 }
 
 // createContentView creates the view with countdown text
-func (w *Window) createContentView(frame foundation.Rect) appkit.View {
+func (w *Window) createContentView(frame foundation.Rect, backingScale float64) appkit.View {
 	// Create container view
 	view := appkit.NewViewWithFrame(frame)
+	layout := layoutForScreen(frame, backingScale)
+	breathing := w.config.BreakType == config.BreakTypeBreathing
+	colors := w.palette()
 
 	// Create main message label
-	messageLabel := appkit.NewLabel("👀 Schau in die Ferne!")
+	messageLabel := appkit.NewLabel(w.openingMessage())
 	messageLabel.SetAlignment(appkit.TextAlignmentCenter)
-	messageLabel.SetTextColor(appkit.Color_WhiteColor())
-	messageLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(48, appkit.FontWeightBold))
+	messageLabel.SetTextColor(colors.Text.color())
+	messageLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(layout.MessageFontSize, appkit.FontWeightBold))
 	messageLabel.SetBackgroundColor(appkit.Color_ClearColor())
 	messageLabel.SetBezeled(false)
 	messageLabel.SetEditable(false)
 
 	// Position message in upper third
-	msgWidth := 800.0
-	msgHeight := 60.0
-	msgX := (frame.Size.Width - msgWidth) / 2
-	msgY := frame.Size.Height*0.6 - msgHeight/2
-	messageLabel.SetFrame(foundation.Rect{
-		Origin: foundation.Point{X: msgX, Y: msgY},
-		Size:   foundation.Size{Width: msgWidth, Height: msgHeight},
-	})
+	messageLabel.SetFrame(layout.Message)
 
 	// Create countdown label
 	countdownLabel := appkit.NewLabel(fmt.Sprintf("%d", w.remainingSecs))
 	countdownLabel.SetAlignment(appkit.TextAlignmentCenter)
-	countdownLabel.SetTextColor(appkit.Color_WhiteColor())
-	countdownLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(120, appkit.FontWeightLight))
+	countdownLabel.SetTextColor(colors.Text.color())
+	countdownLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(layout.CountdownFontSize, appkit.FontWeightLight))
 	countdownLabel.SetBackgroundColor(appkit.Color_ClearColor())
 	countdownLabel.SetBezeled(false)
 	countdownLabel.SetEditable(false)
 
 	// Position countdown in center
-	labelWidth := 300.0
-	labelHeight := 140.0
-	labelX := (frame.Size.Width - labelWidth) / 2
-	labelY := (frame.Size.Height - labelHeight) / 2
-	countdownLabel.SetFrame(foundation.Rect{
-		Origin: foundation.Point{X: labelX, Y: labelY},
-		Size:   foundation.Size{Width: labelWidth, Height: labelHeight},
-	})
+	countdownLabel.SetFrame(layout.Countdown)
 
 	// Create subtitle label
 	subtitleLabel := appkit.NewLabel("Sekunden verbleibend")
 	subtitleLabel.SetAlignment(appkit.TextAlignmentCenter)
-	subtitleLabel.SetTextColor(appkit.Color_ColorWithSRGBRedGreenBlueAlpha(1.0, 1.0, 1.0, 0.7))
-	subtitleLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(24, appkit.FontWeightRegular))
+	subtitleLabel.SetTextColor(colors.Subtitle.color())
+	subtitleLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(layout.SubtitleFontSize, appkit.FontWeightRegular))
 	subtitleLabel.SetBackgroundColor(appkit.Color_ClearColor())
 	subtitleLabel.SetBezeled(false)
 	subtitleLabel.SetEditable(false)
 
 	// Position subtitle below countdown
-	subWidth := 400.0
-	subHeight := 30.0
-	subX := (frame.Size.Width - subWidth) / 2
-	subY := labelY - 50
-	subtitleLabel.SetFrame(foundation.Rect{
-		Origin: foundation.Point{X: subX, Y: subY},
-		Size:   foundation.Size{Width: subWidth, Height: subHeight},
+	subtitleLabel.SetFrame(layout.Subtitle)
+
+	// Add breathing circle behind the countdown
+	if breathing {
+		view.SetWantsLayer(true)
+		view.Layer().AddSublayer(w.createBreathingCircle(frame, layout.CircleDiameter))
+	}
+
+	// Add the dot to follow with the eyes
+	if w.config.GazeTargetExercise {
+		view.SetWantsLayer(true)
+		view.Layer().AddSublayer(w.createGazeTarget(frame, layout.GazeTargetDiameter))
+	}
+
+	// Create button to extend the break
+	addTimeButton := appkit.NewButtonWithFrame(layout.AddTime)
+	addTimeButton.SetTitle("+10 s")
+	addTimeButton.SetBezelStyle(appkit.BezelStyleRounded)
+	addTimeButton.SetFont(appkit.Font_SystemFontOfSizeWeight(layout.SubtitleFontSize*0.75, appkit.FontWeightRegular))
+	action.Set(addTimeButton, func(sender objc.Object) {
+		if w.onAddTime != nil {
+			w.onAddTime()
+		}
 	})
 
 	// Add labels to view
 	view.AddSubview(messageLabel)
 	view.AddSubview(countdownLabel)
 	view.AddSubview(subtitleLabel)
+	view.AddSubview(addTimeButton)
+
+	// Create button to end the break early
+	if w.canEndEarly() {
+		dismissButton := appkit.NewButtonWithFrame(layout.Dismiss)
+		dismissButton.SetTitle("Fertig")
+		dismissButton.SetBezelStyle(appkit.BezelStyleRounded)
+		dismissButton.SetFont(appkit.Font_SystemFontOfSizeWeight(layout.SubtitleFontSize*0.75, appkit.FontWeightRegular))
+		action.Set(dismissButton, func(sender objc.Object) {
+			w.dismiss()
+		})
+		view.AddSubview(dismissButton)
+	}
+
+	w.installClickAction(view, layout)
+
+	// Show when the following break is due at the bottom
+	if w.config.OverlayShowNextBreak && !w.nextBreakAt.IsZero() {
+		nextBreakLabel := appkit.NewLabel(formatNextBreak(w.nextBreakAt))
+		nextBreakLabel.SetAlignment(appkit.TextAlignmentCenter)
+		nextBreakLabel.SetTextColor(colors.Subtitle.color())
+		nextBreakLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(layout.SubtitleFontSize*0.75, appkit.FontWeightRegular))
+		nextBreakLabel.SetBackgroundColor(appkit.Color_ClearColor())
+		nextBreakLabel.SetBezeled(false)
+		nextBreakLabel.SetEditable(false)
+		nextBreakLabel.SetFrame(layout.NextBreak)
+		view.AddSubview(nextBreakLabel)
+		w.nextBreakLabels = append(w.nextBreakLabels, nextBreakLabel)
+	}
 
-	// Store countdown label reference for updates
+	// Store label references for updates
 	w.labels = append(w.labels, countdownLabel)
+	w.messageLabels = append(w.messageLabels, messageLabel)
 
 	return view
 }
 
+// createBreathingCircle creates the pulsing circle layer centered in frame
+func (w *Window) createBreathingCircle(frame foundation.Rect, diameter float64) quartzcore.Layer {
+	circle := quartzcore.NewLayer()
+	circle.SetBounds(coregraphics.Rect{
+		Size: coregraphics.Size{Width: diameter, Height: diameter},
+	})
+	circle.SetPosition(coregraphics.Point{X: frame.Size.Width / 2, Y: frame.Size.Height / 2})
+	circle.SetCornerRadius(diameter / 2)
+	circle.SetBackgroundColor(appkit.Color_ColorWithSRGBRedGreenBlueAlpha(1.0, 1.0, 1.0, 0.15).CGColor())
+	circle.SetAffineTransform(scaleTransform(breathingMinScale))
+
+	w.breathingCircles = append(w.breathingCircles, circle)
+	return circle
+}
+
+// updateBreathing moves the breathing guide to the phase active at elapsed.
+// The circle animates towards the phase's target scale over the time left in
+// the phase. Must be called on the main thread.
+func (w *Window) updateBreathing(elapsed time.Duration) {
+	w.mu.Lock()
+	phase, remaining := breathingPhaseAt(w.config.BreathingPacing, elapsed)
+	if phase == w.breathingPhase {
+		w.mu.Unlock()
+		return
+	}
+	w.breathingPhase = phase
+	circles := w.breathingCircles
+	messageLabels := w.messageLabels
+	w.mu.Unlock()
+
+	quartzcore.Transaction_Begin()
+	quartzcore.Transaction_SetAnimationDuration(corefoundation.TimeInterval(remaining.Seconds()))
+	for _, circle := range circles {
+		circle.SetAffineTransform(scaleTransform(phase.targetScale()))
+	}
+	quartzcore.Transaction_Commit()
+
+	for _, label := range messageLabels {
+		label.SetStringValue(phase.Cue())
+	}
+}
+
+// createGazeTarget creates the dot to follow with the eyes, sized to
+// diameter and moving within frame
+func (w *Window) createGazeTarget(frame foundation.Rect, diameter float64) quartzcore.Layer {
+	dot := quartzcore.NewLayer()
+	dot.SetBounds(coregraphics.Rect{
+		Size: coregraphics.Size{Width: diameter, Height: diameter},
+	})
+	x, y := gazeTargetAt(0, 0)
+	dot.SetPosition(coregraphics.Point{X: x * frame.Size.Width, Y: y * frame.Size.Height})
+	dot.SetCornerRadius(diameter / 2)
+	dot.SetBackgroundColor(w.palette().Text.color().CGColor())
+
+	w.gazeTargets = append(w.gazeTargets, dot)
+	return dot
+}
+
+// updateGazeTarget moves the gaze target along its path to where it is due
+// a second after elapsed, animated over that second so it glides between
+// countdown ticks. Must be called on the main thread.
+func (w *Window) updateGazeTarget(elapsed time.Duration) {
+	w.mu.Lock()
+	total := time.Duration(w.totalSecs) * time.Second
+	dots := w.gazeTargets
+	w.mu.Unlock()
+
+	x, y := gazeTargetAt(min(elapsed+time.Second, total), total)
+	quartzcore.Transaction_Begin()
+	quartzcore.Transaction_SetAnimationDuration(corefoundation.TimeInterval(time.Second.Seconds()))
+	for _, dot := range dots {
+		bounds := dot.Superlayer().Bounds()
+		dot.SetPosition(coregraphics.Point{X: x * bounds.Size.Width, Y: y * bounds.Size.Height})
+	}
+	quartzcore.Transaction_Commit()
+}
+
+// updateSequence shows the eye exercise step active at elapsed.
+// Must be called on the main thread.
+func (w *Window) updateSequence(elapsed time.Duration) {
+	w.mu.Lock()
+	steps := w.config.BreakSequence
+	total := time.Duration(w.totalSecs) * time.Second
+	step := stepAtElapsed(steps, elapsed, total)
+	if step < 0 || step == w.sequenceStep {
+		w.mu.Unlock()
+		return
+	}
+	w.sequenceStep = step
+	messageLabels := w.messageLabels
+	w.mu.Unlock()
+
+	for _, label := range messageLabels {
+		label.SetStringValue(steps[step].Text)
+	}
+}
+
+// scaleTransform returns a uniform scaling transform
+func scaleTransform(scale float64) coregraphics.AffineTransform {
+	return coregraphics.AffineTransform{M11: scale, M22: scale}
+}
+
 // closeOverlayWindows closes and releases all overlay windows
 func (w *Window) closeOverlayWindows() {
 	for _, win := range w.windows {
@@ -231,23 +653,38 @@ func (w *Window) closeOverlayWindows() {
 	}
 	w.windows = nil
 	w.labels = nil
+	w.breathingCircles = nil
+	w.gazeTargets = nil
+	w.messageLabels = nil
+	w.nextBreakLabels = nil
 }
 
-// startCountdown begins the countdown timer
+// startCountdown begins the countdown timer. The remaining seconds are
+// derived from the end time on each tick rather than decremented. The
+// goroutine keeps its own ticker, since Hide clears w.ticker at any time.
 func (w *Window) startCountdown() {
-	w.ticker = time.NewTicker(1 * time.Second)
+	ticker := time.NewTicker(1 * time.Second)
+
+	w.mu.Lock()
+	w.endsAt = time.Now().Add(time.Duration(w.remainingSecs) * time.Second)
+	w.ticker = ticker
+	w.mu.Unlock()
 
 	go func() {
 		for {
 			select {
-			case <-w.ticker.C:
+			case <-ticker.C:
 				w.mu.Lock()
 				if !w.isShowing {
 					w.mu.Unlock()
 					return
 				}
-				w.remainingSecs--
+				w.remainingSecs = remainingSecsAt(w.endsAt, time.Now())
 				remaining := w.remainingSecs
+				elapsed := time.Duration(w.totalSecs-remaining) * time.Second
+				breathing := w.config.BreakType == config.BreakTypeBreathing
+				sequence := len(w.config.BreakSequence) > 0
+				gazeTarget := w.config.GazeTargetExercise
 				labels := w.labels
 				w.mu.Unlock()
 
@@ -256,15 +693,19 @@ func (w *Window) startCountdown() {
 					for _, label := range labels {
 						label.SetStringValue(fmt.Sprintf("%d", remaining))
 					}
+					if breathing {
+						w.updateBreathing(elapsed)
+					} else if sequence {
+						w.updateSequence(elapsed)
+					}
+					if gazeTarget {
+						w.updateGazeTarget(elapsed)
+					}
 				})
 
 				// Check if countdown complete
 				if remaining <= 0 {
-					w.mu.Lock()
-					if w.ticker != nil {
-						w.ticker.Stop()
-					}
-					w.mu.Unlock()
+					ticker.Stop()
 					w.Hide()
 					if w.onComplete != nil {
 						w.onComplete()
@@ -0,0 +1,76 @@
+package permissions
+
+/*
+#cgo LDFLAGS: -framework ApplicationServices -framework CoreGraphics
+#include <stdbool.h>
+#include <ApplicationServices/ApplicationServices.h>
+#include <CoreGraphics/CoreGraphics.h>
+
+// Reports whether the app may observe input in other apps. With prompt,
+// macOS asks the user to grant access if they haven't yet.
+static bool permAccessibilityTrusted(bool prompt) {
+	const void *keys[] = { kAXTrustedCheckOptionPrompt };
+	const void *values[] = { prompt ? kCFBooleanTrue : kCFBooleanFalse };
+	CFDictionaryRef options = CFDictionaryCreate(NULL, keys, values, 1,
+		&kCFTypeDictionaryKeyCallBacks, &kCFTypeDictionaryValueCallBacks);
+	bool trusted = AXIsProcessTrustedWithOptions(options);
+	CFRelease(options);
+	return trusted;
+}
+
+// Reports whether the app may read the windows of other apps. With request,
+// macOS asks the user to grant access if they haven't yet.
+static bool permScreenRecording(bool request) {
+	return request ? CGRequestScreenCaptureAccess() : CGPreflightScreenCaptureAccess();
+}
+*/
+import "C"
+
+// Capabilities holds which macOS privacy permissions the app was granted
+type Capabilities struct {
+	// Accessibility allows the global pause hotkey to see key presses in other apps
+	Accessibility bool
+	// ScreenRecording allows reading other apps' windows to detect fullscreen presentations
+	ScreenRecording bool
+}
+
+// Complete reports whether every permission was granted
+func (c Capabilities) Complete() bool {
+	return c.Accessibility && c.ScreenRecording
+}
+
+// Checker checks and requests the permissions the app needs
+type Checker interface {
+	// Check returns the granted permissions without asking the user
+	Check() Capabilities
+	// Request shows the macOS prompts for the permissions not granted yet.
+	// macOS only prompts once per permission; later requests do nothing.
+	Request()
+}
+
+// systemChecker asks macOS for the app's permissions
+type systemChecker struct{}
+
+// NewSystemChecker creates a Checker backed by the macOS privacy settings
+func NewSystemChecker() Checker {
+	return systemChecker{}
+}
+
+// Check returns the permissions granted in the macOS privacy settings
+func (systemChecker) Check() Capabilities {
+	return Capabilities{
+		Accessibility:   bool(C.permAccessibilityTrusted(false)),
+		ScreenRecording: bool(C.permScreenRecording(false)),
+	}
+}
+
+// Request shows the macOS prompts for the missing permissions
+func (systemChecker) Request() {
+	C.permAccessibilityTrusted(true)
+	C.permScreenRecording(true)
+}
+
+// Check returns the permissions granted in the macOS privacy settings
+func Check() Capabilities {
+	return systemChecker{}.Check()
+}
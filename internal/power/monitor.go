@@ -0,0 +1,96 @@
+package power
+
+import (
+	"sync"
+	"time"
+)
+
+// pollInterval is how often the power source is checked
+const pollInterval = 30 * time.Second
+
+// Monitor polls a Source and reports changes between AC and battery power.
+// Macs without a battery, or where the source is unknown, count as on AC.
+type Monitor struct {
+	source    Source
+	onBattery bool
+	onChange  func(onBattery bool)
+	stop      chan struct{}
+	mu        sync.Mutex
+}
+
+// NewMonitor creates a power monitor for source
+func NewMonitor(source Source) *Monitor {
+	return &Monitor{source: source}
+}
+
+// SetOnChange sets the callback for when the Mac switches between AC and battery power
+func (m *Monitor) SetOnChange(callback func(onBattery bool)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onChange = callback
+}
+
+// Start checks the power source now and then every pollInterval
+func (m *Monitor) Start() {
+	m.mu.Lock()
+	if m.stop != nil {
+		m.mu.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	m.stop = stop
+	m.mu.Unlock()
+
+	m.Check()
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.Check()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops polling
+func (m *Monitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	m.stop = nil
+}
+
+// Check reads the power source and calls the change callback if it switched
+func (m *Monitor) Check() {
+	onBattery, ok := m.source.OnBattery()
+	if !ok {
+		onBattery = false
+	}
+
+	m.mu.Lock()
+	changed := onBattery != m.onBattery
+	m.onBattery = onBattery
+	callback := m.onChange
+	m.mu.Unlock()
+
+	if changed && callback != nil {
+		callback(onBattery)
+	}
+}
+
+// OnBattery returns whether the Mac was on battery at the last check
+func (m *Monitor) OnBattery() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.onBattery
+}
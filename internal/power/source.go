@@ -0,0 +1,52 @@
+package power
+
+/*
+#cgo LDFLAGS: -framework IOKit -framework CoreFoundation
+#include <IOKit/ps/IOPowerSources.h>
+#include <IOKit/ps/IOPSKeys.h>
+
+// Returns 1 when running on battery, 0 on AC power and -1 if unknown
+static int onBatteryPower(void) {
+	CFTypeRef info = IOPSCopyPowerSourcesInfo();
+	if (info == NULL) {
+		return -1;
+	}
+
+	int result = -1;
+	CFStringRef type = IOPSGetProvidingPowerSourceType(info);
+	if (type != NULL) {
+		result = CFStringCompare(type, CFSTR(kIOPMBatteryPowerKey), 0) == kCFCompareEqualTo ? 1 : 0;
+	}
+
+	CFRelease(info);
+	return result;
+}
+*/
+import "C"
+
+// Source reports the current power source
+type Source interface {
+	// OnBattery reports whether the Mac runs on battery. ok is false if the
+	// power source can't be determined.
+	OnBattery() (onBattery bool, ok bool)
+}
+
+// ioKitSource reads the providing power source from IOKit
+type ioKitSource struct{}
+
+// NewIOKitSource creates a Source backed by IOKit
+func NewIOKitSource() Source {
+	return ioKitSource{}
+}
+
+// OnBattery reports whether IOKit names the battery as the providing power source
+func (ioKitSource) OnBattery() (bool, bool) {
+	switch C.onBatteryPower() {
+	case 1:
+		return true, true
+	case 0:
+		return false, true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,6 @@
+package sound
+
+import "errors"
+
+// ErrSoundNotFound is returned when a named sound or sound file doesn't exist
+var ErrSoundNotFound = errors.New("sound not found")
@@ -0,0 +1,31 @@
+package sound
+
+import "time"
+
+// Fade-in of the break chime
+const (
+	fadeDuration = 800 * time.Millisecond
+	fadeInterval = 50 * time.Millisecond
+)
+
+// clampVolume limits v to the playable range 0..1
+func clampVolume(v float64) float64 {
+	return min(max(v, 0), 1)
+}
+
+// fadeSteps returns the volumes to apply one interval apart so playback
+// rises linearly from silence to target over duration. The last step is
+// always the target volume.
+func fadeSteps(target float64, duration, interval time.Duration) []float64 {
+	target = clampVolume(target)
+	if interval <= 0 || duration <= interval {
+		return []float64{target}
+	}
+
+	count := int(duration / interval)
+	steps := make([]float64, count)
+	for i := range steps {
+		steps[i] = target * float64(i+1) / float64(count)
+	}
+	return steps
+}
@@ -0,0 +1,112 @@
+package sound
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/progrium/darwinkit/dispatch"
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/objc"
+)
+
+// Player plays the break sounds
+type Player struct {
+	mu        sync.Mutex
+	sound     appkit.Sound
+	soundPath string // File the loaded sound was read from
+	fade      *time.Ticker
+}
+
+// NewPlayer creates a new sound player
+func NewPlayer() *Player {
+	return &Player{}
+}
+
+// PlayBreakStart plays the break-start chime name, fading in to volume (0-1)
+// so it isn't startling. A sound that can't be found is replaced by the
+// default system sound.
+func (p *Player) PlayBreakStart(name string, volume float64) {
+	path, err := Resolve(name)
+	if err != nil {
+		log.Printf("Warning: break start sound %q not found - using %s", name, DefaultBreakStartSound)
+		if path, err = Resolve(DefaultBreakStartSound); err != nil {
+			log.Printf("Warning: default break start sound not found: %v", err)
+			return
+		}
+	}
+	steps := fadeSteps(volume, fadeDuration, fadeInterval)
+
+	p.mu.Lock()
+	p.stopFadeLocked()
+	ticker := time.NewTicker(fadeInterval)
+	p.fade = ticker
+	p.mu.Unlock()
+
+	dispatch.MainQueue().DispatchAsync(func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if !p.loadLocked(path) {
+			return
+		}
+		p.sound.Stop()
+		p.sound.SetVolume(float32(steps[0]))
+		p.sound.Play()
+	})
+
+	go func() {
+		for _, step := range steps[1:] {
+			<-ticker.C
+			p.mu.Lock()
+			stale := p.fade != ticker
+			p.mu.Unlock()
+			if stale {
+				return
+			}
+
+			dispatch.MainQueue().DispatchAsync(func() {
+				p.mu.Lock()
+				defer p.mu.Unlock()
+				if !p.sound.IsNil() {
+					p.sound.SetVolume(float32(step))
+				}
+			})
+		}
+
+		p.mu.Lock()
+		if p.fade == ticker {
+			p.stopFadeLocked()
+		}
+		p.mu.Unlock()
+	}()
+}
+
+// loadLocked loads the sound file at path unless it is already loaded and
+// reports whether a sound is ready. Must be called on the main thread with
+// p.mu held.
+func (p *Player) loadLocked(path string) bool {
+	if !p.sound.IsNil() && p.soundPath == path {
+		return true
+	}
+
+	sound := appkit.NewSoundWithContentsOfFileByReference(path, true)
+	if sound.IsNil() {
+		log.Printf("Warning: failed to load sound %s", path)
+		return false
+	}
+	objc.Retain(&sound)
+	if !p.sound.IsNil() {
+		p.sound.Release()
+	}
+	p.sound = sound
+	p.soundPath = path
+	return true
+}
+
+// stopFadeLocked stops a running fade. Must be called with p.mu held.
+func (p *Player) stopFadeLocked() {
+	if p.fade != nil {
+		p.fade.Stop()
+		p.fade = nil
+	}
+}
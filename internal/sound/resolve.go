@@ -0,0 +1,56 @@
+package sound
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultBreakStartSound is the system sound used when the configured one
+// can't be found
+const DefaultBreakStartSound = "Glass"
+
+// soundExtensions are the file types searched for a named sound, in order
+var soundExtensions = []string{".aiff", ".aif", ".wav", ".caf", ".mp3", ".m4a"}
+
+// soundDirs returns the directories searched for named sounds, like NSSound
+// does: the user's, the local and the system sound library
+func soundDirs() []string {
+	dirs := []string{"/Library/Sounds", "/System/Library/Sounds"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append([]string{filepath.Join(home, "Library", "Sounds")}, dirs...)
+	}
+	return dirs
+}
+
+// Resolve returns the file of the sound called name, which is either an
+// absolute path or the name of a sound in the sound libraries
+func Resolve(name string) (string, error) {
+	return resolveSound(name, soundDirs(), fileExists)
+}
+
+// resolveSound looks name up in dirs, using exists to check for files
+func resolveSound(name string, dirs []string, exists func(string) bool) (string, error) {
+	if filepath.IsAbs(name) {
+		if exists(name) {
+			return name, nil
+		}
+		return "", fmt.Errorf("%w: %s", ErrSoundNotFound, name)
+	}
+
+	for _, dir := range dirs {
+		for _, ext := range soundExtensions {
+			path := filepath.Join(dir, name+ext)
+			if exists(path) {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrSoundNotFound, name)
+}
+
+// fileExists returns whether path is an existing regular file
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
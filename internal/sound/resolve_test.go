@@ -0,0 +1,42 @@
+package sound
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveSound(t *testing.T) {
+	files := map[string]bool{
+		"/home/Library/Sounds/Chime.wav":    true,
+		"/System/Library/Sounds/Glass.aiff": true,
+		"/System/Library/Sounds/Chime.aiff": true,
+		"/Users/me/ding.mp3":                true,
+	}
+	exists := func(path string) bool { return files[path] }
+	dirs := []string{"/home/Library/Sounds", "/Library/Sounds", "/System/Library/Sounds"}
+
+	tests := []struct {
+		name    string
+		sound   string
+		want    string
+		wantErr error
+	}{
+		{"system sound", "Glass", "/System/Library/Sounds/Glass.aiff", nil},
+		{"user sound wins", "Chime", "/home/Library/Sounds/Chime.wav", nil},
+		{"absolute path", "/Users/me/ding.mp3", "/Users/me/ding.mp3", nil},
+		{"unknown name", "Gong", "", ErrSoundNotFound},
+		{"missing file", "/Users/me/gone.mp3", "", ErrSoundNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSound(tt.sound, dirs, exists)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolveSound(%q) error = %v, want %v", tt.sound, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveSound(%q) = %q, want %q", tt.sound, got, tt.want)
+			}
+		})
+	}
+}
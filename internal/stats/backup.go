@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// backupsToKeep is the number of database backups kept on startup
+const backupsToKeep = 3
+
+// Backup writes a consistent copy of the database to dir as stats.db.bak,
+// keeping up to keep backups. Older backups are rotated to stats.db.bak.1,
+// stats.db.bak.2, ... and the oldest is removed.
+func (s *Store) Backup(dir string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+
+	base := filepath.Join(dir, dbFileName+".bak")
+	tmpPath := base + ".tmp"
+
+	// VACUUM INTO refuses to overwrite, so clear leftovers of a failed run
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if _, err := s.db.Exec("VACUUM INTO ?", tmpPath); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	// Rotate existing backups, dropping the oldest
+	if err := os.Remove(backupName(base, keep-1)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := keep - 2; i >= 0; i-- {
+		err := os.Rename(backupName(base, i), backupName(base, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return os.Rename(tmpPath, base)
+}
+
+// backupName returns the path of the n-th backup, where 0 is the newest
+func backupName(base string, n int) string {
+	if n == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s.%d", base, n)
+}
@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// countBackupBreaks returns how many breaks the backup at path holds
+func countBackupBreaks(t *testing.T, path string) int {
+	t.Helper()
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	defer db.Close()
+
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM breaks").Scan(&n); err != nil {
+		t.Fatalf("count breaks in %s: %v", filepath.Base(path), err)
+	}
+	return n
+}
+
+func TestBackupRotation(t *testing.T) {
+	s := newTestStore(t)
+	dir := t.TempDir()
+	const keep = 3
+
+	// Each backup holds one more break than the one before
+	for i := range 4 {
+		insertBreak(t, s, dayAt(2025, time.March, 3+i), OutcomeCompleted)
+		if err := s.Backup(dir, keep); err != nil {
+			t.Fatalf("Backup %d: %v", i+1, err)
+		}
+	}
+
+	base := filepath.Join(dir, dbFileName+".bak")
+	for n, want := range []int{4, 3, 2} {
+		if got := countBackupBreaks(t, backupName(base, n)); got != want {
+			t.Errorf("%s holds %d breaks, want %d", filepath.Base(backupName(base, n)), got, want)
+		}
+	}
+	if _, err := os.Stat(backupName(base, keep)); !os.IsNotExist(err) {
+		t.Errorf("%s kept beyond the limit", filepath.Base(backupName(base, keep)))
+	}
+	if _, err := os.Stat(base + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temporary backup left behind")
+	}
+}
+
+func TestBackupReplacesLeftoverTemp(t *testing.T) {
+	s := newTestStore(t)
+	dir := t.TempDir()
+	base := filepath.Join(dir, dbFileName+".bak")
+	if err := os.WriteFile(base+".tmp", []byte("half written"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Backup(dir, 1); err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	if got := countBackupBreaks(t, base); got != 0 {
+		t.Errorf("backup holds %d breaks, want 0", got)
+	}
+}
+
+func TestBackupDisabled(t *testing.T) {
+	s := newTestStore(t)
+	dir := t.TempDir()
+	if err := s.Backup(dir, 0); err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("backup written with keep 0: %v", entries)
+	}
+}
@@ -0,0 +1,154 @@
+package stats
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGet30DayAverageCompliance(t *testing.T) {
+	s := newTestStore(t)
+
+	average, days, err := s.Get30DayAverageCompliance()
+	if err != nil {
+		t.Fatalf("Get30DayAverageCompliance: %v", err)
+	}
+	if average != 0 || days != 0 {
+		t.Errorf("Get30DayAverageCompliance() = %v, %d without breaks, want 0, 0", average, days)
+	}
+
+	now := time.Now()
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+
+	// Yesterday at 50% and three days ago at 100% count
+	insertBreak(t, s, daysAgo(1), OutcomeCompleted)
+	insertBreak(t, s, daysAgo(1), OutcomeSkipped)
+	insertBreak(t, s, daysAgo(3), OutcomeCompleted)
+	// Today, days without required breaks and days older than the
+	// baseline don't
+	insertBreak(t, s, now, OutcomeSkipped)
+	insertBreak(t, s, daysAgo(2), OutcomeIdle)
+	insertBreak(t, s, daysAgo(baselineDays+5), OutcomeSkipped)
+
+	average, days, err = s.Get30DayAverageCompliance()
+	if err != nil {
+		t.Fatalf("Get30DayAverageCompliance: %v", err)
+	}
+	if average != 75 || days != 2 {
+		t.Errorf("Get30DayAverageCompliance() = %v, %d, want 75, 2", average, days)
+	}
+}
+
+func TestGetComplianceReportPeriods(t *testing.T) {
+	s := newTestStore(t)
+	now := time.Now()
+	insertBreak(t, s, now.Add(-time.Second), OutcomeCompleted)
+	insertBreak(t, s, now.AddDate(0, 0, -3), OutcomeSkipped)
+	insertBreak(t, s, now.AddDate(0, 0, -20), OutcomeCompleted)
+	insertBreak(t, s, now.AddDate(0, -6, 0), OutcomeSkipped)
+	insertBreak(t, s, now.AddDate(-2, 0, 0), OutcomeCompleted)
+
+	tests := []struct {
+		period        Period
+		wantTotal     int
+		wantCompleted int
+	}{
+		{PeriodToday, 1, 1},
+		{PeriodWeek, 2, 1},
+		{PeriodMonth, 3, 2},
+		{PeriodYear, 4, 2},
+		{PeriodAllTime, 5, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.period), func(t *testing.T) {
+			report, err := s.GetComplianceReport(tt.period, "")
+			if err != nil {
+				t.Fatalf("GetComplianceReport: %v", err)
+			}
+			if report.Period != string(tt.period) || report.Mode != ComplianceOfRequired {
+				t.Errorf("report for period %q in mode %q, want %q in %q", report.Period, report.Mode, tt.period, ComplianceOfRequired)
+			}
+			if report.TotalBreaks != tt.wantTotal || report.CompletedBreaks != tt.wantCompleted {
+				t.Errorf("report counts %d breaks, %d completed, want %d, %d",
+					report.TotalBreaks, report.CompletedBreaks, tt.wantTotal, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestGetComplianceReportInvalidPeriod(t *testing.T) {
+	s := newTestStore(t)
+	for _, period := range []Period{"", "fortnight"} {
+		if _, err := s.GetComplianceReport(period, ""); !errors.Is(err, ErrInvalidPeriod) {
+			t.Errorf("GetComplianceReport(%q) error = %v, want %v", period, err, ErrInvalidPeriod)
+		}
+	}
+}
+
+func TestGetComplianceReportAllTimeWithoutBreaks(t *testing.T) {
+	s := newTestStore(t)
+	report, err := s.GetComplianceReport(PeriodAllTime, "")
+	if err != nil {
+		t.Fatalf("GetComplianceReport: %v", err)
+	}
+	if report.TotalBreaks != 0 || report.ComplianceRate != 0 {
+		t.Errorf("report = %+v without breaks, want it empty", report)
+	}
+}
+
+func TestComplianceOfDecided(t *testing.T) {
+	s := newTestStore(t)
+	s.SetPostponeMode(PostponeSkip)
+	now := time.Now()
+	for _, outcome := range []BreakOutcome{
+		OutcomeCompleted, OutcomeCompleted, OutcomeCompleted,
+		OutcomeSkipped, OutcomePostponed, OutcomePostponed, OutcomeIdle,
+	} {
+		insertBreak(t, s, now.Add(-time.Second), outcome)
+	}
+
+	tests := []struct {
+		name string
+		mode ComplianceMode
+		want float64
+	}{
+		// Postponed breaks count as missed in PostponeSkip mode
+		{"of required", ComplianceOfRequired, 50},
+		// Only completed and skipped breaks were decided
+		{"of decided", ComplianceOfDecided, 75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := s.GetComplianceReport(PeriodToday, tt.mode)
+			if err != nil {
+				t.Fatalf("GetComplianceReport: %v", err)
+			}
+			if report.Mode != tt.mode || report.ComplianceRate != tt.want {
+				t.Errorf("compliance in mode %q = %v, want %v in %q", report.Mode, report.ComplianceRate, tt.want, tt.mode)
+			}
+
+			week, err := s.GetCurrentWeekReport(tt.mode)
+			if err != nil {
+				t.Fatalf("GetCurrentWeekReport: %v", err)
+			}
+			if week.ComplianceRate != tt.want {
+				t.Errorf("weekly compliance in mode %q = %v, want %v", tt.mode, week.ComplianceRate, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplianceOfDecidedWithoutDecisions(t *testing.T) {
+	s := newTestStore(t)
+	insertBreak(t, s, time.Now().Add(-time.Second), OutcomePostponed)
+
+	report, err := s.GetComplianceReport(PeriodToday, ComplianceOfDecided)
+	if err != nil {
+		t.Fatalf("GetComplianceReport: %v", err)
+	}
+	if report.ComplianceRate != 0 {
+		t.Errorf("compliance of decided = %v with only a postponed break, want 0", report.ComplianceRate)
+	}
+}
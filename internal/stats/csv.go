@@ -0,0 +1,218 @@
+package stats
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// breakCSVHeader lists the columns of a breaks CSV file in order, the same
+// for ExportBreaksCSV and ImportBreaksCSV. Timestamps are RFC 3339,
+// completed_at and duration_seconds may be empty.
+var breakCSVHeader = []string{"started_at", "completed_at", "kind", "outcome", "duration_seconds"}
+
+// csvBreak is a break parsed from a CSV row
+type csvBreak struct {
+	StartedAt   time.Time
+	CompletedAt *time.Time
+	Kind        BreakKind
+	Outcome     BreakOutcome
+	Duration    *int
+}
+
+// ExportBreaksCSV writes every break that has ended to w as a CSV file with
+// the breakCSVHeader columns, oldest first, and returns how many it wrote.
+// Pending breaks are left out, as ImportBreaksCSV doesn't accept them.
+// Timestamps keep their fractional seconds, so importing the file into the
+// same database adds nothing.
+func (s *Store) ExportBreaksCSV(w io.Writer) (exported int, err error) {
+	rows, err := s.db.Query(
+		`SELECT started_at, completed_at, kind, outcome, duration_seconds
+		 FROM breaks
+		 WHERE outcome != 'pending'
+		 ORDER BY started_at`,
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(breakCSVHeader); err != nil {
+		return 0, err
+	}
+	for rows.Next() {
+		var startedAt time.Time
+		var completedAt sql.NullTime
+		var kind BreakKind
+		var outcome BreakOutcome
+		var duration sql.NullInt64
+		if err := rows.Scan(&startedAt, &completedAt, &kind, &outcome, &duration); err != nil {
+			return exported, err
+		}
+
+		record := []string{startedAt.Format(time.RFC3339Nano), "", string(kind), string(outcome), ""}
+		if completedAt.Valid {
+			record[1] = completedAt.Time.Format(time.RFC3339Nano)
+		}
+		if duration.Valid {
+			record[4] = strconv.FormatInt(duration.Int64, 10)
+		}
+		if err := writer.Write(record); err != nil {
+			return exported, err
+		}
+		exported++
+	}
+	if err := rows.Err(); err != nil {
+		return exported, err
+	}
+
+	writer.Flush()
+	return exported, writer.Error()
+}
+
+// ImportBreaksCSV reads breaks from a CSV file with the breakCSVHeader
+// columns, e.g. when migrating from another break app, and returns how many
+// were imported. Breaks starting at the same time as one already recorded
+// or an earlier row are skipped. A malformed row fails the whole import
+// with ErrInvalidCSV and its line number, leaving the database untouched.
+// The daily statistics of the days with imported breaks are rebuilt.
+func (s *Store) ImportBreaksCSV(r io.Reader) (imported int, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(breakCSVHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidCSV, err)
+	}
+	if !slices.Equal(header, breakCSVHeader) {
+		return 0, fmt.Errorf("%w: line 1: expected columns %v", ErrInvalidCSV, breakCSVHeader)
+	}
+
+	var breaks []csvBreak
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// csv.ParseError already names the line
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return 0, fmt.Errorf("%w: %v", ErrInvalidCSV, parseErr)
+			}
+			return 0, err
+		}
+
+		b, err := parseBreakRecord(record)
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			return 0, fmt.Errorf("%w: line %d: %v", ErrInvalidCSV, line, err)
+		}
+		breaks = append(breaks, b)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	days := make(map[string]time.Time)
+	for _, b := range breaks {
+		var exists bool
+		err := tx.QueryRow(
+			"SELECT EXISTS(SELECT 1 FROM breaks WHERE started_at = ?)",
+			b.StartedAt,
+		).Scan(&exists)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			continue
+		}
+
+		_, err = tx.Exec(
+			"INSERT INTO breaks (started_at, completed_at, kind, outcome, duration_seconds) VALUES (?, ?, ?, ?, ?)",
+			b.StartedAt,
+			b.CompletedAt,
+			b.Kind,
+			b.Outcome,
+			b.Duration,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import break from %s: %w", b.StartedAt.Format(time.RFC3339), err)
+		}
+		imported++
+		days[b.StartedAt.Format("2006-01-02")] = b.StartedAt
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	for _, day := range days {
+		if err := s.updateDailyStats(day); err != nil {
+			return imported, err
+		}
+	}
+	return imported, nil
+}
+
+// parseBreakRecord parses and validates a CSV row in breakCSVHeader order.
+// Timestamps are converted to local time like those the app records.
+func parseBreakRecord(record []string) (csvBreak, error) {
+	var b csvBreak
+
+	startedAt, err := time.Parse(time.RFC3339, record[0])
+	if err != nil {
+		return b, fmt.Errorf("started_at: %v", err)
+	}
+	b.StartedAt = startedAt.Local()
+
+	if record[1] != "" {
+		completedAt, err := time.Parse(time.RFC3339, record[1])
+		if err != nil {
+			return b, fmt.Errorf("completed_at: %v", err)
+		}
+		if completedAt.Before(startedAt) {
+			return b, fmt.Errorf("completed_at is before started_at")
+		}
+		completedAt = completedAt.Local()
+		b.CompletedAt = &completedAt
+	}
+
+	b.Kind = BreakKind(record[2])
+	switch b.Kind {
+	case BreakKindRegular, BreakKindGentle, BreakKindRecovery, BreakKindSessionLimit:
+	default:
+		return b, fmt.Errorf("unknown kind %q", record[2])
+	}
+
+	// Imported breaks have ended, so pending isn't accepted
+	b.Outcome = BreakOutcome(record[3])
+	switch b.Outcome {
+	case OutcomeCompleted, OutcomeSkipped, OutcomePostponed, OutcomeAbandoned, OutcomeIdle, OutcomeSuppressed:
+	default:
+		return b, fmt.Errorf("unknown outcome %q", record[3])
+	}
+
+	if record[4] != "" {
+		seconds, err := strconv.Atoi(record[4])
+		if err != nil || seconds < 0 {
+			return b, fmt.Errorf("duration_seconds must be a whole number of seconds, got %q", record[4])
+		}
+		b.Duration = &seconds
+	}
+
+	return b, nil
+}
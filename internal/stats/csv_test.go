@@ -0,0 +1,173 @@
+package stats
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// breakCSV returns a breaks CSV file with rows after the header
+func breakCSV(rows ...string) *strings.Reader {
+	return strings.NewReader(strings.Join(append([]string{strings.Join(breakCSVHeader, ",")}, rows...), "\n"))
+}
+
+// rfc3339 formats t like the timestamps of a breaks CSV file
+func rfc3339(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func TestImportBreaksCSVSkipsDuplicates(t *testing.T) {
+	s := newTestStore(t)
+	day := dayAt(2025, time.March, 3)
+	insertBreak(t, s, day, OutcomeCompleted)
+
+	imported, err := s.ImportBreaksCSV(breakCSV(
+		// Already recorded
+		rfc3339(day)+","+rfc3339(day.Add(20*time.Second))+",regular,completed,20",
+		rfc3339(day.Add(time.Hour))+","+rfc3339(day.Add(time.Hour+40*time.Second))+",recovery,completed,40",
+		// Repeats the row above
+		rfc3339(day.Add(time.Hour))+",,regular,skipped,",
+		rfc3339(day.Add(2*time.Hour))+",,regular,skipped,",
+		// Same instant as the row above, written in another zone
+		day.Add(2*time.Hour).UTC().Format(time.RFC3339)+",,regular,completed,",
+	))
+	if err != nil {
+		t.Fatalf("ImportBreaksCSV: %v", err)
+	}
+	if imported != 2 {
+		t.Errorf("imported %d breaks, want 2", imported)
+	}
+
+	counts, err := s.CountBreaksBetween(day.Add(-time.Hour), day.Add(3*time.Hour))
+	if err != nil {
+		t.Fatalf("CountBreaksBetween: %v", err)
+	}
+	if counts[OutcomeCompleted] != 2 || counts[OutcomeSkipped] != 1 {
+		t.Errorf("recorded outcomes %v, want 2 completed and 1 skipped", counts)
+	}
+
+	daily, err := s.GetDailyStats(day)
+	if err != nil {
+		t.Fatalf("GetDailyStats: %v", err)
+	}
+	if daily.BreaksRequired != 3 || daily.BreaksCompleted != 2 || daily.BreaksSkipped != 1 {
+		t.Errorf("daily stats %d required, %d completed, %d skipped, want 3, 2, 1",
+			daily.BreaksRequired, daily.BreaksCompleted, daily.BreaksSkipped)
+	}
+
+	// Importing the same file again adds nothing
+	imported, err = s.ImportBreaksCSV(breakCSV(
+		rfc3339(day.Add(time.Hour)) + ",,regular,skipped,",
+	))
+	if err != nil {
+		t.Fatalf("ImportBreaksCSV again: %v", err)
+	}
+	if imported != 0 {
+		t.Errorf("imported %d breaks again, want 0", imported)
+	}
+}
+
+func TestImportBreaksCSVRejectsMalformedRows(t *testing.T) {
+	start := rfc3339(dayAt(2025, time.March, 3))
+	valid := start + ",,regular,completed,"
+
+	tests := []struct {
+		name     string
+		file     *strings.Reader
+		wantLine string
+	}{
+		{"wrong header", strings.NewReader("started_at,outcome\n" + valid), "line 1"},
+		{"bad timestamp", breakCSV(valid, "yesterday,,regular,completed,"), "line 3"},
+		{"ends before it starts", breakCSV("2025-03-03T12:00:00Z,2025-03-03T11:00:00Z,regular,completed,"), "line 2"},
+		{"unknown kind", breakCSV(valid, "2025-03-04T12:00:00Z,,stretch,completed,"), "line 3"},
+		{"pending outcome", breakCSV("2025-03-04T12:00:00Z,,regular,pending,"), "line 2"},
+		{"negative duration", breakCSV("2025-03-04T12:00:00Z,,regular,completed,-5"), "line 2"},
+		{"missing column", breakCSV(valid, "2025-03-04T12:00:00Z,,regular,completed"), "line 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			imported, err := s.ImportBreaksCSV(tt.file)
+			if !errors.Is(err, ErrInvalidCSV) {
+				t.Fatalf("ImportBreaksCSV() = %v, want %v", err, ErrInvalidCSV)
+			}
+			if !strings.Contains(err.Error(), tt.wantLine) {
+				t.Errorf("error %q doesn't name %s", err, tt.wantLine)
+			}
+			if imported != 0 {
+				t.Errorf("imported %d breaks, want 0", imported)
+			}
+
+			var n int
+			if err := s.db.QueryRow("SELECT COUNT(*) FROM breaks").Scan(&n); err != nil {
+				t.Fatalf("count breaks: %v", err)
+			}
+			if n != 0 {
+				t.Errorf("%d breaks recorded by a failed import, want 0", n)
+			}
+		})
+	}
+}
+
+func TestImportBreaksCSVEmptyFile(t *testing.T) {
+	s := newTestStore(t)
+	imported, err := s.ImportBreaksCSV(strings.NewReader(""))
+	if err != nil || imported != 0 {
+		t.Errorf("ImportBreaksCSV(empty) = %d, %v, want 0, nil", imported, err)
+	}
+}
+
+func TestExportBreaksCSVRoundTrip(t *testing.T) {
+	s := newTestStore(t)
+	day := dayAt(2025, time.March, 3)
+	insertBreak(t, s, day.Add(time.Hour), OutcomeSkipped)
+	insertBreak(t, s, day, OutcomeCompleted)
+	insertBreak(t, s, day.Add(2*time.Hour), OutcomePending)
+	if _, err := s.db.Exec(
+		"INSERT INTO breaks (started_at, kind, outcome) VALUES (?, ?, ?)",
+		day.Add(3*time.Hour+500*time.Millisecond), BreakKindRecovery, OutcomeIdle,
+	); err != nil {
+		t.Fatalf("insert break: %v", err)
+	}
+
+	var out strings.Builder
+	exported, err := s.ExportBreaksCSV(&out)
+	if err != nil {
+		t.Fatalf("ExportBreaksCSV: %v", err)
+	}
+	if exported != 3 {
+		t.Errorf("exported %d breaks, want 3 without the pending one", exported)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{
+		strings.Join(breakCSVHeader, ","),
+		rfc3339(day) + "," + rfc3339(day.Add(20*time.Second)) + ",regular,completed,20",
+		rfc3339(day.Add(time.Hour)) + "," + rfc3339(day.Add(time.Hour+20*time.Second)) + ",regular,skipped,20",
+		day.Add(3*time.Hour+500*time.Millisecond).Format(time.RFC3339Nano) + ",,recovery,idle,",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("exported\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	// The export imports into the same database as duplicates only...
+	imported, err := s.ImportBreaksCSV(strings.NewReader(out.String()))
+	if err != nil {
+		t.Fatalf("ImportBreaksCSV into the same store: %v", err)
+	}
+	if imported != 0 {
+		t.Errorf("imported %d breaks into the same store, want 0", imported)
+	}
+
+	// ...and completely into a fresh one
+	fresh := newTestStore(t)
+	imported, err = fresh.ImportBreaksCSV(strings.NewReader(out.String()))
+	if err != nil {
+		t.Fatalf("ImportBreaksCSV into a fresh store: %v", err)
+	}
+	if imported != exported {
+		t.Errorf("imported %d breaks into a fresh store, want %d", imported, exported)
+	}
+}
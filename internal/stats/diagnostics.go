@@ -0,0 +1,46 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+)
+
+// requiredColumns lists the columns of each table the current version of
+// the app relies on, including those added by migrations
+var requiredColumns = map[string][]string{
+	"breaks":        {"id", "started_at", "completed_at", "duration_seconds", "kind", "outcome", "was_manual"},
+	"daily_stats":   {"date", "breaks_required", "breaks_completed", "breaks_skipped", "breaks_postponed", "compliance_rate"},
+	"sessions":      {"id", "started_at", "ended_at"},
+	"pauses":        {"id", "started_at", "ended_at", "kind"},
+	"break_ratings": {"id", "break_id", "rating", "rated_at"},
+	"monthly_stats": {"month", "breaks_required", "breaks_completed", "breaks_skipped", "breaks_postponed"},
+}
+
+// CheckWritable verifies that the database accepts writes. The test write
+// is rolled back, so the data is left untouched.
+func (s *Store) CheckWritable() error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("INSERT INTO sessions (started_at) VALUES (?)", time.Now())
+	return err
+}
+
+// CheckSchema verifies that every table has the columns of the current schema
+func (s *Store) CheckSchema() error {
+	for table, required := range requiredColumns {
+		columns, err := s.columnNames(table)
+		if err != nil {
+			return err
+		}
+		for _, column := range required {
+			if !columns[column] {
+				return fmt.Errorf("%s.%s is missing", table, column)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package stats
+
+import "errors"
+
+var (
+	// ErrSessionNotFound is returned when a session ID doesn't exist
+	ErrSessionNotFound = errors.New("session not found")
+
+	// ErrInvalidBucketSize is returned when a histogram bucket size is not positive
+	ErrInvalidBucketSize = errors.New("bucket size must be positive")
+
+	// ErrInvalidRating is returned when a rating is outside MinRating..MaxRating
+	ErrInvalidRating = errors.New("rating must be between 1 and 5")
+
+	// ErrInvalidSkipReason is returned when a skip reason is not one of the known reasons
+	ErrInvalidSkipReason = errors.New("invalid skip reason")
+
+	// ErrInvalidPeriod is returned when a compliance report is asked for an unknown period
+	ErrInvalidPeriod = errors.New("invalid period")
+
+	// ErrInvalidCSV is returned when a breaks CSV file can't be imported
+	ErrInvalidCSV = errors.New("invalid breaks CSV")
+)
@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeHealthScore(t *testing.T) {
+	report := func(rate float64) *ComplianceReport {
+		return &ComplianceReport{TotalBreaks: 10, ComplianceRate: rate}
+	}
+
+	tests := []struct {
+		name      string
+		report    *ComplianceReport
+		avgDur    time.Duration
+		targetDur time.Duration
+		streak    int
+		want      int
+	}{
+		{"no report", nil, 20 * time.Second, 20 * time.Second, 7, 0},
+		{"no breaks", &ComplianceReport{ComplianceRate: 100}, 20 * time.Second, 20 * time.Second, 7, 0},
+		{"perfect", report(100), 20 * time.Second, 20 * time.Second, 7, 100},
+		{"longer breaks earn no extra", report(100), time.Minute, 20 * time.Second, 7, 100},
+		{"streak beyond a week earns no extra", report(100), 20 * time.Second, 20 * time.Second, 30, 100},
+		{"half compliance", report(50), 20 * time.Second, 20 * time.Second, 7, 70},
+		{"half duration", report(100), 10 * time.Second, 20 * time.Second, 7, 88},
+		{"no streak", report(100), 20 * time.Second, 20 * time.Second, 0, 85},
+		{"without target", report(0), 0, 0, 0, 25},
+		{"nothing kept", report(0), 0, 20 * time.Second, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeHealthScore(tt.report, tt.avgDur, tt.targetDur, tt.streak); got != tt.want {
+				t.Errorf("ComputeHealthScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetHealthScore(t *testing.T) {
+	s := newTestStore(t)
+	now := time.Now().Add(-time.Minute)
+	for i, outcome := range []BreakOutcome{OutcomeCompleted, OutcomeCompleted, OutcomeCompleted, OutcomeSkipped} {
+		insertBreak(t, s, now.Add(-time.Duration(i)*time.Second), outcome)
+	}
+
+	tests := []struct {
+		name        string
+		breakTarget time.Duration
+		streakGoal  float64
+		want        int
+	}{
+		// 45 points for 75% compliance, 25 for 20 second breaks, 2 for one day
+		{"goals met", 20 * time.Second, 70, 72},
+		{"breaks too short", 40 * time.Second, 70, 60},
+		{"streak goal missed", 20 * time.Second, 80, 70},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.SetHealthScoreGoals(tt.breakTarget, tt.streakGoal)
+			got, err := s.GetHealthScore()
+			if err != nil {
+				t.Fatalf("GetHealthScore: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetHealthScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package stats
+
+import "time"
+
+// GetYearHeatmap returns the compliance rate of every day of year, keyed by
+// date (2006-01-02). Days without breaks are 0.
+func (s *Store) GetYearHeatmap(year int) (map[string]float64, error) {
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	next := first.AddDate(1, 0, 0)
+
+	rows, err := s.db.Query(
+		`SELECT date, COALESCE(compliance_rate, 0)
+		 FROM daily_stats
+		 WHERE breaks_required > 0 AND date >= ? AND date < ?`,
+		first.Format("2006-01-02"),
+		next.Format("2006-01-02"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := make(map[string]float64)
+	for rows.Next() {
+		var date time.Time
+		var rate float64
+		if err := rows.Scan(&date, &rate); err != nil {
+			return nil, err
+		}
+		rates[date.Format("2006-01-02")] = rate
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return yearHeatmap(year, rates), nil
+}
+
+// yearHeatmap returns a map with an entry for every day of year, taking the
+// rate from rates and 0 for days missing there
+func yearHeatmap(year int, rates map[string]float64) map[string]float64 {
+	heatmap := make(map[string]float64, 366)
+	for day := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		heatmap[key] = rates[key]
+	}
+	return heatmap
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestYearHeatmap(t *testing.T) {
+	tests := []struct {
+		name     string
+		year     int
+		wantDays int
+	}{
+		{"common year", 2025, 365},
+		{"leap year", 2024, 366},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates := map[string]float64{"2024-02-29": 80, "2025-06-04": 50, "2026-01-01": 100}
+			heatmap := yearHeatmap(tt.year, rates)
+			if len(heatmap) != tt.wantDays {
+				t.Errorf("heatmap has %d days, want %d", len(heatmap), tt.wantDays)
+			}
+			if _, ok := heatmap["2026-01-01"]; ok {
+				t.Error("heatmap has a day of the next year")
+			}
+		})
+	}
+
+	heatmap := yearHeatmap(2024, map[string]float64{"2024-02-29": 80})
+	if got := heatmap["2024-02-29"]; got != 80 {
+		t.Errorf("heatmap[2024-02-29] = %v, want 80", got)
+	}
+	if got, ok := heatmap["2024-03-01"]; !ok || got != 0 {
+		t.Errorf("heatmap[2024-03-01] = %v, %t, want 0 for a day without breaks", got, ok)
+	}
+}
+
+func TestGetYearHeatmap(t *testing.T) {
+	s := newTestStore(t)
+	insertBreak(t, s, dayAt(2025, time.June, 4), OutcomeCompleted)
+	insertBreak(t, s, dayAt(2025, time.June, 4), OutcomeSkipped)
+	insertBreak(t, s, dayAt(2025, time.December, 31), OutcomeCompleted)
+	insertBreak(t, s, dayAt(2024, time.December, 31), OutcomeCompleted)
+	insertBreak(t, s, dayAt(2025, time.July, 1), OutcomeIdle)
+
+	heatmap, err := s.GetYearHeatmap(2025)
+	if err != nil {
+		t.Fatalf("GetYearHeatmap: %v", err)
+	}
+	if len(heatmap) != 365 {
+		t.Errorf("heatmap has %d days, want 365", len(heatmap))
+	}
+
+	want := map[string]float64{
+		"2025-06-04": 50,
+		"2025-12-31": 100,
+		"2025-07-01": 0,
+		"2025-01-01": 0,
+	}
+	for date, rate := range want {
+		if got := heatmap[date]; got != rate {
+			t.Errorf("heatmap[%s] = %v, want %v", date, got, rate)
+		}
+	}
+	if _, ok := heatmap["2024-12-31"]; ok {
+		t.Error("heatmap has a day of the previous year")
+	}
+}
@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"errors"
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestGetDurationHistogram(t *testing.T) {
+	s := newTestStore(t)
+	from := dayAt(2025, time.June, 4)
+	to := from.Add(time.Hour)
+
+	// insertLasting records a break with outcome that lasted secs
+	insertLasting := func(startedAt time.Time, outcome BreakOutcome, secs int) {
+		id := insertBreak(t, s, startedAt, outcome)
+		if _, err := s.db.Exec("UPDATE breaks SET duration_seconds = ? WHERE id = ?", secs, id); err != nil {
+			t.Fatalf("update duration: %v", err)
+		}
+	}
+	insertLasting(from, OutcomeCompleted, 3)
+	insertLasting(from.Add(10*time.Minute), OutcomeCompleted, 12)
+	insertLasting(from.Add(20*time.Minute), OutcomeCompleted, 14)
+	insertLasting(from.Add(30*time.Minute), OutcomeCompleted, 20)
+	insertLasting(from.Add(40*time.Minute), OutcomeSkipped, 20)
+	insertLasting(to, OutcomeCompleted, 20)
+
+	tests := []struct {
+		name       string
+		bucketSecs int
+		want       map[int]int
+	}{
+		{"5 second buckets", 5, map[int]int{0: 1, 10: 2, 20: 1}},
+		{"10 second buckets", 10, map[int]int{0: 1, 10: 2, 20: 1}},
+		{"one bucket", 60, map[int]int{0: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetDurationHistogram(from, to, tt.bucketSecs)
+			if err != nil {
+				t.Fatalf("GetDurationHistogram: %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("GetDurationHistogram() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDurationHistogramEdgeCases(t *testing.T) {
+	s := newTestStore(t)
+	from := dayAt(2025, time.June, 4)
+
+	got, err := s.GetDurationHistogram(from, from.Add(time.Hour), 5)
+	if err != nil {
+		t.Fatalf("GetDurationHistogram: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("GetDurationHistogram() = %v without breaks, want it empty", got)
+	}
+
+	for _, bucketSecs := range []int{0, -5} {
+		if _, err := s.GetDurationHistogram(from, from.Add(time.Hour), bucketSecs); !errors.Is(err, ErrInvalidBucketSize) {
+			t.Errorf("GetDurationHistogram() with %d second buckets error = %v, want %v", bucketSecs, err, ErrInvalidBucketSize)
+		}
+	}
+}
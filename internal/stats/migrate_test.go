@@ -0,0 +1,102 @@
+package stats
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMigrateBackfillsOutcomes(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(dataDirEnv, dir)
+
+	// A database written before kinds, postponing and outcomes existed
+	db, err := sql.Open("sqlite", filepath.Join(dir, dbFileName))
+	if err != nil {
+		t.Fatalf("open legacy database: %v", err)
+	}
+	_, err = db.Exec(`CREATE TABLE breaks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		started_at TIMESTAMP NOT NULL,
+		completed_at TIMESTAMP,
+		was_completed BOOLEAN DEFAULT 0,
+		was_skipped BOOLEAN DEFAULT 0,
+		duration_seconds INTEGER
+	)`)
+	if err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+
+	start := dayAt(2025, time.March, 3)
+	legacy := []struct {
+		completed, skipped bool
+	}{
+		{true, false},
+		{false, true},
+		{true, true},
+		{false, false},
+	}
+	for i, b := range legacy {
+		_, err := db.Exec(
+			"INSERT INTO breaks (started_at, was_completed, was_skipped) VALUES (?, ?, ?)",
+			start.Add(time.Duration(i)*time.Hour), b.completed, b.skipped,
+		)
+		if err != nil {
+			t.Fatalf("insert legacy break: %v", err)
+		}
+	}
+	db.Close()
+
+	s, err := NewStore()
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	defer s.Close()
+
+	want := []BreakOutcome{OutcomeCompleted, OutcomeSkipped, OutcomeCompleted, OutcomePending}
+	rows, err := s.db.Query("SELECT outcome, kind FROM breaks ORDER BY id")
+	if err != nil {
+		t.Fatalf("query breaks: %v", err)
+	}
+	defer rows.Close()
+	var got []BreakOutcome
+	for rows.Next() {
+		var outcome BreakOutcome
+		var kind BreakKind
+		if err := rows.Scan(&outcome, &kind); err != nil {
+			t.Fatalf("scan break: %v", err)
+		}
+		if kind != BreakKindRegular {
+			t.Errorf("legacy break of kind %s, want %s", kind, BreakKindRegular)
+		}
+		got = append(got, outcome)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("read breaks: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("outcomes %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("outcome of legacy break %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMigrateTwiceKeepsOutcomes(t *testing.T) {
+	s := newTestStore(t)
+	id := insertBreak(t, s, dayAt(2025, time.March, 3), OutcomeAbandoned)
+
+	if err := s.migrate(); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	var outcome BreakOutcome
+	if err := s.db.QueryRow("SELECT outcome FROM breaks WHERE id = ?", id).Scan(&outcome); err != nil {
+		t.Fatalf("query outcome: %v", err)
+	}
+	if outcome != OutcomeAbandoned {
+		t.Errorf("outcome after migrating again = %s, want %s", outcome, OutcomeAbandoned)
+	}
+}
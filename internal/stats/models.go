@@ -1,15 +1,89 @@
 package stats
 
-import "time"
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// BreakKind describes what kind of break was taken
+type BreakKind string
+
+const (
+	// BreakKindRegular is a break of the configured duration
+	BreakKindRegular BreakKind = "regular"
+	// BreakKindGentle is the shortened first break of the day
+	BreakKindGentle BreakKind = "gentle"
+	// BreakKindRecovery is the double-length break after a long session
+	BreakKindRecovery BreakKind = "recovery"
+	// BreakKindSessionLimit is the longer break after MaxSession of
+	// continuous work
+	BreakKindSessionLimit BreakKind = "session_limit"
+)
+
+// PostponeMode controls how postponed breaks count towards compliance
+type PostponeMode string
+
+const (
+	// PostponeNeutral leaves postponed breaks out of the compliance rate
+	PostponeNeutral PostponeMode = "neutral"
+	// PostponePartial counts a postponed break as half completed
+	PostponePartial PostponeMode = "partial"
+	// PostponeSkip counts a postponed break like a skipped one
+	PostponeSkip PostponeMode = "skip"
+)
+
+// ComplianceMode selects which breaks a compliance rate is based on
+type ComplianceMode string
+
+const (
+	// ComplianceOfRequired divides the completed breaks by all required breaks
+	ComplianceOfRequired ComplianceMode = "of_required"
+	// ComplianceOfDecided divides the completed breaks by the completed and
+	// skipped ones, ignoring postponed and abandoned breaks
+	ComplianceOfDecided ComplianceMode = "of_decided"
+)
+
+// postponePartialWeight is how much a postponed break counts in PostponePartial mode
+const postponePartialWeight = 0.5
+
+// Bounds of the eye comfort rating asked for after breaks
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// BreakOutcome describes how a break ended
+type BreakOutcome string
+
+const (
+	// OutcomePending is a break that hasn't ended yet
+	OutcomePending BreakOutcome = "pending"
+	// OutcomeCompleted is a break that was taken
+	OutcomeCompleted BreakOutcome = "completed"
+	// OutcomeSkipped is a break the user skipped
+	OutcomeSkipped BreakOutcome = "skipped"
+	// OutcomePostponed is a break moved to a later time
+	OutcomePostponed BreakOutcome = "postponed"
+	// OutcomeAbandoned is a break that never ended, e.g. because the app quit
+	OutcomeAbandoned BreakOutcome = "abandoned"
+	// OutcomeIdle is a break that became due while the user was away. It
+	// isn't shown and doesn't count towards compliance.
+	OutcomeIdle BreakOutcome = "idle"
+	// OutcomeSuppressed is a break that became due in silent mode. It isn't
+	// shown and doesn't count towards compliance, but is listed in the stats.
+	OutcomeSuppressed BreakOutcome = "suppressed"
+)
 
 // Break represents a single break session
 type Break struct {
-	ID           int64     `json:"id"`
-	StartedAt    time.Time `json:"started_at"`
-	CompletedAt  *time.Time `json:"completed_at,omitempty"`
-	WasCompleted bool      `json:"was_completed"`
-	WasSkipped   bool      `json:"was_skipped"`
-	DurationSecs int       `json:"duration_seconds"`
+	ID           int64        `json:"id"`
+	StartedAt    time.Time    `json:"started_at"`
+	CompletedAt  *time.Time   `json:"completed_at,omitempty"`
+	Outcome      BreakOutcome `json:"outcome"`
+	DurationSecs int          `json:"duration_seconds"`
+	Kind         BreakKind    `json:"kind"`
 }
 
 // DailyStats holds aggregated statistics for a single day
@@ -22,6 +96,17 @@ type DailyStats struct {
 	ComplianceRate   float64   `json:"compliance_rate"`
 }
 
+// MonthlyStats holds aggregated statistics for a single month, summed up
+// from its days
+type MonthlyStats struct {
+	Month           time.Time `json:"month"`
+	BreaksRequired  int       `json:"breaks_required"`
+	BreaksCompleted int       `json:"breaks_completed"`
+	BreaksSkipped   int       `json:"breaks_skipped"`
+	BreaksPostponed int       `json:"breaks_postponed"`
+	ComplianceRate  float64   `json:"compliance_rate"`
+}
+
 // Session represents a working session (from app start to stop)
 type Session struct {
 	ID                 int64      `json:"id"`
@@ -30,14 +115,66 @@ type Session struct {
 	PausedDurationSecs int        `json:"paused_duration_seconds"`
 }
 
+// SkipReason is why the user skipped a break
+type SkipReason string
+
+const (
+	// SkipReasonMeeting is a break skipped because of a meeting
+	SkipReasonMeeting SkipReason = "meeting"
+	// SkipReasonDeadline is a break skipped because of a deadline
+	SkipReasonDeadline SkipReason = "deadline"
+	// SkipReasonJustBecause is a break skipped for no particular reason
+	SkipReasonJustBecause SkipReason = "just_because"
+)
+
+// SkipReasons lists the known skip reasons in the order they are offered
+var SkipReasons = []SkipReason{SkipReasonMeeting, SkipReasonDeadline, SkipReasonJustBecause}
+
+// Valid reports whether r is one of the known skip reasons
+func (r SkipReason) Valid() bool {
+	return slices.Contains(SkipReasons, r)
+}
+
+// MostCommonSkipReason returns the reason given most often in breakdown
+// and how often. Ties go to the reason offered first. ok is false if no
+// reason was given.
+func MostCommonSkipReason(breakdown map[SkipReason]int) (reason SkipReason, count int, ok bool) {
+	for _, r := range SkipReasons {
+		if breakdown[r] > count {
+			reason, count = r, breakdown[r]
+		}
+	}
+	return reason, count, count > 0
+}
+
+// Period is the stretch of time a compliance report covers, ending now
+type Period string
+
+const (
+	// PeriodToday covers today since midnight
+	PeriodToday Period = "today"
+	// PeriodWeek covers the last 7 days
+	PeriodWeek Period = "week"
+	// PeriodMonth covers the last month
+	PeriodMonth Period = "month"
+	// PeriodYear covers the last year
+	PeriodYear Period = "year"
+	// PeriodAllTime covers every recorded break
+	PeriodAllTime Period = "all_time"
+)
+
 // ComplianceReport provides compliance statistics for a period
 type ComplianceReport struct {
-	Period          string  `json:"period"`           // "today", "week", "month"
-	TotalBreaks     int     `json:"total_breaks"`
-	CompletedBreaks int     `json:"completed_breaks"`
-	SkippedBreaks   int     `json:"skipped_breaks"`
-	ComplianceRate  float64 `json:"compliance_rate"`
-	AveragePerDay   float64 `json:"average_per_day"`
+	Period          string         `json:"period"` // A Period, "calendar_week" or "session"
+	Mode            ComplianceMode `json:"mode"`
+	TotalBreaks     int            `json:"total_breaks"`
+	CompletedBreaks int            `json:"completed_breaks"`
+	SkippedBreaks   int            `json:"skipped_breaks"`
+	PostponedBreaks int            `json:"postponed_breaks"`
+	ManualBreaks    int            `json:"manual_breaks"` // Taken outside the schedule, not part of TotalBreaks
+	SilentBreaks    int            `json:"silent_breaks"` // Due in silent mode, not part of TotalBreaks
+	ComplianceRate  float64        `json:"compliance_rate"`
+	AveragePerDay   float64        `json:"average_per_day"`
 }
 
 // CalculateComplianceRate calculates the compliance rate as a percentage
@@ -47,3 +184,189 @@ func CalculateComplianceRate(completed, total int) float64 {
 	}
 	return float64(completed) / float64(total) * 100.0
 }
+
+// WeightedComplianceRate calculates the compliance rate as a percentage,
+// weighting the postponed breaks among total according to mode
+func WeightedComplianceRate(completed, postponed, total int, mode PostponeMode) float64 {
+	switch mode {
+	case PostponePartial:
+		if total == 0 {
+			return 0.0
+		}
+		weighted := float64(completed) + postponePartialWeight*float64(postponed)
+		return weighted / float64(total) * 100.0
+	case PostponeSkip:
+		return CalculateComplianceRate(completed, total)
+	default:
+		return CalculateComplianceRate(completed, total-postponed)
+	}
+}
+
+// CurrentStreak counts the consecutive days, most recent first, whose
+// compliance rate reached threshold. days must be ordered newest first and
+// only contain days with breaks. Today doesn't break the streak while it is
+// still below the threshold, since breaks may still be completed. Up to
+// freezes other days below the threshold are forgiven; they don't count
+// towards the streak but don't end it either. The second result is how many
+// freezes the streak consumed, which only counts freezes followed by an
+// older day reaching the threshold.
+func CurrentStreak(days []DailyStats, threshold float64, today time.Time, freezes int) (int, int) {
+	todayStr := today.Format("2006-01-02")
+
+	streak, used, pending := 0, 0, 0
+	for _, day := range days {
+		if day.ComplianceRate >= threshold {
+			streak++
+			used += pending
+			pending = 0
+			continue
+		}
+		if day.Date.Format("2006-01-02") == todayStr {
+			continue
+		}
+		if used+pending < freezes {
+			pending++
+			continue
+		}
+		break
+	}
+
+	return streak, used
+}
+
+// AverageCompliance returns the mean of the daily compliance rates, or 0
+// without any days
+func AverageCompliance(rates []float64) float64 {
+	if len(rates) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, rate := range rates {
+		sum += rate
+	}
+	return sum / float64(len(rates))
+}
+
+// startOfWeek returns local midnight of the most recent weekStart day on or
+// before now
+func startOfWeek(now time.Time, weekStart time.Weekday) time.Time {
+	daysSince := (int(now.Weekday()) - int(weekStart) + 7) % 7
+	return time.Date(now.Year(), now.Month(), now.Day()-daysSince, 0, 0, 0, 0, now.Location())
+}
+
+// ProjectGoal calculates how many more breaks must be completed today to
+// reach goal (a percentage) given the breaks so far and the number of breaks
+// still expected today. onTrack is false if the goal can no longer be reached
+// even by completing every remaining break.
+func ProjectGoal(goal float64, completed, required, expectedRemaining int) (needed int, onTrack bool) {
+	if expectedRemaining < 0 {
+		expectedRemaining = 0
+	}
+
+	total := required + expectedRemaining
+	if total == 0 {
+		return 0, true
+	}
+
+	target := int(math.Ceil(goal / 100.0 * float64(total)))
+	needed = target - completed
+	if needed < 0 {
+		needed = 0
+	}
+
+	return needed, needed <= expectedRemaining
+}
+
+// LongestGap returns the longest interval between from and to without a
+// completed break. Gaps run from the end of one completed break to the start
+// of the next, plus the stretches from from to the first break and from the
+// last break to to. breaks may be in any order; incomplete breaks are ignored.
+func LongestGap(breaks []Break, from, to time.Time) time.Duration {
+	var completed []Break
+	for _, b := range breaks {
+		if b.Outcome == OutcomeCompleted {
+			completed = append(completed, b)
+		}
+	}
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].StartedAt.Before(completed[j].StartedAt)
+	})
+
+	var longest time.Duration
+	gapStart := from
+	for _, b := range completed {
+		if gap := b.StartedAt.Sub(gapStart); gap > longest {
+			longest = gap
+		}
+		gapStart = b.StartedAt
+		if b.CompletedAt != nil {
+			gapStart = *b.CompletedAt
+		}
+	}
+	if gap := to.Sub(gapStart); gap > longest {
+		longest = gap
+	}
+
+	return longest
+}
+
+// BestRun returns the longest run of consecutive completed breaks. Skipped
+// and abandoned breaks end a run; postponed, pending and unshown breaks
+// neither extend nor end it. breaks may be in any order.
+func BestRun(breaks []Break) int {
+	sorted := slices.Clone(breaks)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartedAt.Before(sorted[j].StartedAt)
+	})
+
+	best, run := 0, 0
+	for _, b := range sorted {
+		switch b.Outcome {
+		case OutcomeCompleted:
+			run++
+			best = max(best, run)
+		case OutcomeSkipped, OutcomeAbandoned:
+			run = 0
+		}
+	}
+	return best
+}
+
+// Weights of the health score components, summing to 100 points
+const (
+	healthComplianceWeight = 60.0
+	healthDurationWeight   = 25.0
+	healthStreakWeight     = 15.0
+	// healthStreakDays is the streak length that earns the full streak points
+	healthStreakDays = 7
+)
+
+// ComputeHealthScore blends break habits into a single score from 0 to 100:
+//
+//   - up to 60 points for the compliance rate of report
+//   - up to 25 points for how close avgDur, the average duration of the
+//     completed breaks, comes to targetDur; longer breaks earn no extra
+//   - up to 15 points for the streak, reaching the maximum at 7 days
+//
+// A nil report or one without breaks scores 0. A non-positive targetDur
+// awards the full duration points.
+func ComputeHealthScore(report *ComplianceReport, avgDur time.Duration, targetDur time.Duration, streak int) int {
+	if report == nil || report.TotalBreaks == 0 {
+		return 0
+	}
+
+	compliance := math.Min(math.Max(report.ComplianceRate/100.0, 0), 1)
+
+	adequacy := 1.0
+	if targetDur > 0 {
+		adequacy = math.Min(math.Max(float64(avgDur)/float64(targetDur), 0), 1)
+	}
+
+	streakShare := math.Min(math.Max(float64(streak)/healthStreakDays, 0), 1)
+
+	score := healthComplianceWeight*compliance +
+		healthDurationWeight*adequacy +
+		healthStreakWeight*streakShare
+
+	return int(math.Round(score))
+}
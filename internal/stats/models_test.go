@@ -0,0 +1,180 @@
+package stats
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestProjectGoal(t *testing.T) {
+	tests := []struct {
+		name              string
+		goal              float64
+		completed         int
+		required          int
+		expectedRemaining int
+		wantNeeded        int
+		wantOnTrack       bool
+	}{
+		{"nothing to do", 80, 0, 0, 0, 0, true},
+		{"on track", 80, 4, 5, 5, 4, true},
+		{"needs every remaining break", 80, 3, 5, 5, 5, true},
+		{"goal reached", 80, 9, 10, 0, 0, true},
+		{"out of reach", 80, 2, 8, 3, 7, false},
+		{"target rounded up", 75, 0, 0, 2, 2, true},
+		{"negative remaining counts as none", 50, 1, 4, -3, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			needed, onTrack := ProjectGoal(tt.goal, tt.completed, tt.required, tt.expectedRemaining)
+			if needed != tt.wantNeeded || onTrack != tt.wantOnTrack {
+				t.Errorf("ProjectGoal() = %d, %t, want %d, %t", needed, onTrack, tt.wantNeeded, tt.wantOnTrack)
+			}
+		})
+	}
+}
+
+// breakAt returns a 20 second break with outcome that started at startedAt.
+// Only completed breaks have a completion time.
+func breakAt(startedAt time.Time, outcome BreakOutcome) Break {
+	b := Break{StartedAt: startedAt, Outcome: outcome, DurationSecs: 20}
+	if outcome == OutcomeCompleted {
+		completedAt := startedAt.Add(20 * time.Second)
+		b.CompletedAt = &completedAt
+	}
+	return b
+}
+
+func TestLongestGap(t *testing.T) {
+	from := time.Date(2025, time.June, 4, 9, 0, 0, 0, time.Local)
+	to := from.Add(8 * time.Hour)
+	at := func(hour int) time.Time { return from.Add(time.Duration(hour-9) * time.Hour) }
+	unfinished := breakAt(at(12), OutcomeCompleted)
+	unfinished.CompletedAt = nil
+
+	tests := []struct {
+		name   string
+		breaks []Break
+		want   time.Duration
+	}{
+		{"no breaks", nil, 8 * time.Hour},
+		{"gap after the last break", []Break{breakAt(at(12), OutcomeCompleted)}, 5*time.Hour - 20*time.Second},
+		{"gap before the first break", []Break{breakAt(at(15), OutcomeCompleted)}, 6 * time.Hour},
+		{"gap between breaks", []Break{breakAt(at(10), OutcomeCompleted), breakAt(at(16), OutcomeCompleted)}, 6*time.Hour - 20*time.Second},
+		{"unordered breaks", []Break{breakAt(at(14), OutcomeCompleted), breakAt(at(10), OutcomeCompleted)}, 4*time.Hour - 20*time.Second},
+		{"incomplete breaks ignored", []Break{breakAt(at(10), OutcomeCompleted), breakAt(at(13), OutcomeSkipped), breakAt(at(14), OutcomePostponed)}, 7*time.Hour - 20*time.Second},
+		{"completed without completion time", []Break{unfinished}, 5 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LongestGap(tt.breaks, from, to); got != tt.want {
+				t.Errorf("LongestGap() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestRun(t *testing.T) {
+	start := time.Date(2025, time.June, 4, 9, 0, 0, 0, time.Local)
+	// run returns breaks 20 minutes apart with the given outcomes
+	run := func(outcomes ...BreakOutcome) []Break {
+		breaks := make([]Break, len(outcomes))
+		for i, outcome := range outcomes {
+			breaks[i] = breakAt(start.Add(time.Duration(i)*20*time.Minute), outcome)
+		}
+		return breaks
+	}
+	reversed := run(OutcomeCompleted, OutcomeSkipped, OutcomeCompleted, OutcomeCompleted, OutcomeCompleted)
+	slices.Reverse(reversed)
+
+	tests := []struct {
+		name   string
+		breaks []Break
+		want   int
+	}{
+		{"no breaks", nil, 0},
+		{"all completed", run(OutcomeCompleted, OutcomeCompleted, OutcomeCompleted), 3},
+		{"skipped ends a run", run(OutcomeCompleted, OutcomeCompleted, OutcomeSkipped, OutcomeCompleted), 2},
+		{"abandoned ends a run", run(OutcomeCompleted, OutcomeAbandoned, OutcomeCompleted, OutcomeCompleted), 2},
+		{"postponed and unshown breaks don't count", run(OutcomeCompleted, OutcomePostponed, OutcomeIdle, OutcomeSuppressed, OutcomePending, OutcomeCompleted), 2},
+		{"nothing completed", run(OutcomeSkipped, OutcomePostponed), 0},
+		{"unordered breaks", reversed, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BestRun(tt.breaks); got != tt.want {
+				t.Errorf("BestRun() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrentStreak(t *testing.T) {
+	today := dayAt(2025, time.June, 10)
+	// days returns daily stats with the given rates, starting today and
+	// going back a day each
+	days := func(rates ...float64) []DailyStats {
+		stats := make([]DailyStats, len(rates))
+		for i, rate := range rates {
+			stats[i] = DailyStats{Date: today.AddDate(0, 0, -i), ComplianceRate: rate}
+		}
+		return stats
+	}
+
+	tests := []struct {
+		name       string
+		days       []DailyStats
+		freezes    int
+		wantStreak int
+		wantUsed   int
+	}{
+		{"no days", nil, 0, 0, 0},
+		{"every day reached", days(90, 85, 80), 0, 3, 0},
+		{"missed day ends the streak", days(90, 50, 90), 0, 1, 0},
+		{"today may still catch up", days(30, 90, 90), 0, 2, 0},
+		{"freeze bridges a missed day", days(90, 50, 90), 1, 2, 1},
+		{"freezes run out", days(90, 50, 50, 90), 1, 1, 0},
+		{"two freezes bridge two days", days(90, 50, 50, 90), 2, 2, 2},
+		{"trailing freeze isn't consumed", days(90, 50), 1, 1, 0},
+		{"today doesn't take a freeze", days(30, 50, 90), 1, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streak, used := CurrentStreak(tt.days, 80, today, tt.freezes)
+			if streak != tt.wantStreak || used != tt.wantUsed {
+				t.Errorf("CurrentStreak() = %d, %d, want %d, %d", streak, used, tt.wantStreak, tt.wantUsed)
+			}
+		})
+	}
+}
+
+func TestWeightedComplianceRate(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed int
+		postponed int
+		total     int
+		mode      PostponeMode
+		want      float64
+	}{
+		{"neutral leaves postponed out", 6, 2, 10, PostponeNeutral, 75},
+		{"unknown mode is neutral", 6, 2, 10, "", 75},
+		{"partial counts postponed half", 6, 2, 10, PostponePartial, 70},
+		{"skip counts postponed as missed", 6, 2, 10, PostponeSkip, 60},
+		{"neutral with only postponed", 0, 4, 4, PostponeNeutral, 0},
+		{"partial with only postponed", 0, 4, 4, PostponePartial, 50},
+		{"no breaks", 0, 0, 0, PostponePartial, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeightedComplianceRate(tt.completed, tt.postponed, tt.total, tt.mode); got != tt.want {
+				t.Errorf("WeightedComplianceRate(%d, %d, %d, %q) = %v, want %v", tt.completed, tt.postponed, tt.total, tt.mode, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package stats
+
+import "time"
+
+// GetMonthlyStats returns the aggregated statistics of every month of year
+// with breaks, oldest first. The aggregates are kept alongside the daily
+// stats, so months whose breaks were pruned are still included.
+func (s *Store) GetMonthlyStats(year int) ([]MonthlyStats, error) {
+	rows, err := s.db.Query(
+		`SELECT month, breaks_required, breaks_completed, breaks_skipped, breaks_postponed
+		 FROM monthly_stats
+		 WHERE breaks_required > 0 AND month >= ? AND month < ?
+		 ORDER BY month`,
+		time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local).Format("2006-01"),
+		time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.Local).Format("2006-01"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var months []MonthlyStats
+	for rows.Next() {
+		var month string
+		var counts breakCounts
+		if err := rows.Scan(&month, &counts.total, &counts.completed, &counts.skipped, &counts.postponed); err != nil {
+			return nil, err
+		}
+		start, err := time.ParseInLocation("2006-01", month, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		months = append(months, MonthlyStats{
+			Month:           start,
+			BreaksRequired:  counts.total,
+			BreaksCompleted: counts.completed,
+			BreaksSkipped:   counts.skipped,
+			BreaksPostponed: counts.postponed,
+			ComplianceRate:  s.complianceRate(counts),
+		})
+	}
+	return months, rows.Err()
+}
+
+// updateMonthlyStats recalculates the aggregate of the month containing
+// date as the sum of its daily stats
+func (s *Store) updateMonthlyStats(date time.Time) error {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	next := first.AddDate(0, 1, 0)
+
+	_, err := s.db.Exec(
+		`INSERT INTO monthly_stats (month, breaks_required, breaks_completed, breaks_skipped, breaks_postponed)
+		 SELECT ?,
+		        COALESCE(SUM(breaks_required), 0),
+		        COALESCE(SUM(breaks_completed), 0),
+		        COALESCE(SUM(breaks_skipped), 0),
+		        COALESCE(SUM(breaks_postponed), 0)
+		 FROM daily_stats
+		 WHERE date >= ? AND date < ?
+		 ON CONFLICT(month) DO UPDATE SET
+		   breaks_required = excluded.breaks_required,
+		   breaks_completed = excluded.breaks_completed,
+		   breaks_skipped = excluded.breaks_skipped,
+		   breaks_postponed = excluded.breaks_postponed`,
+		first.Format("2006-01"),
+		first.Format("2006-01-02"),
+		next.Format("2006-01-02"),
+	)
+	return err
+}
+
+// backfillMonthlyStats adds the aggregates of months that only have daily
+// stats, recorded before monthly stats were kept alongside them. Months
+// aggregated by earlier prunes are left as they are.
+func (s *Store) backfillMonthlyStats() error {
+	_, err := s.db.Exec(
+		`INSERT INTO monthly_stats (month, breaks_required, breaks_completed, breaks_skipped, breaks_postponed)
+		 SELECT substr(date, 1, 7),
+		        SUM(breaks_required),
+		        SUM(breaks_completed),
+		        SUM(breaks_skipped),
+		        SUM(breaks_postponed)
+		 FROM daily_stats
+		 WHERE date IS NOT NULL
+		 GROUP BY substr(date, 1, 7)
+		 ON CONFLICT(month) DO NOTHING`,
+	)
+	return err
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonthlyStatsSumTheirDays(t *testing.T) {
+	s := newTestStore(t)
+
+	days := []time.Time{
+		dayAt(2025, time.March, 3),
+		dayAt(2025, time.March, 17),
+		dayAt(2025, time.March, 31),
+		dayAt(2025, time.April, 1),
+	}
+	outcomes := []BreakOutcome{OutcomeCompleted, OutcomeSkipped, OutcomePostponed, OutcomeCompleted, OutcomeIdle}
+	for i, day := range days {
+		for _, outcome := range outcomes[:2+i] {
+			insertBreak(t, s, day, outcome)
+		}
+	}
+
+	months, err := s.GetMonthlyStats(2025)
+	if err != nil {
+		t.Fatalf("GetMonthlyStats: %v", err)
+	}
+	if len(months) != 2 {
+		t.Fatalf("got %d months, want 2", len(months))
+	}
+
+	for _, month := range months {
+		var want MonthlyStats
+		for _, day := range days {
+			if day.Month() != month.Month.Month() {
+				continue
+			}
+			daily, err := s.GetDailyStats(day)
+			if err != nil {
+				t.Fatalf("GetDailyStats: %v", err)
+			}
+			want.BreaksRequired += daily.BreaksRequired
+			want.BreaksCompleted += daily.BreaksCompleted
+			want.BreaksSkipped += daily.BreaksSkipped
+		}
+		if month.BreaksRequired != want.BreaksRequired ||
+			month.BreaksCompleted != want.BreaksCompleted ||
+			month.BreaksSkipped != want.BreaksSkipped {
+			t.Errorf("%s: got %+v, want the sum of its days %+v", month.Month.Format("2006-01"), month, want)
+		}
+	}
+
+	march, april := months[0], months[1]
+	if march.BreaksRequired != 9 || march.BreaksCompleted != 4 || march.BreaksSkipped != 3 || march.BreaksPostponed != 2 {
+		t.Errorf("March = %+v, want 9 required, 4 completed, 3 skipped, 2 postponed", march)
+	}
+	// The idle break doesn't count
+	if april.BreaksRequired != 4 {
+		t.Errorf("April has %d required breaks, want 4", april.BreaksRequired)
+	}
+}
+
+func TestGetMonthlyStatsOnlyReturnsYear(t *testing.T) {
+	s := newTestStore(t)
+	insertBreak(t, s, dayAt(2024, time.December, 31), OutcomeCompleted)
+	insertBreak(t, s, dayAt(2025, time.January, 1), OutcomeCompleted)
+	insertBreak(t, s, dayAt(2026, time.January, 1), OutcomeCompleted)
+
+	months, err := s.GetMonthlyStats(2025)
+	if err != nil {
+		t.Fatalf("GetMonthlyStats: %v", err)
+	}
+	if len(months) != 1 || months[0].Month.Format("2006-01") != "2025-01" {
+		t.Errorf("got %+v, want only 2025-01", months)
+	}
+}
+
+func TestRebuildKeepsStatsOfPrunedDays(t *testing.T) {
+	s := newTestStore(t)
+
+	now := time.Now()
+	old := now.AddDate(0, 0, -20)
+	recent := now.AddDate(0, 0, -1)
+	insertBreak(t, s, old, OutcomeCompleted)
+	insertBreak(t, s, old, OutcomeSkipped)
+	insertBreak(t, s, recent, OutcomeCompleted)
+
+	if _, err := s.PruneOlderThan(10); err != nil {
+		t.Fatalf("PruneOlderThan: %v", err)
+	}
+	rebuilt, err := s.RebuildDailyStats(now.AddDate(0, 0, -30), now)
+	if err != nil {
+		t.Fatalf("RebuildDailyStats: %v", err)
+	}
+	if rebuilt != 1 {
+		t.Errorf("rebuilt %d days, want only the day with breaks left", rebuilt)
+	}
+
+	daily, err := s.GetDailyStats(old)
+	if err != nil {
+		t.Fatalf("GetDailyStats: %v", err)
+	}
+	if daily.BreaksRequired != 2 || daily.BreaksCompleted != 1 || daily.BreaksSkipped != 1 {
+		t.Errorf("stats of pruned day = %+v, want 2 required, 1 completed, 1 skipped", daily)
+	}
+
+	months, err := s.GetMonthlyStats(old.Year())
+	if err != nil {
+		t.Fatalf("GetMonthlyStats: %v", err)
+	}
+	required := 0
+	for _, month := range months {
+		if month.Month.Month() == old.Month() {
+			required = month.BreaksRequired
+		}
+	}
+	if want := 2; old.Month() != recent.Month() && required != want {
+		t.Errorf("month of pruned day has %d required breaks, want %d", required, want)
+	} else if want := 3; old.Month() == recent.Month() && required != want {
+		t.Errorf("month of pruned day has %d required breaks, want %d", required, want)
+	}
+}
@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PauseKind tells why the timer was paused
+type PauseKind string
+
+const (
+	// PauseKindManual is a pause the user started from the menu or a hotkey
+	PauseKindManual PauseKind = "manual"
+	// PauseKindIdle is a pause started because the user was away
+	PauseKindIdle PauseKind = "idle"
+)
+
+// Pause represents a period in which the timer was paused
+type Pause struct {
+	ID        int64      `json:"id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"` // nil while the pause lasts
+	Kind      PauseKind  `json:"kind"`
+}
+
+// StartPause records the start of a pause and returns its ID
+func (s *Store) StartPause(kind PauseKind) (int64, error) {
+	result, err := s.db.Exec(
+		"INSERT INTO pauses (started_at, kind) VALUES (?, ?)",
+		time.Now(),
+		kind,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// EndPause marks a pause as ended
+func (s *Store) EndPause(pauseID int64) error {
+	_, err := s.db.Exec(
+		"UPDATE pauses SET ended_at = ? WHERE id = ? AND ended_at IS NULL",
+		time.Now(),
+		pauseID,
+	)
+	return err
+}
+
+// CloseOpenPauses ends pauses left open, e.g. because the app quit while
+// paused. Their real end is unknown, so they are closed at their start and
+// don't count towards the paused time. It must only be called while the
+// timer isn't paused and returns the number of pauses closed.
+func (s *Store) CloseOpenPauses() (int, error) {
+	result, err := s.db.Exec("UPDATE pauses SET ended_at = started_at WHERE ended_at IS NULL")
+	if err != nil {
+		return 0, err
+	}
+	closed, err := result.RowsAffected()
+	return int(closed), err
+}
+
+// GetPausedTimeToday returns how long the timer was paused today, manually
+// or while idle. A pause that is still running counts up to now.
+func (s *Store) GetPausedTimeToday() (time.Duration, error) {
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	rows, err := s.db.Query(
+		`SELECT id, started_at, ended_at, kind
+		 FROM pauses
+		 WHERE started_at < ? AND (ended_at IS NULL OR ended_at > ?)`,
+		now,
+		startOfDay,
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var pauses []Pause
+	for rows.Next() {
+		var p Pause
+		var endedAt sql.NullTime
+		if err := rows.Scan(&p.ID, &p.StartedAt, &endedAt, &p.Kind); err != nil {
+			return 0, err
+		}
+		if endedAt.Valid {
+			p.EndedAt = &endedAt.Time
+		}
+		pauses = append(pauses, p)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	return PausedWithin(pauses, startOfDay, now), nil
+}
+
+// PausedWithin sums the parts of pauses that fall into [from, to). Pauses
+// without an end last until to.
+func PausedWithin(pauses []Pause, from, to time.Time) time.Duration {
+	var total time.Duration
+	for _, p := range pauses {
+		start := p.StartedAt
+		end := to
+		if p.EndedAt != nil && p.EndedAt.Before(to) {
+			end = *p.EndedAt
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPausedWithin(t *testing.T) {
+	from := time.Date(2025, time.June, 4, 0, 0, 0, 0, time.Local)
+	to := from.Add(12 * time.Hour)
+	pause := func(start, end time.Duration) Pause {
+		endedAt := from.Add(end)
+		return Pause{StartedAt: from.Add(start), EndedAt: &endedAt, Kind: PauseKindManual}
+	}
+	open := Pause{StartedAt: to.Add(-time.Hour), Kind: PauseKindIdle}
+
+	tests := []struct {
+		name   string
+		pauses []Pause
+		want   time.Duration
+	}{
+		{"no pauses", nil, 0},
+		{"within the range", []Pause{pause(time.Hour, 90*time.Minute), pause(3*time.Hour, 4*time.Hour)}, 90 * time.Minute},
+		{"started before the range", []Pause{pause(-time.Hour, 15*time.Minute)}, 15 * time.Minute},
+		{"ended after the range", []Pause{pause(11*time.Hour, 13*time.Hour)}, time.Hour},
+		{"before the range", []Pause{pause(-2*time.Hour, -time.Hour)}, 0},
+		{"still running", []Pause{open}, time.Hour},
+		{"closed at its start", []Pause{pause(time.Hour, time.Hour)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PausedWithin(tt.pauses, from, to); got != tt.want {
+				t.Errorf("PausedWithin() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPausedTimeToday(t *testing.T) {
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if now.Sub(startOfDay) < 30*time.Minute {
+		t.Skip("too close to midnight for today's pauses")
+	}
+
+	s := newTestStore(t)
+	insert := func(startedAt time.Time, endedAt interface{}, kind PauseKind) {
+		if _, err := s.db.Exec("INSERT INTO pauses (started_at, ended_at, kind) VALUES (?, ?, ?)", startedAt, endedAt, kind); err != nil {
+			t.Fatalf("insert pause: %v", err)
+		}
+	}
+	// 10 minutes of a pause over midnight, nothing of yesterday's and a
+	// running idle pause of 5 minutes so far
+	insert(startOfDay.Add(-time.Hour), startOfDay.Add(10*time.Minute), PauseKindManual)
+	insert(startOfDay.Add(-3*time.Hour), startOfDay.Add(-2*time.Hour), PauseKindManual)
+	insert(now.Add(-5*time.Minute), nil, PauseKindIdle)
+
+	got, err := s.GetPausedTimeToday()
+	if err != nil {
+		t.Fatalf("GetPausedTimeToday: %v", err)
+	}
+	want := 15 * time.Minute
+	if got < want || got > want+time.Minute {
+		t.Errorf("GetPausedTimeToday() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+)
+
+// PruneOlderThan deletes breaks, ratings, sessions and pauses that started
+// more than days days before today. Daily stats and the monthly aggregates
+// summed up from them are kept, so long-term trends survive; they are
+// brought up to date from the breaks before those are deleted. It returns
+// the number of deleted breaks, sessions and pauses. days <= 0 keeps
+// everything.
+func (s *Store) PruneOlderThan(days int) (int, error) {
+	if days <= 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	cutoff := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -days)
+
+	// Once the breaks are gone the stats of their days can't be rebuilt
+	first, err := s.firstBreakStart()
+	if err != nil {
+		return 0, err
+	}
+	if first.Valid && first.Time.Before(cutoff) {
+		if _, err := s.RebuildDailyStats(first.Time.In(cutoff.Location()), cutoff.AddDate(0, 0, -1)); err != nil {
+			return 0, fmt.Errorf("failed to update stats before pruning: %w", err)
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(
+		`DELETE FROM break_ratings WHERE break_id IN (SELECT id FROM breaks WHERE started_at < ?)`,
+		cutoff,
+	); err != nil {
+		return 0, fmt.Errorf("failed to delete ratings: %w", err)
+	}
+
+	deleted := 0
+	for _, table := range []string{"breaks", "sessions", "pauses"} {
+		result, err := tx.Exec("DELETE FROM "+table+" WHERE started_at < ?", cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete %s: %w", table, err)
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		deleted += int(count)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
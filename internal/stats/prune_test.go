@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPruneOlderThan(t *testing.T) {
+	now := time.Now()
+	old := now.AddDate(0, 0, -40)
+	recent := now.AddDate(0, 0, -2)
+
+	tests := []struct {
+		name        string
+		days        int
+		wantDeleted int
+		wantBreaks  int
+		wantRatings int
+	}{
+		{"keeps everything without retention", 0, 0, 3, 1},
+		{"deletes what started before the cutoff", 30, 4, 1, 0},
+		{"keeps what is within the retention", 60, 0, 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestStore(t)
+			oldBreak := insertBreak(t, s, old, OutcomeCompleted)
+			insertBreak(t, s, old, OutcomeSkipped)
+			insertBreak(t, s, recent, OutcomeCompleted)
+			if err := s.RecordRating(oldBreak, 4); err != nil {
+				t.Fatalf("RecordRating: %v", err)
+			}
+			if _, err := s.db.Exec("INSERT INTO sessions (started_at) VALUES (?)", old); err != nil {
+				t.Fatalf("insert session: %v", err)
+			}
+			if _, err := s.db.Exec("INSERT INTO pauses (started_at, kind) VALUES (?, ?)", old, PauseKindManual); err != nil {
+				t.Fatalf("insert pause: %v", err)
+			}
+
+			deleted, err := s.PruneOlderThan(tt.days)
+			if err != nil {
+				t.Fatalf("PruneOlderThan: %v", err)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("deleted %d rows, want %d", deleted, tt.wantDeleted)
+			}
+
+			var breaks int
+			if err := s.db.QueryRow("SELECT COUNT(*) FROM breaks").Scan(&breaks); err != nil {
+				t.Fatalf("count breaks: %v", err)
+			}
+			if breaks != tt.wantBreaks {
+				t.Errorf("%d breaks left, want %d", breaks, tt.wantBreaks)
+			}
+
+			var ratings int
+			if err := s.db.QueryRow("SELECT COUNT(*) FROM break_ratings WHERE break_id = ?", oldBreak).Scan(&ratings); err != nil {
+				t.Fatalf("count ratings: %v", err)
+			}
+			if ratings != tt.wantRatings {
+				t.Errorf("%d ratings of the old break left, want %d", ratings, tt.wantRatings)
+			}
+		})
+	}
+}
+
+func TestPruneKeepsAggregates(t *testing.T) {
+	s := newTestStore(t)
+
+	old := time.Now().AddDate(0, 0, -40)
+	insertBreak(t, s, old, OutcomeCompleted)
+	insertBreak(t, s, old, OutcomeSkipped)
+	insertBreak(t, s, old, OutcomePostponed)
+
+	// Daily stats written before postponed breaks were counted per day
+	if _, err := s.db.Exec("UPDATE daily_stats SET breaks_postponed = 0"); err != nil {
+		t.Fatalf("reset postponed: %v", err)
+	}
+
+	if _, err := s.PruneOlderThan(30); err != nil {
+		t.Fatalf("PruneOlderThan: %v", err)
+	}
+
+	daily, err := s.GetDailyStats(old)
+	if err != nil {
+		t.Fatalf("GetDailyStats: %v", err)
+	}
+	if daily.BreaksRequired != 3 || daily.BreaksCompleted != 1 || daily.BreaksSkipped != 1 {
+		t.Errorf("daily stats = %+v, want 3 required, 1 completed, 1 skipped", daily)
+	}
+
+	months, err := s.GetMonthlyStats(old.Year())
+	if err != nil {
+		t.Fatalf("GetMonthlyStats: %v", err)
+	}
+	if len(months) != 1 {
+		t.Fatalf("got %d months, want 1", len(months))
+	}
+	if got := months[0]; got.BreaksRequired != 3 || got.BreaksPostponed != 1 {
+		t.Errorf("monthly stats = %+v, want 3 required, 1 postponed", got)
+	}
+}
@@ -0,0 +1,28 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetRestMinutesToday(t *testing.T) {
+	s := newTestStore(t)
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// 20 second breaks, only the completed ones of today count
+	for range 5 {
+		insertBreak(t, s, startOfDay.Add(time.Second), OutcomeCompleted)
+	}
+	insertBreak(t, s, startOfDay.Add(time.Second), OutcomeSkipped)
+	insertBreak(t, s, startOfDay.Add(-time.Hour), OutcomeCompleted)
+
+	got, err := s.GetRestMinutesToday()
+	if err != nil {
+		t.Fatalf("GetRestMinutesToday: %v", err)
+	}
+	// 100 seconds of rest make one whole minute
+	if got != 1 {
+		t.Errorf("GetRestMinutesToday() = %d, want 1", got)
+	}
+}
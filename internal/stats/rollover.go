@@ -0,0 +1,125 @@
+package stats
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// rolloverCheckInterval is how often the rollover checks for a new day
+const rolloverCheckInterval = 1 * time.Minute
+
+// DayRollover finalizes the daily stats of the previous day once the local
+// date changes. Daily stats are otherwise only updated when a break is
+// recorded, so a quiet evening would leave yesterday's row stale.
+type DayRollover struct {
+	store    *Store
+	now      func() time.Time
+	lastDay  string
+	ticker   *time.Ticker
+	stopChan chan struct{}
+	running  bool
+	onDayEnd func(day time.Time)
+	mu       sync.Mutex
+}
+
+// NewDayRollover creates a new rollover for the store
+func NewDayRollover(store *Store) *DayRollover {
+	return &DayRollover{
+		store:    store,
+		now:      time.Now,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetOnDayEnd sets the callback for when a day the rollover saw has ended
+// and its daily stats are final. It is called with the rollover's lock
+// held, so it must not call back into the rollover.
+func (r *DayRollover) SetOnDayEnd(callback func(day time.Time)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onDayEnd = callback
+}
+
+// Start checks for a new day now and then periodically
+func (r *DayRollover) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running {
+		return
+	}
+
+	r.running = true
+	r.ticker = time.NewTicker(rolloverCheckInterval)
+	ticker := r.ticker
+
+	go func() {
+		r.logCheck()
+		for {
+			select {
+			case <-ticker.C:
+				r.logCheck()
+			case <-r.stopChan:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic checks
+func (r *DayRollover) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.running {
+		return
+	}
+
+	r.running = false
+	close(r.stopChan)
+
+	if r.ticker != nil {
+		r.ticker.Stop()
+		r.ticker = nil
+	}
+}
+
+// Check finalizes the previous day if the date changed since the last check
+// and makes sure a row exists for the current day
+func (r *DayRollover) Check() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	today := now.Format("2006-01-02")
+	if today == r.lastDay {
+		return nil
+	}
+
+	if r.lastDay != "" {
+		previous, err := time.ParseInLocation("2006-01-02", r.lastDay, now.Location())
+		if err == nil {
+			if err := r.store.updateDailyStats(previous); err != nil {
+				return err
+			}
+			if r.onDayEnd != nil {
+				r.onDayEnd(previous)
+			}
+		}
+	}
+
+	if err := r.store.updateDailyStats(now); err != nil {
+		return err
+	}
+
+	r.lastDay = today
+	return nil
+}
+
+// logCheck runs Check and logs failures
+func (r *DayRollover) logCheck() {
+	if err := r.Check(); err != nil {
+		log.Printf("Warning: failed to roll over daily stats: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayRolloverCheck(t *testing.T) {
+	s := newTestStore(t)
+	now := time.Date(2025, time.June, 4, 22, 0, 0, 0, time.Local)
+	r := NewDayRollover(s)
+	r.now = func() time.Time { return now }
+	var ended []time.Time
+	r.SetOnDayEnd(func(day time.Time) { ended = append(ended, day) })
+
+	check := func() {
+		t.Helper()
+		if err := r.Check(); err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+	}
+	hasRow := func(date string) bool {
+		t.Helper()
+		var n int
+		if err := s.db.QueryRow("SELECT COUNT(*) FROM daily_stats WHERE date = ?", date).Scan(&n); err != nil {
+			t.Fatalf("count daily stats: %v", err)
+		}
+		return n == 1
+	}
+
+	check()
+	if !hasRow("2025-06-04") {
+		t.Error("no row for the first day")
+	}
+	if len(ended) != 0 {
+		t.Errorf("day ended at the first check: %v", ended)
+	}
+
+	// A break the daily stats haven't seen yet, like one left by a crash
+	if _, err := s.db.Exec(
+		"INSERT INTO breaks (started_at, kind, outcome) VALUES (?, ?, ?)",
+		now.Add(time.Hour), BreakKindRegular, OutcomeSkipped,
+	); err != nil {
+		t.Fatalf("insert break: %v", err)
+	}
+	now = now.Add(90 * time.Minute)
+	check()
+	if len(ended) != 0 {
+		t.Errorf("day ended before midnight: %v", ended)
+	}
+
+	// After midnight the previous day is final and reported once
+	now = now.Add(time.Hour)
+	check()
+	check()
+	if len(ended) != 1 || ended[0].Format("2006-01-02") != "2025-06-04" {
+		t.Fatalf("days ended = %v, want 2025-06-04 once", ended)
+	}
+	if !hasRow("2025-06-05") {
+		t.Error("no row for the new day")
+	}
+
+	daily, err := s.GetDailyStats(ended[0])
+	if err != nil {
+		t.Fatalf("GetDailyStats: %v", err)
+	}
+	if daily.BreaksRequired != 1 || daily.BreaksSkipped != 1 {
+		t.Errorf("final stats of the previous day = %+v, want the late break", daily)
+	}
+}
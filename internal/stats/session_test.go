@@ -0,0 +1,80 @@
+package stats
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// insertSession records a session from startedAt to endedAt, or a running
+// one if endedAt is zero
+func insertSession(t *testing.T, s *Store, startedAt, endedAt time.Time) int64 {
+	t.Helper()
+	var ended interface{}
+	if !endedAt.IsZero() {
+		ended = endedAt
+	}
+	result, err := s.db.Exec("INSERT INTO sessions (started_at, ended_at) VALUES (?, ?)", startedAt, ended)
+	if err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatalf("LastInsertId: %v", err)
+	}
+	return id
+}
+
+func TestGetSessionCompliance(t *testing.T) {
+	s := newTestStore(t)
+	start := time.Date(2025, time.June, 4, 9, 0, 0, 0, time.Local)
+	session := insertSession(t, s, start, start.Add(3*time.Hour))
+
+	// Only the breaks started within the session count
+	insertBreak(t, s, start.Add(-30*time.Minute), OutcomeSkipped)
+	insertBreak(t, s, start.Add(20*time.Minute), OutcomeCompleted)
+	insertBreak(t, s, start.Add(40*time.Minute), OutcomeCompleted)
+	insertBreak(t, s, start.Add(time.Hour), OutcomeSkipped)
+	insertBreak(t, s, start.Add(2*time.Hour), OutcomeCompleted)
+	insertBreak(t, s, start.Add(3*time.Hour), OutcomeSkipped)
+
+	report, err := s.GetSessionCompliance(session)
+	if err != nil {
+		t.Fatalf("GetSessionCompliance: %v", err)
+	}
+	if report.TotalBreaks != 4 || report.CompletedBreaks != 3 || report.SkippedBreaks != 1 {
+		t.Errorf("report counts %d breaks, %d completed, %d skipped, want 4, 3, 1",
+			report.TotalBreaks, report.CompletedBreaks, report.SkippedBreaks)
+	}
+	if report.ComplianceRate != 75 {
+		t.Errorf("ComplianceRate = %v, want 75", report.ComplianceRate)
+	}
+	// A session shorter than a day counts as one
+	if report.AveragePerDay != 3 {
+		t.Errorf("AveragePerDay = %v, want 3", report.AveragePerDay)
+	}
+}
+
+func TestGetSessionComplianceRunningSession(t *testing.T) {
+	s := newTestStore(t)
+	now := time.Now()
+	session := insertSession(t, s, now.Add(-time.Hour), time.Time{})
+	insertBreak(t, s, now.Add(-2*time.Hour), OutcomeSkipped)
+	insertBreak(t, s, now.Add(-30*time.Minute), OutcomeCompleted)
+
+	report, err := s.GetSessionCompliance(session)
+	if err != nil {
+		t.Fatalf("GetSessionCompliance: %v", err)
+	}
+	if report.TotalBreaks != 1 || report.CompletedBreaks != 1 {
+		t.Errorf("report counts %d breaks, %d completed, want the one of the running session",
+			report.TotalBreaks, report.CompletedBreaks)
+	}
+}
+
+func TestGetSessionComplianceUnknownSession(t *testing.T) {
+	s := newTestStore(t)
+	if _, err := s.GetSessionCompliance(42); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("GetSessionCompliance() error = %v, want %v", err, ErrSessionNotFound)
+	}
+}
@@ -3,8 +3,10 @@ package stats
 import (
 	"database/sql"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -13,11 +15,19 @@ import (
 const (
 	appName    = "2020Rule"
 	dbFileName = "stats.db"
+	// dataDirEnv overrides the data directory, e.g. for portable installs
+	dataDirEnv = "TWENTY_RULE_DATA_DIR"
 )
 
 // Store manages persistence of statistics using SQLite
 type Store struct {
-	db *sql.DB
+	db           *sql.DB
+	postponeMode PostponeMode
+	weekStart    time.Weekday
+	freezes      int
+	breakTarget  time.Duration // Break duration the health score expects
+	streakGoal   float64       // Daily compliance that extends the streak, in percent
+	mu           sync.Mutex
 }
 
 // NewStore creates a new statistics store
@@ -39,7 +49,7 @@ func NewStore() (*Store, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	store := &Store{db: db}
+	store := &Store{db: db, postponeMode: PostponeNeutral, weekStart: time.Monday}
 
 	// Initialize schema
 	if err := store.initSchema(); err != nil {
@@ -47,6 +57,11 @@ func NewStore() (*Store, error) {
 		return nil, fmt.Errorf("failed to initialize schema: %w", err)
 	}
 
+	// Back up the database to protect against corruption
+	if err := store.Backup(dbDir, backupsToKeep); err != nil {
+		log.Printf("Warning: failed to back up database: %v", err)
+	}
+
 	return store, nil
 }
 
@@ -75,6 +90,7 @@ func (s *Store) initSchema() error {
 		breaks_required INTEGER DEFAULT 0,
 		breaks_completed INTEGER DEFAULT 0,
 		breaks_skipped INTEGER DEFAULT 0,
+		breaks_postponed INTEGER DEFAULT 0,
 		total_work_minutes INTEGER DEFAULT 0,
 		compliance_rate REAL
 	);
@@ -86,20 +102,222 @@ func (s *Store) initSchema() error {
 		paused_duration_seconds INTEGER DEFAULT 0
 	);
 
+	CREATE TABLE IF NOT EXISTS pauses (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		started_at TIMESTAMP NOT NULL,
+		ended_at TIMESTAMP,
+		kind TEXT NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS break_ratings (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		break_id INTEGER NOT NULL,
+		rating INTEGER NOT NULL,
+		rated_at TIMESTAMP NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS monthly_stats (
+		month TEXT PRIMARY KEY,
+		breaks_required INTEGER DEFAULT 0,
+		breaks_completed INTEGER DEFAULT 0,
+		breaks_skipped INTEGER DEFAULT 0,
+		breaks_postponed INTEGER DEFAULT 0
+	);
+
 	CREATE INDEX IF NOT EXISTS idx_breaks_started_at ON breaks(started_at);
+	CREATE INDEX IF NOT EXISTS idx_break_ratings_rated_at ON break_ratings(rated_at);
 	CREATE INDEX IF NOT EXISTS idx_daily_stats_date ON daily_stats(date);
 	CREATE INDEX IF NOT EXISTS idx_sessions_started_at ON sessions(started_at);
+	CREATE INDEX IF NOT EXISTS idx_pauses_started_at ON pauses(started_at);
 	`
 
-	_, err := s.db.Exec(schema)
+	if _, err := s.db.Exec(schema); err != nil {
+		return err
+	}
+
+	return s.migrate()
+}
+
+// migrate upgrades databases created by older versions of the app
+func (s *Store) migrate() error {
+	if err := s.addColumnIfMissing("breaks", "kind", "TEXT NOT NULL DEFAULT 'regular'"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("breaks", "was_postponed", "BOOLEAN DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("breaks", "outcome", "TEXT NOT NULL DEFAULT 'pending'"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("breaks", "was_manual", "BOOLEAN DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("breaks", "skip_reason", "TEXT"); err != nil {
+		return err
+	}
+	if err := s.addColumnIfMissing("daily_stats", "breaks_postponed", "INTEGER DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := s.backfillOutcomes(); err != nil {
+		return err
+	}
+	return s.backfillMonthlyStats()
+}
+
+// backfillOutcomes derives the outcome of breaks recorded before the outcome
+// column existed from the legacy was_completed, was_skipped and
+// was_postponed flags. A completed break wins over the other flags. Breaks
+// recorded since never set the flags, so running this again changes nothing.
+func (s *Store) backfillOutcomes() error {
+	_, err := s.db.Exec(
+		`UPDATE breaks SET outcome = CASE
+		   WHEN was_completed = 1 THEN 'completed'
+		   WHEN was_skipped = 1 THEN 'skipped'
+		   WHEN was_postponed = 1 THEN 'postponed'
+		 END
+		 WHERE outcome = 'pending'
+		   AND (was_completed = 1 OR was_skipped = 1 OR was_postponed = 1)`,
+	)
 	return err
 }
 
+// SetPostponeMode sets how postponed breaks count towards compliance
+func (s *Store) SetPostponeMode(mode PostponeMode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.postponeMode = mode
+}
+
+// SetWeekStart sets the first day of the week for calendar week reports
+func (s *Store) SetWeekStart(weekday time.Weekday) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.weekStart = weekday
+}
+
+// SetStreakFreezes sets how many days below the threshold a streak survives
+func (s *Store) SetStreakFreezes(freezes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.freezes = freezes
+}
+
+// SetHealthScoreGoals sets the break duration and the daily compliance goal
+// (a percentage) GetHealthScore judges the last week by
+func (s *Store) SetHealthScoreGoals(breakTarget time.Duration, streakGoal float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.breakTarget = breakTarget
+	s.streakGoal = streakGoal
+}
+
+// complianceRateFor calculates the compliance rate for counts in mode.
+// ComplianceOfRequired applies the configured postpone mode.
+func (s *Store) complianceRateFor(counts breakCounts, mode ComplianceMode) float64 {
+	if mode == ComplianceOfDecided {
+		return CalculateComplianceRate(counts.completed, counts.completed+counts.skipped)
+	}
+	return s.complianceRate(counts)
+}
+
+// complianceRate calculates the compliance rate for counts using the
+// configured postpone mode
+func (s *Store) complianceRate(counts breakCounts) float64 {
+	s.mu.Lock()
+	mode := s.postponeMode
+	s.mu.Unlock()
+
+	return WeightedComplianceRate(counts.completed, counts.postponed, counts.total, mode)
+}
+
+// addColumnIfMissing adds a column to a table unless it already exists
+func (s *Store) addColumnIfMissing(table, column, definition string) error {
+	columns, err := s.columnNames(table)
+	if err != nil {
+		return err
+	}
+	if columns[column] {
+		return nil
+	}
+
+	_, err = s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
+
+// columnNames returns the set of columns of a table, which is empty if the
+// table doesn't exist
+func (s *Store) columnNames(table string) (map[string]bool, error) {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   bool
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
+
 // RecordBreakStart records the start of a break
-func (s *Store) RecordBreakStart() (int64, error) {
+func (s *Store) RecordBreakStart(kind BreakKind) (int64, error) {
 	result, err := s.db.Exec(
-		"INSERT INTO breaks (started_at) VALUES (?)",
+		"INSERT INTO breaks (started_at, kind) VALUES (?, ?)",
 		time.Now(),
+		kind,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// RecordManualBreakStart records the start of a break the user asked for
+// outside the schedule. Manual breaks don't count towards compliance.
+func (s *Store) RecordManualBreakStart(kind BreakKind) (int64, error) {
+	result, err := s.db.Exec(
+		"INSERT INTO breaks (started_at, kind, was_manual) VALUES (?, ?, 1)",
+		time.Now(),
+		kind,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// RecordBreakIdle records a break that became due while the user was idle
+func (s *Store) RecordBreakIdle(kind BreakKind) (int64, error) {
+	return s.recordUnshownBreak(kind, OutcomeIdle)
+}
+
+// RecordBreakSuppressed records a break that became due in silent mode
+func (s *Store) RecordBreakSuppressed(kind BreakKind) (int64, error) {
+	return s.recordUnshownBreak(kind, OutcomeSuppressed)
+}
+
+// recordUnshownBreak records a break that ended with outcome as soon as it
+// became due, without being shown
+func (s *Store) recordUnshownBreak(kind BreakKind, outcome BreakOutcome) (int64, error) {
+	now := time.Now()
+	result, err := s.db.Exec(
+		"INSERT INTO breaks (started_at, completed_at, kind, outcome) VALUES (?, ?, ?, ?)",
+		now,
+		now,
+		kind,
+		outcome,
 	)
 	if err != nil {
 		return 0, err
@@ -111,8 +329,9 @@ func (s *Store) RecordBreakStart() (int64, error) {
 func (s *Store) RecordBreakComplete(breakID int64, duration time.Duration) error {
 	now := time.Now()
 	_, err := s.db.Exec(
-		"UPDATE breaks SET completed_at = ?, was_completed = 1, duration_seconds = ? WHERE id = ?",
+		"UPDATE breaks SET completed_at = ?, outcome = ?, duration_seconds = ? WHERE id = ?",
 		now,
+		OutcomeCompleted,
 		int(duration.Seconds()),
 		breakID,
 	)
@@ -128,8 +347,9 @@ func (s *Store) RecordBreakComplete(breakID int64, duration time.Duration) error
 func (s *Store) RecordBreakSkipped(breakID int64) error {
 	now := time.Now()
 	_, err := s.db.Exec(
-		"UPDATE breaks SET completed_at = ?, was_skipped = 1 WHERE id = ?",
+		"UPDATE breaks SET completed_at = ?, outcome = ? WHERE id = ?",
 		now,
+		OutcomeSkipped,
 		breakID,
 	)
 	if err != nil {
@@ -140,14 +360,178 @@ func (s *Store) RecordBreakSkipped(breakID int64) error {
 	return s.updateDailyStats(now)
 }
 
+// RecordSkipReason stores why the user skipped a break. Only skipped breaks
+// take a reason; a later reason replaces an earlier one.
+func (s *Store) RecordSkipReason(breakID int64, reason SkipReason) error {
+	if !reason.Valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidSkipReason, reason)
+	}
+
+	_, err := s.db.Exec(
+		"UPDATE breaks SET skip_reason = ? WHERE id = ? AND outcome = ?",
+		reason,
+		breakID,
+		OutcomeSkipped,
+	)
+	return err
+}
+
+// GetSkipReasonBreakdown counts the skipped breaks started in period by
+// the reason given for them. Skips without a reason are left out.
+func (s *Store) GetSkipReasonBreakdown(period Period) (map[SkipReason]int, error) {
+	now := time.Now()
+	from, err := s.periodStart(period, now)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(
+		`SELECT skip_reason, COUNT(*)
+		 FROM breaks
+		 WHERE outcome = ? AND skip_reason IS NOT NULL AND started_at >= ? AND started_at < ?
+		 GROUP BY skip_reason`,
+		OutcomeSkipped,
+		from,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	breakdown := make(map[SkipReason]int)
+	for rows.Next() {
+		var reason SkipReason
+		var count int
+		if err := rows.Scan(&reason, &count); err != nil {
+			return nil, err
+		}
+		breakdown[reason] = count
+	}
+
+	return breakdown, rows.Err()
+}
+
+// RecordBreakPostponed marks a break as postponed to a later time
+func (s *Store) RecordBreakPostponed(breakID int64) error {
+	now := time.Now()
+	_, err := s.db.Exec(
+		"UPDATE breaks SET completed_at = ?, outcome = ? WHERE id = ?",
+		now,
+		OutcomePostponed,
+		breakID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Update daily stats
+	return s.updateDailyStats(now)
+}
+
+// RecordBreakAbandoned marks a break that was cut off without a decision,
+// e.g. by the overlay's emergency exit
+func (s *Store) RecordBreakAbandoned(breakID int64) error {
+	now := time.Now()
+	_, err := s.db.Exec(
+		"UPDATE breaks SET completed_at = ?, outcome = ? WHERE id = ?",
+		now,
+		OutcomeAbandoned,
+		breakID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Update daily stats
+	return s.updateDailyStats(now)
+}
+
+// AbandonPendingBreaks marks breaks that never ended as abandoned, e.g.
+// because the app quit during the break. It must only be called while no
+// break is in progress and returns the number of breaks marked.
+func (s *Store) AbandonPendingBreaks() (int, error) {
+	result, err := s.db.Exec(
+		"UPDATE breaks SET outcome = ? WHERE outcome = ?",
+		OutcomeAbandoned,
+		OutcomePending,
+	)
+	if err != nil {
+		return 0, err
+	}
+	abandoned, err := result.RowsAffected()
+	return int(abandoned), err
+}
+
+// RecordRating stores the user's eye comfort rating (1-5) after a break
+func (s *Store) RecordRating(breakID int64, rating int) error {
+	if rating < MinRating || rating > MaxRating {
+		return fmt.Errorf("%w: %d", ErrInvalidRating, rating)
+	}
+	_, err := s.db.Exec(
+		"INSERT INTO break_ratings (break_id, rating, rated_at) VALUES (?, ?, ?)",
+		breakID,
+		rating,
+		time.Now(),
+	)
+	return err
+}
+
+// GetAverageRating returns the average eye comfort rating given in
+// [from, to) and how many ratings it is based on
+func (s *Store) GetAverageRating(from, to time.Time) (average float64, count int, err error) {
+	err = s.db.QueryRow(
+		`SELECT COALESCE(AVG(rating), 0), COUNT(*)
+		 FROM break_ratings
+		 WHERE rated_at >= ? AND rated_at < ?`,
+		from,
+		to,
+	).Scan(&average, &count)
+	return average, count, err
+}
+
+// GetDurationHistogram counts the breaks completed in [from, to) by
+// duration. Keys are the start of each bucket in seconds, so with 5 second
+// buckets a 12 second break counts towards key 10.
+func (s *Store) GetDurationHistogram(from, to time.Time, bucketSecs int) (map[int]int, error) {
+	if bucketSecs <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidBucketSize, bucketSecs)
+	}
+
+	rows, err := s.db.Query(
+		`SELECT (COALESCE(duration_seconds, 0) / ?) * ? AS bucket, COUNT(*)
+		 FROM breaks
+		 WHERE outcome = 'completed' AND started_at >= ? AND started_at < ?
+		 GROUP BY bucket`,
+		bucketSecs,
+		bucketSecs,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	histogram := make(map[int]int)
+	for rows.Next() {
+		var bucket, count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, err
+		}
+		histogram[bucket] = count
+	}
+
+	return histogram, rows.Err()
+}
+
 // GetBreaksByDate returns all breaks for a specific date
 func (s *Store) GetBreaksByDate(date time.Time) ([]Break, error) {
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	rows, err := s.db.Query(
-		`SELECT id, started_at, completed_at, was_completed, was_skipped,
-		        COALESCE(duration_seconds, 0)
+		`SELECT id, started_at, completed_at, outcome, COALESCE(duration_seconds, 0), kind
 		 FROM breaks
 		 WHERE started_at >= ? AND started_at < ?
 		 ORDER BY started_at DESC`,
@@ -163,7 +547,7 @@ func (s *Store) GetBreaksByDate(date time.Time) ([]Break, error) {
 	for rows.Next() {
 		var b Break
 		var completedAt sql.NullTime
-		err := rows.Scan(&b.ID, &b.StartedAt, &completedAt, &b.WasCompleted, &b.WasSkipped, &b.DurationSecs)
+		err := rows.Scan(&b.ID, &b.StartedAt, &completedAt, &b.Outcome, &b.DurationSecs, &b.Kind)
 		if err != nil {
 			return nil, err
 		}
@@ -176,6 +560,98 @@ func (s *Store) GetBreaksByDate(date time.Time) ([]Break, error) {
 	return breaks, rows.Err()
 }
 
+// GetRestMinutesToday returns the total duration of the breaks completed
+// today in whole minutes
+func (s *Store) GetRestMinutesToday() (int, error) {
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var seconds int
+	err := s.db.QueryRow(
+		`SELECT COALESCE(SUM(duration_seconds), 0)
+		 FROM breaks
+		 WHERE outcome = ? AND started_at >= ?`,
+		OutcomeCompleted,
+		startOfDay,
+	).Scan(&seconds)
+	return seconds / 60, err
+}
+
+// CountBreaksToday returns the number of breaks started today. Breaks that
+// were due while the user was idle or in silent mode are not counted.
+func (s *Store) CountBreaksToday() (int, error) {
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var count int
+	err := s.db.QueryRow(
+		"SELECT COUNT(*) FROM breaks WHERE started_at >= ? AND outcome NOT IN (?, ?)",
+		startOfDay,
+		OutcomeIdle,
+		OutcomeSuppressed,
+	).Scan(&count)
+	return count, err
+}
+
+// CountBreaksBetween counts the breaks started in [from, to) by outcome.
+// Outcomes without breaks are left out.
+func (s *Store) CountBreaksBetween(from, to time.Time) (map[BreakOutcome]int, error) {
+	rows, err := s.db.Query(
+		`SELECT outcome, COUNT(*)
+		 FROM breaks
+		 WHERE started_at >= ? AND started_at < ?
+		 GROUP BY outcome`,
+		from,
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[BreakOutcome]int)
+	for rows.Next() {
+		var outcome BreakOutcome
+		var count int
+		if err := rows.Scan(&outcome, &count); err != nil {
+			return nil, err
+		}
+		counts[outcome] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// GetLongestGap returns the longest stretch without a completed break on
+// the given date. For today the stretch ends at the current time.
+func (s *Store) GetLongestGap(date time.Time) (time.Duration, error) {
+	breaks, err := s.GetBreaksByDate(date)
+	if err != nil {
+		return 0, err
+	}
+
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	endOfDay := startOfDay.Add(24 * time.Hour)
+	if now := time.Now(); now.Before(endOfDay) {
+		endOfDay = now
+	}
+	if !endOfDay.After(startOfDay) {
+		return 0, nil
+	}
+
+	return LongestGap(breaks, startOfDay, endOfDay), nil
+}
+
+// GetBestRunForDay returns the longest run of consecutive completed breaks
+// on the given date, see BestRun
+func (s *Store) GetBestRunForDay(date time.Time) (int, error) {
+	breaks, err := s.GetBreaksByDate(date)
+	if err != nil {
+		return 0, err
+	}
+	return BestRun(breaks), nil
+}
+
 // GetDailyStats returns statistics for a specific date
 func (s *Store) GetDailyStats(date time.Time) (*DailyStats, error) {
 	dateStr := date.Format("2006-01-02")
@@ -208,56 +684,292 @@ func (s *Store) GetDailyStats(date time.Time) (*DailyStats, error) {
 	return &stats, nil
 }
 
-// GetComplianceReport generates a compliance report for a time period
-func (s *Store) GetComplianceReport(period string) (*ComplianceReport, error) {
-	var startDate time.Time
+// GetYesterdayStats returns the daily statistics of yesterday, which are
+// empty if there were no breaks
+func (s *Store) GetYesterdayStats() (*DailyStats, error) {
+	return s.GetDailyStats(time.Now().AddDate(0, 0, -1))
+}
+
+// GetCurrentStreak returns the number of consecutive days with breaks whose
+// compliance rate reached threshold (a percentage) and how many streak
+// freezes it consumed. Days without any breaks are ignored.
+func (s *Store) GetCurrentStreak(threshold float64) (int, int, error) {
+	rows, err := s.db.Query(
+		`SELECT date, breaks_required, breaks_completed, breaks_skipped,
+		        total_work_minutes, COALESCE(compliance_rate, 0)
+		 FROM daily_stats
+		 WHERE breaks_required > 0
+		 ORDER BY date DESC`,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rows.Close()
+
+	var days []DailyStats
+	for rows.Next() {
+		var day DailyStats
+		err := rows.Scan(&day.Date, &day.BreaksRequired, &day.BreaksCompleted,
+			&day.BreaksSkipped, &day.TotalWorkMinutes, &day.ComplianceRate)
+		if err != nil {
+			return 0, 0, err
+		}
+		days = append(days, day)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	s.mu.Lock()
+	freezes := s.freezes
+	s.mu.Unlock()
+
+	streak, used := CurrentStreak(days, threshold, time.Now(), freezes)
+	return streak, used, nil
+}
+
+// baselineDays is how many days before today make up the personal baseline
+const baselineDays = 30
+
+// Get30DayAverageCompliance returns the average daily compliance rate of the
+// 30 days before today and how many of them had breaks. Today is excluded so
+// it can be compared against the average; with fewer days of data the
+// average covers only those.
+func (s *Store) Get30DayAverageCompliance() (float64, int, error) {
+	today := time.Now()
+	from := today.AddDate(0, 0, -baselineDays)
+
+	rows, err := s.db.Query(
+		`SELECT COALESCE(compliance_rate, 0)
+		 FROM daily_stats
+		 WHERE breaks_required > 0 AND date >= ? AND date < ?`,
+		from.Format("2006-01-02"),
+		today.Format("2006-01-02"),
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rows.Close()
+
+	var rates []float64
+	for rows.Next() {
+		var rate float64
+		if err := rows.Scan(&rate); err != nil {
+			return 0, 0, err
+		}
+		rates = append(rates, rate)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	return AverageCompliance(rates), len(rates), nil
+}
+
+// GetAverageBreakDuration returns the average duration of the breaks
+// completed in [from, to), or 0 if there are none
+func (s *Store) GetAverageBreakDuration(from, to time.Time) (time.Duration, error) {
+	var avgSecs float64
+	err := s.db.QueryRow(
+		`SELECT COALESCE(AVG(duration_seconds), 0)
+		 FROM breaks
+		 WHERE outcome = 'completed' AND started_at >= ? AND started_at < ?`,
+		from,
+		to,
+	).Scan(&avgSecs)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(avgSecs * float64(time.Second)), nil
+}
+
+// GetHealthScore computes the health score of the last week, judging break
+// durations and the streak by the goals set with SetHealthScoreGoals. See
+// ComputeHealthScore.
+func (s *Store) GetHealthScore() (int, error) {
+	s.mu.Lock()
+	breakTarget, streakGoal := s.breakTarget, s.streakGoal
+	s.mu.Unlock()
+
+	report, err := s.GetComplianceReport(PeriodWeek, ComplianceOfRequired)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	avgDur, err := s.GetAverageBreakDuration(now.AddDate(0, 0, -7), now)
+	if err != nil {
+		return 0, err
+	}
+
+	streak, _, err := s.GetCurrentStreak(streakGoal)
+	if err != nil {
+		return 0, err
+	}
+
+	return ComputeHealthScore(report, avgDur, breakTarget, streak), nil
+}
+
+// GetComplianceReport generates a compliance report for a time period with
+// the compliance rate calculated in mode. An empty mode means
+// ComplianceOfRequired.
+func (s *Store) GetComplianceReport(period Period, mode ComplianceMode) (*ComplianceReport, error) {
+	if mode == "" {
+		mode = ComplianceOfRequired
+	}
+
 	now := time.Now()
+	startDate, err := s.periodStart(period, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reportBetween(string(period), startDate, now, mode)
+}
+
+// periodStart returns when period begins if it ends at now. All time
+// begins on the day of the first break, or today without breaks.
+func (s *Store) periodStart(period Period, now time.Time) (time.Time, error) {
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
 	switch period {
-	case "today":
-		startDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	case "week":
-		startDate = now.AddDate(0, 0, -7)
-	case "month":
-		startDate = now.AddDate(0, -1, 0)
+	case PeriodToday:
+		return startOfDay, nil
+	case PeriodWeek:
+		return now.AddDate(0, 0, -7), nil
+	case PeriodMonth:
+		return now.AddDate(0, -1, 0), nil
+	case PeriodYear:
+		return now.AddDate(-1, 0, 0), nil
+	case PeriodAllTime:
+		first, err := s.firstBreakStart()
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !first.Valid {
+			return startOfDay, nil
+		}
+		return time.Date(first.Time.Year(), first.Time.Month(), first.Time.Day(), 0, 0, 0, 0, now.Location()), nil
 	default:
-		return nil, fmt.Errorf("invalid period: %s", period)
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidPeriod, period)
 	}
+}
 
-	var total, completed, skipped int
-	err := s.db.QueryRow(
-		`SELECT
-			COUNT(*) as total,
-			SUM(CASE WHEN was_completed = 1 THEN 1 ELSE 0 END) as completed,
-			SUM(CASE WHEN was_skipped = 1 THEN 1 ELSE 0 END) as skipped
-		 FROM breaks
-		 WHERE started_at >= ?`,
-		startDate,
-	).Scan(&total, &completed, &skipped)
+// firstBreakStart returns when the earliest recorded break started, invalid
+// if there are no breaks
+func (s *Store) firstBreakStart() (sql.NullTime, error) {
+	// MIN() would lose the column type, so the driver couldn't parse the time
+	var first sql.NullTime
+	err := s.db.QueryRow("SELECT started_at FROM breaks ORDER BY started_at LIMIT 1").Scan(&first)
+	if err == sql.ErrNoRows {
+		return first, nil
+	}
+	return first, err
+}
+
+// GetCurrentWeekReport generates a compliance report for the calendar week
+// so far, starting on the configured week start day
+func (s *Store) GetCurrentWeekReport(mode ComplianceMode) (*ComplianceReport, error) {
+	if mode == "" {
+		mode = ComplianceOfRequired
+	}
 
+	s.mu.Lock()
+	weekStart := s.weekStart
+	s.mu.Unlock()
+
+	now := time.Now()
+	return s.reportBetween("calendar_week", startOfWeek(now, weekStart), now, mode)
+}
+
+// reportBetween generates a compliance report for the breaks started in
+// [startDate, now)
+func (s *Store) reportBetween(period string, startDate, now time.Time, mode ComplianceMode) (*ComplianceReport, error) {
+	counts, err := s.countBreaks(startDate, now)
 	if err != nil {
 		return nil, err
 	}
 
-	complianceRate := CalculateComplianceRate(completed, total)
+	complianceRate := s.complianceRateFor(counts, mode)
 
 	// Calculate days in period
 	days := int(now.Sub(startDate).Hours() / 24)
 	if days == 0 {
 		days = 1
 	}
-	averagePerDay := float64(completed) / float64(days)
+	averagePerDay := float64(counts.completed) / float64(days)
 
 	return &ComplianceReport{
 		Period:          period,
-		TotalBreaks:     total,
-		CompletedBreaks: completed,
-		SkippedBreaks:   skipped,
+		Mode:            mode,
+		TotalBreaks:     counts.total,
+		CompletedBreaks: counts.completed,
+		SkippedBreaks:   counts.skipped,
+		PostponedBreaks: counts.postponed,
+		ManualBreaks:    counts.manual,
+		SilentBreaks:    counts.suppressed,
 		ComplianceRate:  complianceRate,
 		AveragePerDay:   averagePerDay,
 	}, nil
 }
 
+// ProjectGoalProgress projects how many of the expected remaining breaks
+// today must be completed to reach the daily compliance goal (a percentage)
+func (s *Store) ProjectGoalProgress(goal float64, expectedRemaining int) (needed int, onTrack bool, err error) {
+	report, err := s.GetComplianceReport(PeriodToday, ComplianceOfRequired)
+	if err != nil {
+		return 0, false, err
+	}
+
+	needed, onTrack = ProjectGoal(goal, report.CompletedBreaks, report.TotalBreaks, expectedRemaining)
+	return needed, onTrack, nil
+}
+
+// GetSessionCompliance generates a compliance report for the breaks started
+// during a session. Sessions that haven't ended yet count up to now.
+func (s *Store) GetSessionCompliance(sessionID int64) (*ComplianceReport, error) {
+	var startedAt time.Time
+	var endedAt sql.NullTime
+	err := s.db.QueryRow(
+		"SELECT started_at, ended_at FROM sessions WHERE id = ?",
+		sessionID,
+	).Scan(&startedAt, &endedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %d", ErrSessionNotFound, sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	end := time.Now()
+	if endedAt.Valid {
+		end = endedAt.Time
+	}
+
+	counts, err := s.countBreaks(startedAt, end)
+	if err != nil {
+		return nil, err
+	}
+
+	days := end.Sub(startedAt).Hours() / 24
+	if days < 1 {
+		days = 1
+	}
+
+	return &ComplianceReport{
+		Period:          "session",
+		Mode:            ComplianceOfRequired,
+		TotalBreaks:     counts.total,
+		CompletedBreaks: counts.completed,
+		SkippedBreaks:   counts.skipped,
+		PostponedBreaks: counts.postponed,
+		ManualBreaks:    counts.manual,
+		SilentBreaks:    counts.suppressed,
+		ComplianceRate:  s.complianceRate(counts),
+		AveragePerDay:   float64(counts.completed) / days,
+	}, nil
+}
+
 // StartSession records the start of a new application session
 func (s *Store) StartSession() (int64, error) {
 	result, err := s.db.Exec(
@@ -281,55 +993,139 @@ func (s *Store) EndSession(sessionID int64, pausedDuration time.Duration) error
 	return err
 }
 
-// updateDailyStats recalculates and updates daily statistics for a given date
-func (s *Store) updateDailyStats(date time.Time) error {
-	dateStr := date.Format("2006-01-02")
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+// breakCounts holds the number of scheduled breaks per outcome in a time
+// range and, separately, the number of manual breaks
+type breakCounts struct {
+	total      int
+	completed  int
+	skipped    int
+	postponed  int
+	manual     int
+	suppressed int
+}
 
-	// Calculate stats from breaks table
-	var required, completed, skipped int
+// countBreaks counts the breaks started in [from, to). Breaks that were due
+// while the user was idle are left out, those due in silent mode are
+// counted separately.
+func (s *Store) countBreaks(from, to time.Time) (breakCounts, error) {
+	var counts breakCounts
 	err := s.db.QueryRow(
 		`SELECT
-			COUNT(*) as required,
-			SUM(CASE WHEN was_completed = 1 THEN 1 ELSE 0 END) as completed,
-			SUM(CASE WHEN was_skipped = 1 THEN 1 ELSE 0 END) as skipped
+			COALESCE(SUM(CASE WHEN was_manual = 0 AND outcome NOT IN ('idle', 'suppressed') THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN was_manual = 0 AND outcome = 'completed' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN was_manual = 0 AND outcome = 'skipped' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN was_manual = 0 AND outcome = 'postponed' THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN was_manual = 1 THEN 1 ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN outcome = 'suppressed' THEN 1 ELSE 0 END), 0)
 		 FROM breaks
 		 WHERE started_at >= ? AND started_at < ?`,
-		startOfDay,
-		endOfDay,
-	).Scan(&required, &completed, &skipped)
+		from,
+		to,
+	).Scan(&counts.total, &counts.completed, &counts.skipped, &counts.postponed, &counts.manual, &counts.suppressed)
+	return counts, err
+}
+
+// hasBreaks returns whether any break started in [from, to)
+func (s *Store) hasBreaks(from, to time.Time) (bool, error) {
+	var found bool
+	err := s.db.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM breaks WHERE started_at >= ? AND started_at < ?)",
+		from,
+		to,
+	).Scan(&found)
+	return found, err
+}
+
+// updateDailyStats recalculates and updates daily statistics for a given
+// date and the aggregate of its month
+func (s *Store) updateDailyStats(date time.Time) error {
+	dateStr := date.Format("2006-01-02")
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	endOfDay := startOfDay.Add(24 * time.Hour)
 
+	// Calculate stats from breaks table
+	counts, err := s.countBreaks(startOfDay, endOfDay)
 	if err != nil {
 		return err
 	}
 
-	complianceRate := CalculateComplianceRate(completed, required)
+	complianceRate := s.complianceRate(counts)
 
 	// Upsert daily stats
 	_, err = s.db.Exec(
-		`INSERT INTO daily_stats (date, breaks_required, breaks_completed, breaks_skipped, compliance_rate)
-		 VALUES (?, ?, ?, ?, ?)
+		`INSERT INTO daily_stats (date, breaks_required, breaks_completed, breaks_skipped, breaks_postponed, compliance_rate)
+		 VALUES (?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(date) DO UPDATE SET
 		   breaks_required = excluded.breaks_required,
 		   breaks_completed = excluded.breaks_completed,
 		   breaks_skipped = excluded.breaks_skipped,
+		   breaks_postponed = excluded.breaks_postponed,
 		   compliance_rate = excluded.compliance_rate`,
 		dateStr,
-		required,
-		completed,
-		skipped,
+		counts.total,
+		counts.completed,
+		counts.skipped,
+		counts.postponed,
 		complianceRate,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return s.updateMonthlyStats(date)
+}
+
+// RebuildDailyStats recalculates the daily statistics of every day from
+// from to to, both included, from the breaks table, e.g. when a crash left
+// them stale. The aggregates of the months touched are recalculated along
+// with them. Days without breaks are left alone, as their breaks may have
+// been pruned and the stats are all that is left of them. It returns the
+// number of days rebuilt.
+func (s *Store) RebuildDailyStats(from, to time.Time) (int, error) {
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location())
+
+	rebuilt := 0
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		found, err := s.hasBreaks(day, day.AddDate(0, 0, 1))
+		if err != nil {
+			return rebuilt, err
+		}
+		if !found {
+			continue
+		}
+		if err := s.updateDailyStats(day); err != nil {
+			return rebuilt, fmt.Errorf("failed to rebuild %s: %w", day.Format("2006-01-02"), err)
+		}
+		rebuilt++
+	}
+	return rebuilt, nil
+}
+
+// DataDir returns the application's data directory holding stats.db
+// On macOS: ~/Library/Application Support/2020Rule, unless
+// TWENTY_RULE_DATA_DIR is set
+func DataDir() (string, error) {
+	if dir := os.Getenv(dataDirEnv); dir != "" {
+		return filepath.Abs(dir)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return dataDirIn(home), nil
+}
+
+// dataDirIn returns the data directory for the given home directory
+func dataDirIn(home string) string {
+	return filepath.Join(home, "Library", "Application Support", appName)
 }
 
 // getDBPath returns the path to the SQLite database file
 func getDBPath() (string, error) {
-	home, err := os.UserHomeDir()
+	dir, err := DataDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, "Library", "Application Support", appName, dbFileName), nil
+	return filepath.Join(dir, dbFileName), nil
 }
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestStore opens a store backed by a fresh database in a temporary
+// data directory
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	t.Setenv(dataDirEnv, t.TempDir())
+
+	store, err := NewStore()
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// insertBreak records a regular break with outcome that started at
+// startedAt and updates the stats of its day, like the Record methods do
+func insertBreak(t *testing.T, s *Store, startedAt time.Time, outcome BreakOutcome) int64 {
+	t.Helper()
+	result, err := s.db.Exec(
+		"INSERT INTO breaks (started_at, completed_at, kind, outcome, duration_seconds) VALUES (?, ?, ?, ?, ?)",
+		startedAt,
+		startedAt.Add(20*time.Second),
+		BreakKindRegular,
+		outcome,
+		20,
+	)
+	if err != nil {
+		t.Fatalf("insert break: %v", err)
+	}
+	if err := s.updateDailyStats(startedAt); err != nil {
+		t.Fatalf("updateDailyStats: %v", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatalf("LastInsertId: %v", err)
+	}
+	return id
+}
+
+// dayAt returns the given date at noon in the local time zone
+func dayAt(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.Local)
+}
+
+func TestDataDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	override := t.TempDir()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		env  string
+		want string
+	}{
+		{"unset", "", filepath.Join(home, "Library", "Application Support", "2020Rule")},
+		{"absolute override", override, override},
+		{"relative override", "portable", filepath.Join(cwd, "portable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(dataDirEnv, tt.env)
+			got, err := DataDir()
+			if err != nil {
+				t.Fatalf("DataDir: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DataDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
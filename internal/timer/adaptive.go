@@ -0,0 +1,50 @@
+package timer
+
+import "time"
+
+const (
+	// adaptWindow is how many of the latest breaks the adaptive interval judges
+	adaptWindow = 10
+	// adaptStep is how much the adaptive interval changes the work duration at once
+	adaptStep = 1 * time.Minute
+	// adaptRaiseBelow is the compliance rate below which the interval grows
+	adaptRaiseBelow = 80.0
+	// adaptLowerFrom is the compliance rate from which the interval shrinks
+	adaptLowerFrom = 100.0
+)
+
+// intervalBounds limits the work duration the adaptive interval can pick
+type intervalBounds struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// adaptInterval returns the work duration following current given the
+// compliance rate (a percentage) of the latest breaks: longer when breaks
+// are often skipped, shorter when all of them were kept. The result always
+// lies within bounds.
+func adaptInterval(current time.Duration, recentCompliance float64, bounds intervalBounds) time.Duration {
+	next := current
+	switch {
+	case recentCompliance < adaptRaiseBelow:
+		next += adaptStep
+	case recentCompliance >= adaptLowerFrom:
+		next -= adaptStep
+	}
+	return min(max(next, bounds.Min), bounds.Max)
+}
+
+// outcomeCompliance returns the share of completed breaks among outcomes as
+// a percentage, or 0 without any
+func outcomeCompliance(outcomes []bool) float64 {
+	if len(outcomes) == 0 {
+		return 0
+	}
+	completed := 0
+	for _, done := range outcomes {
+		if done {
+			completed++
+		}
+	}
+	return float64(completed) / float64(len(outcomes)) * 100
+}
@@ -0,0 +1,87 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+func TestAdaptInterval(t *testing.T) {
+	bounds := intervalBounds{Min: 15 * time.Minute, Max: 30 * time.Minute}
+
+	tests := []struct {
+		name       string
+		current    time.Duration
+		compliance float64
+		want       time.Duration
+	}{
+		{"often skipped grows", 20 * time.Minute, 50, 21 * time.Minute},
+		{"just below the raise threshold grows", 20 * time.Minute, 79.9, 21 * time.Minute},
+		{"mostly kept stays", 20 * time.Minute, 80, 20 * time.Minute},
+		{"all kept shrinks", 20 * time.Minute, 100, 19 * time.Minute},
+		{"never grows past the maximum", 30 * time.Minute, 0, 30 * time.Minute},
+		{"never shrinks below the minimum", 15 * time.Minute, 100, 15 * time.Minute},
+		{"out of bounds is pulled back", 40 * time.Minute, 90, 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adaptInterval(tt.current, tt.compliance, bounds); got != tt.want {
+				t.Errorf("adaptInterval(%s, %.1f) = %s, want %s", tt.current, tt.compliance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutcomeCompliance(t *testing.T) {
+	tests := []struct {
+		outcomes []bool
+		want     float64
+	}{
+		{nil, 0},
+		{[]bool{true, true}, 100},
+		{[]bool{true, false, false, true}, 50},
+		{[]bool{false}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := outcomeCompliance(tt.outcomes); got != tt.want {
+			t.Errorf("outcomeCompliance(%v) = %.1f, want %.1f", tt.outcomes, got, tt.want)
+		}
+	}
+}
+
+func TestAdaptedCallbackRunsWithoutLock(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.AdaptiveInterval = true
+	m := NewManager(cfg, nil)
+
+	adapted := make(chan time.Duration, 1)
+	m.SetOnIntervalAdapted(func(d time.Duration) {
+		// Deadlocks if the callback runs with the lock held
+		m.GetState()
+		adapted <- d
+	})
+
+	m.Start()
+	defer m.Stop()
+	for range adaptWindow {
+		dueNow(m)
+		m.SkipBreak()
+	}
+
+	want := cfg.WorkDuration + adaptStep
+	select {
+	case got := <-adapted:
+		if got != want {
+			t.Errorf("adapted to %s, want %s", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("interval adapted callback didn't run")
+	}
+
+	if cfg.WorkDuration == want {
+		t.Error("the adapted value was written to the shared config")
+	}
+}
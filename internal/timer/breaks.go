@@ -0,0 +1,70 @@
+package timer
+
+import (
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/stats"
+)
+
+const (
+	// gentleBreakFactor scales the first break of the day when GentleFirstBreak is enabled
+	gentleBreakFactor = 0.5
+	// recoveryBreakFactor scales the break after a session longer than LongSessionThreshold
+	recoveryBreakFactor = 2
+)
+
+// BreakInfo describes a break that has been triggered
+type BreakInfo struct {
+	ID          int64 // Stats record of the break, 0 if it wasn't recorded
+	Kind        stats.BreakKind
+	Duration    time.Duration
+	NextBreakAt time.Time // When the following break is due if this one is completed on time
+	Manual      bool      // Asked for by the user outside the schedule
+	Lockout     bool      // A session limit break that can't be ended early
+}
+
+// selectBreak decides the kind and length of the next break given how many
+// breaks have already been started today, the time since the last completed
+// break and the length of the continuous session. A session limit break
+// takes precedence over the recovery break, which takes precedence over the
+// gentle one.
+func selectBreak(cfg *config.Config, breaksToday int, sinceCompleted, session time.Duration) BreakInfo {
+	if maxSession := cfg.MaxSession(); maxSession > 0 && session >= maxSession {
+		return sessionLimitBreak(cfg)
+	}
+
+	if cfg.LongSessionThreshold > 0 && sinceCompleted >= cfg.LongSessionThreshold {
+		return BreakInfo{Kind: stats.BreakKindRecovery, Duration: cfg.BreakDuration * recoveryBreakFactor}
+	}
+
+	if cfg.GentleFirstBreak && breaksToday == 0 {
+		duration := time.Duration(float64(cfg.BreakDuration) * gentleBreakFactor).Round(time.Second)
+		if duration < time.Second {
+			duration = time.Second
+		}
+		return BreakInfo{Kind: stats.BreakKindGentle, Duration: duration}
+	}
+
+	return BreakInfo{Kind: stats.BreakKindRegular, Duration: cfg.BreakDuration}
+}
+
+// sessionLimitBreak returns the longer break at the end of a session of
+// MaxSession. With a SessionCooldown it lasts the cooldown and locks the
+// user out, otherwise it is as long as a recovery break.
+func sessionLimitBreak(cfg *config.Config) BreakInfo {
+	if cfg.SessionCooldown > 0 {
+		return BreakInfo{Kind: stats.BreakKindSessionLimit, Duration: cfg.SessionCooldown, Lockout: true}
+	}
+	return BreakInfo{Kind: stats.BreakKindSessionLimit, Duration: cfg.BreakDuration * recoveryBreakFactor}
+}
+
+// countsAsCompleted decides whether a break of the target duration that
+// ended after elapsed counts as completed, i.e. lasted at least minFraction
+// of the target
+func countsAsCompleted(elapsed, target time.Duration, minFraction float64) bool {
+	if target <= 0 {
+		return true
+	}
+	return float64(elapsed) >= minFraction*float64(target)
+}
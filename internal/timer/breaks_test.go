@@ -0,0 +1,236 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/stats"
+)
+
+func TestSelectBreak(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.BreakDuration = 20 * time.Second
+	cfg.GentleFirstBreak = true
+	cfg.LongSessionThreshold = 90 * time.Minute
+	cfg.MaxSessionHours = 3
+
+	tests := []struct {
+		name           string
+		breaksToday    int
+		sinceCompleted time.Duration
+		session        time.Duration
+		wantKind       stats.BreakKind
+		wantDuration   time.Duration
+	}{
+		{"regular", 3, 20 * time.Minute, time.Hour, stats.BreakKindRegular, 20 * time.Second},
+		{"gentle first break of the day", 0, 20 * time.Minute, 20 * time.Minute, stats.BreakKindGentle, 10 * time.Second},
+		{"recovery after the threshold", 3, 90 * time.Minute, 2 * time.Hour, stats.BreakKindRecovery, 40 * time.Second},
+		{"recovery wins over gentle", 0, 2 * time.Hour, 2 * time.Hour, stats.BreakKindRecovery, 40 * time.Second},
+		{"session limit wins over recovery", 3, 2 * time.Hour, 3 * time.Hour, stats.BreakKindSessionLimit, 40 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectBreak(cfg, tt.breaksToday, tt.sinceCompleted, tt.session)
+			if got.Kind != tt.wantKind || got.Duration != tt.wantDuration {
+				t.Errorf("selectBreak() = %s for %s, want %s for %s", got.Kind, got.Duration, tt.wantKind, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestSelectBreakDisabledFeatures(t *testing.T) {
+	cfg := config.DefaultConfig()
+	if got := selectBreak(cfg, 0, 10*time.Hour, 10*time.Hour); got.Kind != stats.BreakKindRegular {
+		t.Errorf("selectBreak() = %s without thresholds, want %s", got.Kind, stats.BreakKindRegular)
+	}
+}
+
+func TestCountsAsCompleted(t *testing.T) {
+	tests := []struct {
+		name        string
+		elapsed     time.Duration
+		target      time.Duration
+		minFraction float64
+		want        bool
+	}{
+		{"full break", 20 * time.Second, 20 * time.Second, 0.8, true},
+		{"longer than the target", 30 * time.Second, 20 * time.Second, 0.8, true},
+		{"exactly the threshold", 16 * time.Second, 20 * time.Second, 0.8, true},
+		{"just below the threshold", 16*time.Second - time.Millisecond, 20 * time.Second, 0.8, false},
+		{"ended right away", 0, 20 * time.Second, 0.8, false},
+		{"no minimum", 0, 20 * time.Second, 0, true},
+		{"whole break required", 19 * time.Second, 20 * time.Second, 1, false},
+		{"no target", 0, 0, 0.8, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countsAsCompleted(tt.elapsed, tt.target, tt.minFraction); got != tt.want {
+				t.Errorf("countsAsCompleted(%s, %s, %g) = %t, want %t", tt.elapsed, tt.target, tt.minFraction, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecoveryBreakAfterRest(t *testing.T) {
+	tests := []struct {
+		name string
+		// rest happens after two skipped breaks, 40 minutes into the session
+		rest func(m *Manager, clock *fakeClock)
+		want stats.BreakKind
+	}{
+		{
+			name: "no rest",
+			rest: func(m *Manager, clock *fakeClock) {},
+			want: stats.BreakKindRecovery,
+		},
+		{
+			name: "idle",
+			rest: func(m *Manager, clock *fakeClock) {
+				m.PauseInactive()
+				clock.Advance(30 * time.Minute)
+				m.ResumeFromInactive()
+			},
+			want: stats.BreakKindRegular,
+		},
+		{
+			name: "long manual pause",
+			rest: func(m *Manager, clock *fakeClock) {
+				m.Pause()
+				clock.Advance(30 * time.Minute)
+				m.Resume()
+			},
+			want: stats.BreakKindRegular,
+		},
+		{
+			name: "short manual pause",
+			rest: func(m *Manager, clock *fakeClock) {
+				m.Pause()
+				clock.Advance(5 * time.Minute)
+				m.Resume()
+			},
+			want: stats.BreakKindRecovery,
+		},
+		{
+			name: "sleep",
+			rest: func(m *Manager, clock *fakeClock) {
+				clock.Jump(time.Hour)
+				m.HandleWake()
+			},
+			want: stats.BreakKindRegular,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.LongSessionThreshold = 50 * time.Minute
+			cfg.ResetAfterPauseLongerThan = 10 * time.Minute
+			m, clock := newTestManager(t, cfg)
+			var kinds []stats.BreakKind
+			m.SetOnBreakRequired(func(info BreakInfo) { kinds = append(kinds, info.Kind) })
+
+			m.Start()
+			for range 2 {
+				clock.Advance(cfg.WorkDuration)
+				m.SkipBreak()
+			}
+			tt.rest(m, clock)
+			clock.Advance(m.GetTimeUntilBreak())
+
+			if len(kinds) != 3 {
+				t.Fatalf("%d breaks started, want 3", len(kinds))
+			}
+			if kinds[2] != tt.want {
+				t.Errorf("break after rest = %s, want %s", kinds[2], tt.want)
+			}
+		})
+	}
+}
+
+func TestRecoveryBreakResetsAfterCompletion(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.LongSessionThreshold = 30 * time.Minute
+	m, clock := newTestManager(t, cfg)
+	var kinds []stats.BreakKind
+	m.SetOnBreakRequired(func(info BreakInfo) { kinds = append(kinds, info.Kind) })
+
+	m.Start()
+	clock.Advance(cfg.WorkDuration)
+	m.SkipBreak()
+	clock.Advance(cfg.WorkDuration)
+	if got := m.GetTimeSinceLastCompletedBreak(); got != 2*cfg.WorkDuration {
+		t.Errorf("time since last completed break = %s, want %s", got, 2*cfg.WorkDuration)
+	}
+	m.CompleteBreak()
+	clock.Advance(cfg.WorkDuration)
+
+	want := []stats.BreakKind{stats.BreakKindRegular, stats.BreakKindRecovery, stats.BreakKindRegular}
+	if len(kinds) != len(want) {
+		t.Fatalf("breaks %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("breaks %v, want %v", kinds, want)
+			break
+		}
+	}
+}
+
+func TestSessionLimitBreak(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		// pause happens 5 minutes into the second interval
+		pause     time.Duration
+		wantKinds []stats.BreakKind
+	}{
+		{"no pause", 0, 0, []stats.BreakKind{stats.BreakKindRegular, stats.BreakKindRegular, stats.BreakKindSessionLimit}},
+		{"pause left out", 0, 30 * time.Minute, []stats.BreakKind{stats.BreakKindRegular, stats.BreakKindRegular, stats.BreakKindSessionLimit}},
+		{"long pause restarts the session", 10 * time.Minute, 30 * time.Minute, []stats.BreakKind{stats.BreakKindRegular, stats.BreakKindRegular, stats.BreakKindRegular}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.WorkDuration = 20 * time.Minute
+			cfg.MaxSessionHours = 1
+			cfg.SessionCooldown = 2 * time.Minute
+			cfg.ResetAfterPauseLongerThan = tt.threshold
+			m, clock := newTestManager(t, cfg)
+			var breaks []BreakInfo
+			m.SetOnBreakRequired(func(info BreakInfo) { breaks = append(breaks, info) })
+
+			m.Start()
+			clock.Advance(m.GetTimeUntilBreak())
+			m.CompleteBreak()
+			clock.Advance(5 * time.Minute)
+			if tt.pause > 0 {
+				m.Pause()
+				clock.Advance(tt.pause)
+				if d := m.GetSessionDuration(); d != 25*time.Minute {
+					t.Errorf("GetSessionDuration() = %s while paused, want %s", d, 25*time.Minute)
+				}
+				m.Resume()
+			}
+			for range len(tt.wantKinds) - 1 {
+				clock.Advance(m.GetTimeUntilBreak())
+				m.CompleteBreak()
+			}
+
+			if len(breaks) != len(tt.wantKinds) {
+				t.Fatalf("%d breaks started, want %d", len(breaks), len(tt.wantKinds))
+			}
+			for i, info := range breaks {
+				if info.Kind != tt.wantKinds[i] {
+					t.Errorf("break %d = %s, want %s", i+1, info.Kind, tt.wantKinds[i])
+				}
+				if wantLockout := info.Kind == stats.BreakKindSessionLimit; info.Lockout != wantLockout {
+					t.Errorf("break %d lockout = %t, want %t", i+1, info.Lockout, wantLockout)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package timer
+
+import "time"
+
+// Clock tells the time and runs functions after a delay. The manager uses
+// the system clock; tests replace it to control time.
+type Clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) Stopper
+}
+
+// Stopper cancels a function scheduled by a Clock
+type Stopper interface {
+	Stop() bool
+}
+
+// systemClock is the Clock of the time package
+type systemClock struct{}
+
+// Now returns the current local time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// AfterFunc calls f in its own goroutine after d
+func (systemClock) AfterFunc(d time.Duration, f func()) Stopper {
+	return time.AfterFunc(d, f)
+}
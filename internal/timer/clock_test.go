@@ -0,0 +1,108 @@
+package timer
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// fakeClock is a Clock whose time only moves when told to. Scheduled
+// functions run synchronously from Advance.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// fakeTimer is a function scheduled on a fakeClock
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	f     func()
+	done  bool // Fired or stopped
+}
+
+// newFakeClock returns a clock standing at a Wednesday morning
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, time.June, 4, 9, 0, 0, 0, time.Local)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) Stopper {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	pending := !t.done
+	t.done = true
+	return pending
+}
+
+// Advance moves the clock forward by d, running the functions that become
+// due on the way in order
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	for {
+		next := c.nextDue(target)
+		if next == nil {
+			break
+		}
+		next.done = true
+		if next.at.After(c.now) {
+			c.now = next.at
+		}
+		c.mu.Unlock()
+		next.f()
+		c.mu.Lock()
+	}
+	c.now = target
+	c.mu.Unlock()
+}
+
+// Jump moves the clock forward by d without running anything, like a Mac
+// sleeping through its timers
+func (c *fakeClock) Jump(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// nextDue returns the earliest pending timer due by target. Must be called
+// with c.mu held.
+func (c *fakeClock) nextDue(target time.Time) *fakeTimer {
+	var next *fakeTimer
+	for _, t := range c.timers {
+		if t.done || t.at.After(target) {
+			continue
+		}
+		if next == nil || t.at.Before(next.at) {
+			next = t
+		}
+	}
+	return next
+}
+
+// newTestManager creates a manager without stats store that runs on a
+// fake clock
+func newTestManager(t *testing.T, cfg *config.Config) (*Manager, *fakeClock) {
+	t.Helper()
+	clock := newFakeClock()
+	m := NewManager(cfg, nil)
+	m.clock = clock
+	t.Cleanup(m.Stop)
+	return m, clock
+}
@@ -0,0 +1,37 @@
+package timer
+
+import "time"
+
+// EventType identifies what happened in the timer
+type EventType string
+
+const (
+	// EventStateChange is emitted whenever the timer state changes
+	EventStateChange EventType = "state_change"
+	// EventBreakStarted is emitted when a break is triggered
+	EventBreakStarted EventType = "break_started"
+	// EventBreakDeferred is emitted when a due break waits for a presentation to end
+	EventBreakDeferred EventType = "break_deferred"
+	// EventBreakCompleted is emitted when a break is completed
+	EventBreakCompleted EventType = "break_completed"
+	// EventBreakSkipped is emitted when a break is skipped
+	EventBreakSkipped EventType = "break_skipped"
+	// EventBreakPostponed is emitted when a break is postponed
+	EventBreakPostponed EventType = "break_postponed"
+	// EventBreakAbandoned is emitted when a break is cut off without a decision
+	EventBreakAbandoned EventType = "break_abandoned"
+	// EventBreakIdle is emitted when a due break is dropped because the user is idle
+	EventBreakIdle EventType = "break_idle"
+	// EventBreakSuppressed is emitted when a due break is only recorded because of silent mode or screen sharing
+	EventBreakSuppressed EventType = "break_suppressed"
+	// EventBreakWatchdog is emitted when a break nobody ended is completed by the watchdog
+	EventBreakWatchdog EventType = "break_watchdog"
+)
+
+// Event is a record of a timer transition or break outcome
+type Event struct {
+	Time    time.Time `json:"timestamp"`
+	Type    EventType `json:"event"`
+	State   string    `json:"state"`
+	BreakID int64     `json:"break_id,omitempty"`
+}
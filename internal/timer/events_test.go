@@ -0,0 +1,81 @@
+package timer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/eventlog"
+)
+
+func TestEventLogSequence(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m := NewManager(cfg, newTestStore(t))
+	clock := newFakeClock()
+	m.clock = clock
+	t.Cleanup(m.Stop)
+
+	var buf bytes.Buffer
+	eventLog := eventlog.New(&buf)
+	m.SetOnEvent(func(e Event) {
+		if err := eventLog.Log(e); err != nil {
+			t.Errorf("Log: %v", err)
+		}
+	})
+
+	m.Start()
+	clock.Advance(cfg.WorkDuration)
+	clock.Advance(cfg.BreakDuration)
+	m.CompleteBreak()
+	clock.Advance(cfg.WorkDuration)
+	m.SkipBreak()
+
+	type line struct {
+		Time    time.Time `json:"timestamp"`
+		Type    EventType `json:"event"`
+		State   string    `json:"state"`
+		BreakID int64     `json:"break_id"`
+	}
+	want := []line{
+		{Type: EventStateChange, State: StateRunning.String()},
+		{Type: EventBreakStarted, State: StateBreakRequired.String(), BreakID: 1},
+		{Type: EventStateChange, State: StateBreakRequired.String(), BreakID: 1},
+		{Type: EventBreakCompleted, State: StateBreakRequired.String(), BreakID: 1},
+		{Type: EventStateChange, State: StateRunning.String()},
+		{Type: EventBreakStarted, State: StateBreakRequired.String(), BreakID: 2},
+		{Type: EventStateChange, State: StateBreakRequired.String(), BreakID: 2},
+		{Type: EventBreakSkipped, State: StateBreakRequired.String(), BreakID: 2},
+		{Type: EventStateChange, State: StateRunning.String()},
+	}
+
+	var got []line
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var l line
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		got = append(got, l)
+	}
+
+	if len(got) != len(want) {
+		for _, l := range got {
+			t.Logf("%+v", l)
+		}
+		t.Fatalf("%d events logged, want %d", len(got), len(want))
+	}
+	var last time.Time
+	for i := range want {
+		if got[i].Type != want[i].Type || got[i].State != want[i].State || got[i].BreakID != want[i].BreakID {
+			t.Errorf("event %d = %s in %s for break %d, want %s in %s for break %d", i,
+				got[i].Type, got[i].State, got[i].BreakID, want[i].Type, want[i].State, want[i].BreakID)
+		}
+		if got[i].Time.Before(last) {
+			t.Errorf("event %d logged at %s, before the event before it", i, got[i].Time)
+		}
+		last = got[i].Time
+	}
+}
@@ -1,6 +1,8 @@
 package timer
 
 import (
+	"math/rand/v2"
+	"slices"
 	"sync"
 	"time"
 
@@ -38,22 +40,90 @@ func (s State) String() string {
 	}
 }
 
+// deferCheckInterval is how often a deferred break checks whether the
+// presentation has ended
+const deferCheckInterval = 15 * time.Second
+
+// breakWatchdogSlack is how long after a break should have ended the
+// watchdog completes it. It leaves room for the overlay countdown and the
+// grace period of unanswered break notifications.
+const breakWatchdogSlack = 2 * time.Minute
+
+// PresentationDetector reports whether the user is giving a presentation
+type PresentationDetector interface {
+	IsPresenting() bool
+}
+
+// IdleDetector reports how long the user has been away from the computer
+type IdleDetector interface {
+	IdleFor() time.Duration
+}
+
+// PowerSource reports whether the Mac runs on battery
+type PowerSource interface {
+	OnBattery() bool
+}
+
+// OverlaySuppressor reports whether break overlays must not be shown right
+// now, e.g. while the screen is shared
+type OverlaySuppressor interface {
+	ShouldSuppress() bool
+}
+
+// AppSource reports the frontmost application
+type AppSource interface {
+	FrontmostApp() (bundleID string, fullscreen bool)
+}
+
 // Manager handles the timer logic and state transitions
 type Manager struct {
 	state          State
 	config         *config.Config
+	clock          Clock
 	statsStore     *stats.Store
-	currentTimer   *time.Timer
+	currentTimer   Stopper
+	preBreakTimer  Stopper
+	warningTimer   Stopper
+	breakWatchdog  Stopper
 	workStartTime  time.Time
 	breakStartTime time.Time
 	currentBreakID int64
+	currentBreak   BreakInfo
 	elapsed        time.Duration
 	pauseTime      time.Time
+	autoResumeAt   time.Time
+	autoResume     Stopper
+	suspendedUntil time.Time
+	resumeTimer    Stopper
+	deferred       bool
+	deferredInApp  bool      // The break waits for an app from DeferInApps, not a presentation
+	appDeferSince  time.Time // When an app from DeferInApps first held back the due break, zero if none did
+	silent         bool      // Due breaks are recorded as suppressed instead of shown
+	presentation   PresentationDetector
+	power          PowerSource
+	idle           IdleDetector
+	suppressor     OverlaySuppressor
+	apps           AppSource
+	appDuration    time.Duration // Work duration of the frontmost app when the interval was scheduled, 0 = none
+	cadence        time.Duration // Work duration from the cadence schedule when the interval began, 0 = not begun
+	firstInterval  bool          // The first work interval since Start gets the InitialDelay
+	lastCompleted  time.Time     // End of the last completed break or rest, or the first Start
+	extension      time.Duration // Added to the current work interval, up to MaxExtension
+	jitter         time.Duration // Random offset of the current work interval, see IntervalJitter
+	rng            *rand.Rand    // Draws the jitter, seeded for tests
+	recentBreaks   []bool        // Outcomes judged by AdaptiveInterval, true = completed
+	completedInRow int           // Scheduled breaks completed since the last skip in this session
+	sessionStart   time.Time     // Start of the continuous session less the time paused, reset by idle, long pauses and session limit breaks
 
 	// Callbacks
-	onBreakRequired func()
+	onPreBreak      func(time.Duration)
+	onBreakWarning  func(time.Duration)
+	onBreakRequired func(BreakInfo)
 	onBreakComplete func()
 	onStateChange   func(State)
+	onResumedIdle   func(time.Duration)
+	onEvent         func(Event)
+	onAdapted       func(time.Duration)
 
 	mu sync.Mutex
 }
@@ -64,6 +134,8 @@ func NewManager(cfg *config.Config, store *stats.Store) *Manager {
 		state:      StatePausedManual,
 		config:     cfg,
 		statsStore: store,
+		clock:      systemClock{},
+		rng:        rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
 	}
 }
 
@@ -77,9 +149,16 @@ func (m *Manager) Start() {
 	}
 
 	m.state = StateRunning
-	m.workStartTime = time.Now()
+	m.workStartTime = m.clock.Now()
 	m.elapsed = 0
+	m.firstInterval = true
+	m.extension = 0
+	m.sessionStart = m.workStartTime
+	if m.lastCompleted.IsZero() {
+		m.lastCompleted = m.workStartTime
+	}
 
+	m.beginInterval()
 	m.scheduleWorkTimer()
 	m.notifyStateChange()
 }
@@ -94,9 +173,37 @@ func (m *Manager) Pause() {
 	}
 
 	m.stopCurrentTimer()
-	m.pauseTime = time.Now()
-	m.elapsed += time.Since(m.workStartTime)
+	m.pauseTime = m.clock.Now()
+	m.elapsed += m.pauseTime.Sub(m.workStartTime)
+	m.state = StatePausedManual
+	m.notifyStateChange()
+}
+
+// PauseFor manually pauses the timer and resumes it automatically after d
+func (m *Manager) PauseFor(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StateRunning {
+		return
+	}
+
+	m.stopCurrentTimer()
+	m.pauseTime = m.clock.Now()
+	m.elapsed += m.pauseTime.Sub(m.workStartTime)
 	m.state = StatePausedManual
+
+	resumeAt := m.pauseTime.Add(d)
+	m.autoResumeAt = resumeAt
+	m.autoResume = m.clock.AfterFunc(d, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		// A manual resume and pause in the meantime replaces this resume
+		if m.autoResumeAt.Equal(resumeAt) {
+			m.resume()
+		}
+	})
+
 	m.notifyStateChange()
 }
 
@@ -104,13 +211,33 @@ func (m *Manager) Pause() {
 func (m *Manager) Resume() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.resume()
+}
 
+// resume resumes the timer from pause. After a manual pause longer than
+// ResetAfterPauseLongerThan the work interval and the session start over
+// and the pause counts as rest, otherwise both continue where they were
+// paused. Must be called with m.mu held.
+func (m *Manager) resume() {
 	if m.state != StatePausedManual && m.state != StatePausedInactive {
 		return
 	}
+	m.cancelAutoResume()
+
+	now := m.clock.Now()
+	paused := now.Sub(m.pauseTime)
+	if m.state == StatePausedManual && pauseRestartsInterval(paused, m.config.ResetAfterPauseLongerThan) {
+		m.elapsed = 0
+		m.lastCompleted = now
+		m.sessionStart = now
+		m.beginInterval()
+	} else if !m.sessionStart.IsZero() {
+		// The pause wasn't work, so leave it out of the session
+		m.sessionStart = m.sessionStart.Add(paused)
+	}
 
 	m.state = StateRunning
-	m.workStartTime = time.Now()
+	m.workStartTime = now
 	m.scheduleWorkTimer()
 	m.notifyStateChange()
 }
@@ -125,8 +252,8 @@ func (m *Manager) PauseInactive() {
 	}
 
 	m.stopCurrentTimer()
-	m.pauseTime = time.Now()
-	m.elapsed += time.Since(m.workStartTime)
+	m.pauseTime = m.clock.Now()
+	m.elapsed += m.pauseTime.Sub(m.workStartTime)
 	m.state = StatePausedInactive
 	m.notifyStateChange()
 }
@@ -140,12 +267,133 @@ func (m *Manager) ResumeFromInactive() {
 		return
 	}
 
+	// The user was already idle for the threshold before the timer paused
+	idleDuration := m.clock.Now().Sub(m.pauseTime) + m.config.IdleThreshold
+
 	m.state = StateRunning
-	m.workStartTime = time.Now()
+	m.workStartTime = m.clock.Now()
+	// Being away ends the session and was rest enough
+	m.sessionStart = m.workStartTime
+	m.lastCompleted = m.workStartTime
+	m.scheduleWorkTimer()
+	m.notifyStateChange()
+
+	if m.onResumedIdle != nil {
+		go m.onResumedIdle(idleDuration) // Call in goroutine to avoid blocking
+	}
+}
+
+// HandleWake brings the running work interval up to date after the Mac
+// woke from sleep. Timers don't advance during sleep, so the time slept is
+// taken from the wall clock. With CatchUpBreaks a single break is taken if
+// the interval ran out during sleep, otherwise the sleep counts as a break.
+func (m *Manager) HandleWake() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handleWake(m.clock.Now())
+}
+
+// handleWake handles a wake at now. Must be called with m.mu held.
+func (m *Manager) handleWake(now time.Time) {
+	if m.state != StateRunning {
+		return
+	}
+
+	// Round(0) drops the monotonic reading, which excludes the sleep
+	worked := m.elapsed + now.Round(0).Sub(m.workStartTime.Round(0))
+	m.stopCurrentTimer()
+
+	switch wakeActionFor(worked, m.workDuration(), m.config.CatchUpBreaks) {
+	case wakeContinue:
+		m.elapsed = worked
+		m.workStartTime = now
+		m.scheduleWorkTimer()
+	case wakeBreak:
+		m.triggerBreak()
+	case wakeRestart:
+		m.workStartTime = now
+		m.elapsed = 0
+		m.extension = 0
+		m.sessionStart = now
+		m.lastCompleted = now
+		m.beginInterval()
+		m.scheduleWorkTimer()
+		m.notifyStateChange()
+	}
+}
+
+// RestartInterval starts the current work interval over
+func (m *Manager) RestartInterval() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StateRunning {
+		return
+	}
+
+	m.workStartTime = m.clock.Now()
+	m.elapsed = 0
+	m.extension = 0
+	m.beginInterval()
 	m.scheduleWorkTimer()
 	m.notifyStateChange()
 }
 
+// TriggerBreakNow starts a regular break right away instead of waiting for
+// the work interval to run out. The break is recorded as manual, so it
+// doesn't count towards compliance, and the next work interval starts once
+// it ends.
+func (m *Manager) TriggerBreakNow() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StateRunning {
+		return
+	}
+
+	m.stopCurrentTimer()
+	m.firstInterval = false
+	m.extension = 0
+	m.startBreak(BreakInfo{
+		Kind:     stats.BreakKindRegular,
+		Duration: m.config.BreakDuration,
+		Manual:   true,
+	})
+}
+
+// ExtendWorkInterval pushes the break due at the end of the running work
+// interval later by d, up to MaxExtension per interval. It returns the
+// extension actually granted.
+func (m *Manager) ExtendWorkInterval(d time.Duration) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StateRunning {
+		return 0
+	}
+
+	granted := grantExtension(m.extension, d, m.config.MaxExtension)
+	if granted <= 0 {
+		return 0
+	}
+	m.extension += granted
+
+	// Rescheduling counts from workStartTime, so take the time worked so far along
+	now := m.clock.Now()
+	m.elapsed += now.Sub(m.workStartTime)
+	m.workStartTime = now
+	m.scheduleWorkTimer()
+	return granted
+}
+
+// CanExtendWorkInterval returns whether the running work interval can be
+// extended any further
+func (m *Manager) CanExtendWorkInterval() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state == StateRunning && m.extension < m.config.MaxExtension
+}
+
 // CompleteBreak marks the current break as completed
 func (m *Manager) CompleteBreak() {
 	m.mu.Lock()
@@ -154,19 +402,51 @@ func (m *Manager) CompleteBreak() {
 	if m.state != StateBreakRequired {
 		return
 	}
+	m.completeBreak(m.clock.Now().Sub(m.breakStartTime))
+}
+
+// EndBreakEarly ends the current break after the user looked away for
+// elapsed. The break counts as completed if elapsed reaches MinBreakFraction
+// of its duration and as skipped otherwise; the return value tells which.
+func (m *Manager) EndBreakEarly(elapsed time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StateBreakRequired {
+		return false
+	}
+	if !countsAsCompleted(elapsed, m.currentBreak.Duration, m.config.MinBreakFraction) {
+		m.skipBreak()
+		return false
+	}
+	m.completeBreak(elapsed)
+	return true
+}
+
+// completeBreak records the current break as completed after duration and
+// starts the next work interval. Must be called with m.mu held.
+func (m *Manager) completeBreak(duration time.Duration) {
+	m.stopBreakWatchdog()
 
 	// Record break completion
 	if m.statsStore != nil && m.currentBreakID > 0 {
-		duration := time.Since(m.breakStartTime)
 		m.statsStore.RecordBreakComplete(m.currentBreakID, duration)
 	}
+	m.emit(EventBreakCompleted)
+	m.adaptInterval(true)
+	m.countInRow(true)
 
 	// Reset to running state
 	m.state = StateRunning
-	m.workStartTime = time.Now()
+	m.workStartTime = m.clock.Now()
+	m.lastCompleted = m.workStartTime
+	if m.currentBreak.Kind == stats.BreakKindSessionLimit {
+		m.sessionStart = m.workStartTime
+	}
 	m.elapsed = 0
 	m.currentBreakID = 0
 
+	m.beginInterval()
 	m.scheduleWorkTimer()
 	m.notifyStateChange()
 
@@ -183,22 +463,285 @@ func (m *Manager) SkipBreak() {
 	if m.state != StateBreakRequired {
 		return
 	}
+	m.skipBreak()
+}
+
+// skipBreak records the current break as skipped and starts the next work
+// interval. Must be called with m.mu held.
+func (m *Manager) skipBreak() {
+	m.stopBreakWatchdog()
 
 	// Record break as skipped
 	if m.statsStore != nil && m.currentBreakID > 0 {
 		m.statsStore.RecordBreakSkipped(m.currentBreakID)
 	}
+	m.emit(EventBreakSkipped)
+	m.adaptInterval(false)
+	m.countInRow(false)
+
+	// Reset to running state
+	m.state = StateRunning
+	m.workStartTime = m.clock.Now()
+	m.elapsed = 0
+	m.currentBreakID = 0
+
+	m.beginInterval()
+	m.scheduleWorkTimer()
+	m.notifyStateChange()
+}
+
+// PostponeBreak moves the current break d into the future. The break is
+// recorded as postponed and the next one becomes due after d.
+func (m *Manager) PostponeBreak(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StateBreakRequired {
+		return
+	}
+	m.stopBreakWatchdog()
+
+	if m.statsStore != nil && m.currentBreakID > 0 {
+		m.statsStore.RecordBreakPostponed(m.currentBreakID)
+	}
+	m.emit(EventBreakPostponed)
+
+	// Resume the work interval with only d left
+	m.state = StateRunning
+	m.workStartTime = m.clock.Now()
+	m.currentBreakID = 0
+	m.refreshAppDuration()
+	m.elapsed = max(m.workDuration()-d, 0)
+
+	m.scheduleWorkTimer()
+	m.notifyStateChange()
+}
+
+// PostponeUpcomingBreak moves the break about to start d into the future,
+// e.g. from its warning, and reports whether it did. It only acts while the
+// break is at most PreBreakWarning away, so a late answer to the warning
+// can't postpone the break after the one it was about. Like PostponeBreak,
+// the break is recorded as postponed.
+func (m *Manager) PostponeUpcomingBreak(d time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StateRunning || m.config.PreBreakWarning <= 0 {
+		return false
+	}
+	remaining := m.workDuration() - m.elapsed - m.clock.Now().Sub(m.workStartTime)
+	if remaining > m.config.PreBreakWarning {
+		return false
+	}
+
+	m.stopCurrentTimer()
+	if m.statsStore != nil {
+		if id, err := m.statsStore.RecordBreakStart(stats.BreakKindRegular); err == nil {
+			m.statsStore.RecordBreakPostponed(id)
+			m.currentBreakID = id
+		}
+	}
+	m.emit(EventBreakPostponed)
+	m.currentBreakID = 0
+
+	// Like the postponed break, the extra time replaces the rest of the interval
+	m.workStartTime = m.clock.Now()
+	m.refreshAppDuration()
+	m.elapsed = max(m.workDuration()-d, 0)
+
+	m.scheduleWorkTimer()
+	m.notifyStateChange()
+	return true
+}
+
+// AbandonBreak ends the current break without counting it as completed or
+// skipped, e.g. after an emergency exit from the overlay
+func (m *Manager) AbandonBreak() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StateBreakRequired {
+		return
+	}
+	m.stopBreakWatchdog()
+
+	if m.statsStore != nil && m.currentBreakID > 0 {
+		m.statsStore.RecordBreakAbandoned(m.currentBreakID)
+	}
+	m.emit(EventBreakAbandoned)
+	m.countInRow(false)
 
 	// Reset to running state
 	m.state = StateRunning
-	m.workStartTime = time.Now()
+	m.workStartTime = m.clock.Now()
 	m.elapsed = 0
 	m.currentBreakID = 0
 
+	m.beginInterval()
 	m.scheduleWorkTimer()
 	m.notifyStateChange()
 }
 
+// ExtendBreak lengthens the current break by d and reports whether a break
+// was in progress. The recorded duration is measured when the break ends,
+// so it reflects the extension.
+func (m *Manager) ExtendBreak(d time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StateBreakRequired {
+		return false
+	}
+
+	m.currentBreak.Duration += d
+	return true
+}
+
+// SuspendUntilTomorrow stops triggering breaks until local midnight.
+// The work timer keeps running and breaks resume automatically at midnight.
+func (m *Manager) SuspendUntilTomorrow() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	m.suspendedUntil = nextMidnight(now)
+
+	if m.resumeTimer != nil {
+		m.resumeTimer.Stop()
+	}
+	m.resumeTimer = m.clock.AfterFunc(m.suspendedUntil.Sub(now), func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.endSuspension()
+	})
+
+	m.notifyStateChange()
+}
+
+// CancelSuspension re-enables breaks before the suspension ends on its own
+func (m *Manager) CancelSuspension() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.endSuspension()
+}
+
+// GetPausedSince returns when the timer was paused, or the zero time if it
+// isn't paused
+func (m *Manager) GetPausedSince() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state != StatePausedManual && m.state != StatePausedInactive {
+		return time.Time{}
+	}
+	return m.pauseTime
+}
+
+// GetAutoResumeAt returns when a pause started with PauseFor ends, or the
+// zero time if no automatic resume is scheduled
+func (m *Manager) GetAutoResumeAt() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.autoResumeAt
+}
+
+// IsDeferred returns whether a due break waits for a presentation to end
+// or an app from DeferInApps
+func (m *Manager) IsDeferred() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.deferred
+}
+
+// IsDeferredInApp returns whether a due break waits for an app from
+// DeferInApps rather than a presentation
+func (m *Manager) IsDeferredInApp() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.deferred && m.deferredInApp
+}
+
+// SetPresentationDetector sets the detector used to defer breaks during
+// presentations when DeferBreaksDuringPresentations is enabled
+func (m *Manager) SetPresentationDetector(detector PresentationDetector) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.presentation = detector
+}
+
+// SetIdleDetector sets the detector consulted when a break becomes due.
+// Breaks due while the user has been idle for IdleThreshold are recorded as
+// idle and not shown.
+func (m *Manager) SetIdleDetector(detector IdleDetector) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.idle = detector
+}
+
+// SetOverlaySuppressor sets what is asked whether a due break may be shown.
+// Breaks it holds back are recorded as suppressed, like in silent mode.
+func (m *Manager) SetOverlaySuppressor(suppressor OverlaySuppressor) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.suppressor = suppressor
+}
+
+// SetAppSource sets the source of the frontmost application used to pick
+// per-app work durations
+func (m *Manager) SetAppSource(source AppSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.apps = source
+}
+
+// SetPowerSource sets the power source used to suppress breaks on battery
+// when PauseOnBattery is enabled
+func (m *Manager) SetPowerSource(source PowerSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.power = source
+}
+
+// IsSuppressedOnBattery returns whether breaks are currently suppressed
+// because the Mac runs on battery
+func (m *Manager) IsSuppressedOnBattery() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.suppressedOnBattery()
+}
+
+// IsOutsideRemindersWindow returns whether breaks are held because the
+// daily reminders window from StartRemindersAt to StopRemindersAt is over
+func (m *Manager) IsOutsideRemindersWindow() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return !remindersActiveAt(m.clock.Now(), m.config)
+}
+
+// SetSilent turns silent mode on or off. In silent mode the timer keeps
+// running, but due breaks are only recorded as suppressed: no overlay, no
+// sound and no onBreakRequired callback. A break already showing isn't
+// affected.
+func (m *Manager) SetSilent(silent bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.silent = silent
+}
+
+// IsSilent returns whether silent mode is on
+func (m *Manager) IsSilent() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.silent
+}
+
+// IsSuspended returns whether breaks are currently suspended for the day
+func (m *Manager) IsSuspended() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return isSuspended(m.clock.Now(), m.suspendedUntil)
+}
+
 // GetState returns the current state
 func (m *Manager) GetState() State {
 	m.mu.Lock()
@@ -215,8 +758,8 @@ func (m *Manager) GetTimeUntilBreak() time.Duration {
 		return 0
 	}
 
-	totalElapsed := m.elapsed + time.Since(m.workStartTime)
-	remaining := m.config.WorkDuration - totalElapsed
+	totalElapsed := m.elapsed + m.clock.Now().Sub(m.workStartTime)
+	remaining := m.workDuration() - totalElapsed
 
 	if remaining < 0 {
 		return 0
@@ -225,6 +768,88 @@ func (m *Manager) GetTimeUntilBreak() time.Duration {
 	return remaining
 }
 
+// GetTimeSinceLastCompletedBreak returns how long ago the last break was
+// completed. Skipped and postponed breaks don't count; before the first
+// completed break it is measured from the first Start.
+func (m *Manager) GetTimeSinceLastCompletedBreak() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.lastCompleted.IsZero() {
+		return 0
+	}
+	return m.clock.Now().Sub(m.lastCompleted)
+}
+
+// GetSessionDuration returns how long the current continuous session has
+// lasted. Breaks within it count and pauses don't; being idle long enough to
+// auto-pause, a pause longer than ResetAfterPauseLongerThan or completing a
+// session limit break starts a new one. It is 0 before the first Start.
+func (m *Manager) GetSessionDuration() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// The session stands still while paused
+	end := m.clock.Now()
+	if m.state != StateRunning && m.state != StateBreakRequired && m.pauseTime.After(m.sessionStart) {
+		end = m.pauseTime
+	}
+	return sessionLength(m.sessionStart, end)
+}
+
+// GetConsecutiveCompletions returns how many scheduled breaks were
+// completed in a row since the last skipped or abandoned one. It starts at
+// 0 with every session.
+func (m *Manager) GetConsecutiveCompletions() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.completedInRow
+}
+
+// IsBreakDue returns whether a break is currently required
+func (m *Manager) IsBreakDue() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state == StateBreakRequired
+}
+
+// GetProgress returns the elapsed fraction of the current work interval,
+// from 0 to 1. It stays frozen while paused and is 1 while a break is due.
+func (m *Manager) GetProgress() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch m.state {
+	case StateBreakRequired:
+		return 1
+	case StateRunning:
+		return workProgress(m.elapsed+m.clock.Now().Sub(m.workStartTime), m.workDuration())
+	default:
+		return workProgress(m.elapsed, m.workDuration())
+	}
+}
+
+// GetNextBreakTime returns when the next break is due. During a break it is
+// the break after the current one. It is zero while paused.
+func (m *Manager) GetNextBreakTime() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.nextBreakTime()
+}
+
+// nextBreakTime returns when the next break is due. Must be called with
+// m.mu held.
+func (m *Manager) nextBreakTime() time.Time {
+	switch m.state {
+	case StateRunning:
+		return nextBreakAt(m.workStartTime, m.elapsed, m.workDuration())
+	case StateBreakRequired:
+		return nextBreakAt(m.breakStartTime.Add(m.currentBreak.Duration), 0, m.workDuration())
+	default:
+		return time.Time{}
+	}
+}
+
 // GetBreakTimeRemaining returns the remaining time in the current break
 func (m *Manager) GetBreakTimeRemaining() time.Duration {
 	m.mu.Lock()
@@ -234,8 +859,8 @@ func (m *Manager) GetBreakTimeRemaining() time.Duration {
 		return 0
 	}
 
-	elapsed := time.Since(m.breakStartTime)
-	remaining := m.config.BreakDuration - elapsed
+	elapsed := m.clock.Now().Sub(m.breakStartTime)
+	remaining := m.currentBreak.Duration - elapsed
 
 	if remaining < 0 {
 		return 0
@@ -244,8 +869,24 @@ func (m *Manager) GetBreakTimeRemaining() time.Duration {
 	return remaining
 }
 
+// SetOnPreBreak sets the callback for shortly before a break is required.
+// It receives the time left until the break starts.
+func (m *Manager) SetOnPreBreak(callback func(time.Duration)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onPreBreak = callback
+}
+
+// SetOnBreakWarning sets the callback for PreBreakWarning before a break is
+// required. It receives the time left until the break starts.
+func (m *Manager) SetOnBreakWarning(callback func(time.Duration)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onBreakWarning = callback
+}
+
 // SetOnBreakRequired sets the callback for when a break is required
-func (m *Manager) SetOnBreakRequired(callback func()) {
+func (m *Manager) SetOnBreakRequired(callback func(BreakInfo)) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.onBreakRequired = callback
@@ -265,6 +906,32 @@ func (m *Manager) SetOnStateChange(callback func(State)) {
 	m.onStateChange = callback
 }
 
+// SetOnResumedFromIdle sets the callback for when the timer resumes after
+// inactivity. It receives how long the user was away.
+func (m *Manager) SetOnResumedFromIdle(callback func(time.Duration)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onResumedIdle = callback
+}
+
+// SetOnEvent sets the callback for every state change and break outcome.
+// It is called synchronously in order, so it must not call back into the
+// manager.
+func (m *Manager) SetOnEvent(callback func(Event)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onEvent = callback
+}
+
+// SetOnIntervalAdapted sets the callback for when AdaptiveInterval changes
+// the work duration, so the new value can be persisted. The manager already
+// uses the new value when it runs, in its own goroutine.
+func (m *Manager) SetOnIntervalAdapted(callback func(time.Duration)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onAdapted = callback
+}
+
 // UpdateConfig updates the configuration
 func (m *Manager) UpdateConfig(cfg *config.Config) {
 	m.mu.Lock()
@@ -278,6 +945,8 @@ func (m *Manager) Stop() {
 	defer m.mu.Unlock()
 
 	m.stopCurrentTimer()
+	m.stopBreakWatchdog()
+	m.cancelAutoResume()
 	m.state = StatePausedManual
 	m.elapsed = 0
 	m.notifyStateChange()
@@ -286,14 +955,17 @@ func (m *Manager) Stop() {
 // scheduleWorkTimer schedules a timer for the work duration
 func (m *Manager) scheduleWorkTimer() {
 	m.stopCurrentTimer()
+	m.refreshAppDuration()
 
-	remaining := m.config.WorkDuration - m.elapsed
+	remaining := m.workDuration() - m.elapsed
 	if remaining <= 0 {
 		m.triggerBreak()
 		return
 	}
+	m.deferred = false
+	m.appDeferSince = time.Time{}
 
-	m.currentTimer = time.AfterFunc(remaining, func() {
+	m.currentTimer = m.clock.AfterFunc(remaining, func() {
 		m.mu.Lock()
 		defer m.mu.Unlock()
 
@@ -301,44 +973,456 @@ func (m *Manager) scheduleWorkTimer() {
 			m.triggerBreak()
 		}
 	})
+
+	m.schedulePreBreak(remaining)
+}
+
+// beginInterval fixes the cadence work duration and draws the jitter of a
+// fresh work interval. Resumed, extended and postponed intervals keep
+// theirs, even if the cadence schedule moved on in the meantime. Must be
+// called with m.mu held.
+func (m *Manager) beginInterval() {
+	m.cadence = effectiveConfig(m.clock.Now(), m.config).WorkDuration
+	m.refreshAppDuration()
+	m.jitter = 0
+	m.jitter = jitterOffset(m.rng, m.workDuration(), m.config.IntervalJitter)
+}
+
+// schedulePreBreak schedules the pre-break and warning callbacks for their
+// configured lead times before a break due in remaining
+func (m *Manager) schedulePreBreak(remaining time.Duration) {
+	m.preBreakTimer = m.schedulePreBreakCue(remaining, m.config.PreBreakDimDuration, func(left time.Duration) {
+		if m.onPreBreak != nil {
+			go m.onPreBreak(left) // Call in goroutine to avoid blocking
+		}
+	})
+	m.warningTimer = m.schedulePreBreakCue(remaining, m.config.PreBreakWarning, func(left time.Duration) {
+		if m.onBreakWarning != nil {
+			go m.onBreakWarning(left) // Call in goroutine to avoid blocking
+		}
+	})
+}
+
+// schedulePreBreakCue schedules cue lead before a break due in remaining.
+// If less than lead is left, cue runs right away with what remains. It
+// runs with m.mu held and is dropped if the break won't be shown. Without
+// a lead nothing is scheduled and the timer is nil.
+func (m *Manager) schedulePreBreakCue(remaining, lead time.Duration, cue func(left time.Duration)) Stopper {
+	if lead <= 0 {
+		return nil
+	}
+
+	return m.clock.AfterFunc(max(remaining-lead, 0), func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		now := m.clock.Now()
+		if m.state != StateRunning || m.silent || m.breaksHeld(now) || m.shouldDefer() {
+			return
+		}
+		cue(min(lead, remaining))
+	})
 }
 
 // triggerBreak initiates a break
 func (m *Manager) triggerBreak() {
+	m.firstInterval = false
+	m.extension = 0
+
+	// The resume timer may fire late after sleep, so also check the wall clock
+	now := m.clock.Now()
+	if m.breaksHeld(now) {
+		m.workStartTime = now
+		m.elapsed = 0
+		m.beginInterval()
+		m.scheduleWorkTimer()
+		return
+	}
+
+	if m.shouldDefer() {
+		m.deferBreak()
+		return
+	}
+	m.deferred = false
+	m.appDeferSince = time.Time{}
+
+	// Nobody is there to take the break; the user is resting anyway
+	if m.idleNow() {
+		m.dropIdleBreak()
+		return
+	}
+
+	// Silent mode and screen sharing keep count of the breaks without
+	// interrupting
+	if m.silent || m.overlaySuppressed() {
+		m.dropSilentBreak()
+		return
+	}
+
+	// Only the gentle first break depends on today's history
+	breaksToday := 1
+	if m.config.GentleFirstBreak && m.statsStore != nil {
+		if count, err := m.statsStore.CountBreaksToday(); err == nil {
+			breaksToday = count
+		}
+	}
+	m.startBreak(selectBreak(m.config, breaksToday, now.Sub(m.lastCompleted), sessionLength(m.sessionStart, now)))
+}
+
+// startBreak records info as the current break and shows it. Must be called
+// with m.mu held.
+func (m *Manager) startBreak(info BreakInfo) {
+	m.currentBreak = info
+
 	// Record break start
 	if m.statsStore != nil {
-		breakID, err := m.statsStore.RecordBreakStart()
+		record := m.statsStore.RecordBreakStart
+		if info.Manual {
+			record = m.statsStore.RecordManualBreakStart
+		}
+		breakID, err := record(m.currentBreak.Kind)
 		if err == nil {
 			m.currentBreakID = breakID
+			m.currentBreak.ID = breakID
 		}
 	}
 
 	m.state = StateBreakRequired
-	m.breakStartTime = time.Now()
+	m.breakStartTime = m.clock.Now()
+	m.currentBreak.NextBreakAt = m.nextBreakTime()
+	m.emit(EventBreakStarted)
 
 	// Note: Break completion is handled by the overlay's onComplete callback
-	// which calls CompleteBreak(). We don't schedule a timer here to avoid
-	// race conditions between the overlay countdown and a separate timer.
+	// which calls CompleteBreak(). The watchdog only steps in well after the
+	// countdown should have ended, so it doesn't race the overlay.
+	m.scheduleBreakWatchdog(m.breakStartTime)
 
 	m.notifyStateChange()
 
 	if m.onBreakRequired != nil {
-		m.onBreakRequired()
+		m.onBreakRequired(m.currentBreak)
 	}
 }
 
-// stopCurrentTimer stops the current timer if it exists
+// dropIdleBreak records the due break as idle instead of showing it and
+// starts the next work interval. Must be called with m.mu held.
+func (m *Manager) dropIdleBreak() {
+	m.dropBreak(m.statsStore.RecordBreakIdle, EventBreakIdle)
+}
+
+// dropSilentBreak records the due break as suppressed instead of showing it
+// and starts the next work interval. Must be called with m.mu held.
+func (m *Manager) dropSilentBreak() {
+	m.dropBreak(m.statsStore.RecordBreakSuppressed, EventBreakSuppressed)
+}
+
+// dropBreak records the due break with record, emits eventType and starts
+// the next work interval without showing the break. Must be called with
+// m.mu held.
+func (m *Manager) dropBreak(record func(stats.BreakKind) (int64, error), eventType EventType) {
+	if m.statsStore != nil {
+		if id, err := record(stats.BreakKindRegular); err == nil {
+			m.currentBreakID = id
+		}
+	}
+	m.emit(eventType)
+	m.currentBreakID = 0
+
+	m.workStartTime = m.clock.Now()
+	m.elapsed = 0
+	m.beginInterval()
+	m.scheduleWorkTimer()
+}
+
+// idleNow returns whether the user has been idle for IdleThreshold right
+// now. The idle time is read fresh, since the idle poll may lag behind.
+// Must be called with m.mu held.
+func (m *Manager) idleNow() bool {
+	return m.idle != nil && m.config.IdleThreshold > 0 && m.idle.IdleFor() >= m.config.IdleThreshold
+}
+
+// overlaySuppressed returns whether the OverlaySuppressor holds back due
+// breaks right now. Must be called with m.mu held.
+func (m *Manager) overlaySuppressed() bool {
+	return m.suppressor != nil && m.suppressor.ShouldSuppress()
+}
+
+// breaksHeld returns whether breaks due at now are dropped and the work
+// interval starts over: while suspended, on weekends without breaks and on
+// battery with PauseOnBattery. Must be called with m.mu held.
+func (m *Manager) breaksHeld(now time.Time) bool {
+	return isSuspended(now, m.suspendedUntil) || !breaksEnabledAt(now, m.config) || m.suppressedOnBattery()
+}
+
+// suppressedOnBattery returns whether PauseOnBattery applies right now.
+// Must be called with m.mu held.
+func (m *Manager) suppressedOnBattery() bool {
+	return suppressOnBattery(m.config, m.power != nil && m.power.OnBattery())
+}
+
+// workDuration returns the length of the current work interval, with the
+// cadence work duration fixed when it began. The first interval after Start
+// is adjusted by the InitialDelay, and the interval's jitter and extensions
+// granted by ExtendWorkInterval are added.
+func (m *Manager) workDuration() time.Duration {
+	duration := m.cadence
+	if duration <= 0 {
+		duration = effectiveConfig(m.clock.Now(), m.config).WorkDuration
+	}
+	if m.appDuration > 0 {
+		duration = m.appDuration
+	}
+	if m.firstInterval && duration+m.config.InitialDelay > 0 {
+		duration += m.config.InitialDelay
+	}
+	return duration + m.jitter + m.extension
+}
+
+// countInRow counts the end of the current break towards the breaks
+// completed in a row. Manual breaks neither extend nor end the run. Must be
+// called with m.mu held.
+func (m *Manager) countInRow(completed bool) {
+	if m.currentBreak.Manual {
+		return
+	}
+	if completed {
+		m.completedInRow++
+	} else {
+		m.completedInRow = 0
+	}
+}
+
+// adaptInterval records a break outcome and, with AdaptiveInterval, adjusts
+// the work duration once enough breaks were judged. Must be called with
+// m.mu held.
+func (m *Manager) adaptInterval(completed bool) {
+	if !m.config.AdaptiveInterval {
+		m.recentBreaks = nil
+		return
+	}
+	// Manual breaks say nothing about how well the interval fits
+	if m.currentBreak.Manual {
+		return
+	}
+
+	m.recentBreaks = append(m.recentBreaks, completed)
+	if len(m.recentBreaks) < adaptWindow {
+		return
+	}
+
+	bounds := intervalBounds{Min: m.config.AdaptiveMinInterval, Max: m.config.AdaptiveMaxInterval}
+	current := m.config.WorkDuration
+	adapted := adaptInterval(current, outcomeCompliance(m.recentBreaks), bounds)
+	m.recentBreaks = m.recentBreaks[1:]
+	if adapted == current {
+		return
+	}
+
+	// Judge the new interval on fresh breaks only
+	m.recentBreaks = nil
+	m.config = m.config.Clone()
+	m.config.WorkDuration = adapted
+	if m.onAdapted != nil {
+		go m.onAdapted(adapted) // Saves the config, so don't hold the lock
+	}
+}
+
+// refreshAppDuration looks up the per-app work duration of the frontmost
+// app. Must be called with m.mu held.
+func (m *Manager) refreshAppDuration() {
+	m.appDuration = 0
+	if m.apps == nil || len(m.config.PerAppWorkDuration) == 0 {
+		return
+	}
+	bundleID, _ := m.apps.FrontmostApp()
+	m.appDuration = appWorkDuration(bundleID, m.config.PerAppWorkDuration, 0)
+}
+
+// shouldDefer returns whether the due break must wait for a presentation to
+// end or for an app from DeferInApps to leave the front. Must be called
+// with m.mu held.
+func (m *Manager) shouldDefer() bool {
+	return m.presenting() || m.deferForApp(m.clock.Now())
+}
+
+// presenting returns whether the due break must wait for a presentation
+func (m *Manager) presenting() bool {
+	return m.config.DeferBreaksDuringPresentations &&
+		m.presentation != nil &&
+		m.presentation.IsPresenting()
+}
+
+// deferForApp returns whether the due break must wait because an app from
+// DeferInApps is frontmost at now. Like a postponed break, it waits at most
+// MaxAppDeferral after an app first held it back; time spent waiting for a
+// presentation doesn't count. Must be called with m.mu held.
+func (m *Manager) deferForApp(now time.Time) bool {
+	if m.apps == nil || len(m.config.DeferInApps) == 0 {
+		return false
+	}
+	if !m.appDeferSince.IsZero() && now.Sub(m.appDeferSince) >= m.config.MaxAppDeferral {
+		return false
+	}
+	bundleID, _ := m.apps.FrontmostApp()
+	return slices.Contains(m.config.DeferInApps, bundleID)
+}
+
+// deferBreak holds back the due break and checks again later. Unlike a
+// suspension there is no deadline for presentations; the break fires once
+// the presentation ends.
+func (m *Manager) deferBreak() {
+	m.deferredInApp = !m.presenting()
+	if m.deferredInApp && m.appDeferSince.IsZero() {
+		m.appDeferSince = m.clock.Now()
+	}
+	if !m.deferred {
+		m.deferred = true
+		m.emit(EventBreakDeferred)
+		m.notifyStateChange()
+	}
+
+	m.currentTimer = m.clock.AfterFunc(deferCheckInterval, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if m.state == StateRunning && m.deferred {
+			m.triggerBreak()
+		}
+	})
+}
+
+// endSuspension clears a suspension and starts a fresh work interval
+func (m *Manager) endSuspension() {
+	if m.suspendedUntil.IsZero() {
+		return
+	}
+
+	m.suspendedUntil = time.Time{}
+	if m.resumeTimer != nil {
+		m.resumeTimer.Stop()
+		m.resumeTimer = nil
+	}
+
+	// Start a fresh interval so the first break after resuming isn't immediate
+	if m.state == StateRunning {
+		m.workStartTime = m.clock.Now()
+		m.elapsed = 0
+		m.beginInterval()
+		m.scheduleWorkTimer()
+	}
+
+	m.notifyStateChange()
+}
+
+// scheduleBreakWatchdog schedules the check for the break started at
+// started, due breakWatchdogSlack after the break should have ended. Must
+// be called with m.mu held.
+func (m *Manager) scheduleBreakWatchdog(started time.Time) {
+	m.stopBreakWatchdog()
+	wait := breakWatchdogDeadline(started, m.currentBreak.Duration).Sub(m.clock.Now())
+	m.breakWatchdog = m.clock.AfterFunc(wait, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.checkBreakWatchdog(started, m.clock.Now())
+	})
+}
+
+// checkBreakWatchdog completes the break started at started if it is still
+// in progress at now, past its deadline. A break extended in the meantime
+// gets a new check at its new deadline. Must be called with m.mu held.
+func (m *Manager) checkBreakWatchdog(started, now time.Time) {
+	// A later break has its own watchdog
+	if m.state != StateBreakRequired || !m.breakStartTime.Equal(started) {
+		return
+	}
+	if now.Before(breakWatchdogDeadline(started, m.currentBreak.Duration)) {
+		m.scheduleBreakWatchdog(started)
+		return
+	}
+
+	// The overlay never reported back, e.g. because its countdown stopped
+	m.emit(EventBreakWatchdog)
+	m.completeBreak(m.currentBreak.Duration)
+}
+
+// stopBreakWatchdog stops the break watchdog if it is scheduled. Must be
+// called with m.mu held.
+func (m *Manager) stopBreakWatchdog() {
+	if m.breakWatchdog != nil {
+		m.breakWatchdog.Stop()
+		m.breakWatchdog = nil
+	}
+}
+
+// stopCurrentTimer stops the current, pre-break and warning timers if they
+// exist
 func (m *Manager) stopCurrentTimer() {
 	if m.currentTimer != nil {
 		m.currentTimer.Stop()
 		m.currentTimer = nil
 	}
+	if m.preBreakTimer != nil {
+		m.preBreakTimer.Stop()
+		m.preBreakTimer = nil
+	}
+	if m.warningTimer != nil {
+		m.warningTimer.Stop()
+		m.warningTimer = nil
+	}
+}
+
+// cancelAutoResume stops a scheduled automatic resume if there is one
+func (m *Manager) cancelAutoResume() {
+	if m.autoResume != nil {
+		m.autoResume.Stop()
+		m.autoResume = nil
+	}
+	m.autoResumeAt = time.Time{}
 }
 
 // notifyStateChange calls the state change callback if set
 func (m *Manager) notifyStateChange() {
+	m.emit(EventStateChange)
+
 	if m.onStateChange != nil {
 		state := m.state
 		go m.onStateChange(state) // Call in goroutine to avoid blocking
 	}
 }
+
+// emit calls the event callback if set
+func (m *Manager) emit(eventType EventType) {
+	if m.onEvent != nil {
+		m.onEvent(Event{
+			Time:    m.clock.Now(),
+			Type:    eventType,
+			State:   m.state.String(),
+			BreakID: m.currentBreakID,
+		})
+	}
+}
+
+// nextMidnight returns the start of the local day following now
+func nextMidnight(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+}
+
+// breakWatchdogDeadline returns when the watchdog completes a break of
+// duration started at started
+func breakWatchdogDeadline(started time.Time, duration time.Duration) time.Time {
+	return started.Add(duration + breakWatchdogSlack)
+}
+
+// isSuspended returns whether breaks are suspended at now
+func isSuspended(now, suspendedUntil time.Time) bool {
+	return !suspendedUntil.IsZero() && now.Before(suspendedUntil)
+}
+
+// sessionLength returns how long a session started at sessionStart has
+// lasted at now, 0 if no session was started
+func sessionLength(sessionStart, now time.Time) time.Duration {
+	if sessionStart.IsZero() {
+		return 0
+	}
+	return now.Sub(sessionStart)
+}
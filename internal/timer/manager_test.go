@@ -0,0 +1,840 @@
+package timer
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+	"github.com/siegfried/2020rule/internal/stats"
+)
+
+// newTestStore opens a stats store backed by a fresh database in a
+// temporary data directory
+func newTestStore(t *testing.T) *stats.Store {
+	t.Helper()
+	t.Setenv("TWENTY_RULE_DATA_DIR", t.TempDir())
+
+	store, err := stats.NewStore()
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// eventRecorder collects the types of the events a manager emits
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []EventType
+}
+
+// record subscribes the recorder to m's events
+func (r *eventRecorder) record(m *Manager) {
+	m.SetOnEvent(func(e Event) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.events = append(r.events, e.Type)
+	})
+}
+
+// has returns whether an event of eventType was emitted
+func (r *eventRecorder) has(eventType EventType) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Contains(r.events, eventType)
+}
+
+// count returns how many events of eventType were emitted
+func (r *eventRecorder) count(eventType EventType) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, e := range r.events {
+		if e == eventType {
+			n++
+		}
+	}
+	return n
+}
+
+// fakeSuppressor holds back break overlays while suppress is set
+type fakeSuppressor struct {
+	suppress bool
+}
+
+func (f fakeSuppressor) ShouldSuppress() bool { return f.suppress }
+
+// dueNow makes the break of a running manager due
+func dueNow(m *Manager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.triggerBreak()
+}
+
+func TestSuppressedOverlayRecordsSuppressedBreak(t *testing.T) {
+	tests := []struct {
+		name        string
+		suppressor  OverlaySuppressor
+		wantShown   bool
+		wantOutcome stats.BreakOutcome
+		wantEvent   EventType
+	}{
+		{"without suppressor", nil, true, stats.OutcomePending, EventBreakStarted},
+		{"not sharing", fakeSuppressor{suppress: false}, true, stats.OutcomePending, EventBreakStarted},
+		{"sharing", fakeSuppressor{suppress: true}, false, stats.OutcomeSuppressed, EventBreakSuppressed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t)
+			m := NewManager(config.DefaultConfig(), store)
+			m.SetOverlaySuppressor(tt.suppressor)
+			var events eventRecorder
+			events.record(m)
+			shown := false
+			m.SetOnBreakRequired(func(BreakInfo) { shown = true })
+
+			m.Start()
+			defer m.Stop()
+			from := time.Now().Add(-time.Second)
+			dueNow(m)
+
+			if shown != tt.wantShown {
+				t.Errorf("break shown = %t, want %t", shown, tt.wantShown)
+			}
+			if !events.has(tt.wantEvent) {
+				t.Errorf("no %s event emitted", tt.wantEvent)
+			}
+			if events.has(EventBreakSkipped) {
+				t.Error("break was recorded as skipped")
+			}
+
+			counts, err := store.CountBreaksBetween(from, time.Now().Add(time.Second))
+			if err != nil {
+				t.Fatalf("CountBreaksBetween: %v", err)
+			}
+			if counts[tt.wantOutcome] != 1 || len(counts) != 1 {
+				t.Errorf("recorded outcomes %v, want one %s break", counts, tt.wantOutcome)
+			}
+
+			wantState := StateBreakRequired
+			if !tt.wantShown {
+				wantState = StateRunning
+			}
+			if state := m.GetState(); state != wantState {
+				t.Errorf("state = %s, want %s", state, wantState)
+			}
+		})
+	}
+}
+
+func TestWakeAfterSleepingThroughIntervals(t *testing.T) {
+	tests := []struct {
+		name       string
+		catchUp    bool
+		wantState  State
+		wantBreaks int
+	}{
+		{"sleep counts as a break", false, StateRunning, 0},
+		{"catch up with one break", true, StateBreakRequired, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.CatchUpBreaks = tt.catchUp
+			m, clock := newTestManager(t, cfg)
+			var events eventRecorder
+			events.record(m)
+
+			m.Start()
+			clock.Advance(5 * time.Minute)
+			clock.Jump(3*cfg.WorkDuration + 5*time.Minute)
+			m.HandleWake()
+			// Timers that slept through their time fire late
+			clock.Advance(0)
+
+			if state := m.GetState(); state != tt.wantState {
+				t.Errorf("state after wake = %s, want %s", state, tt.wantState)
+			}
+			if n := events.count(EventBreakStarted); n != tt.wantBreaks {
+				t.Errorf("%d breaks after wake, want %d", n, tt.wantBreaks)
+			}
+
+			if tt.catchUp {
+				m.CompleteBreak()
+			}
+			if left := m.GetTimeUntilBreak(); left != cfg.WorkDuration {
+				t.Errorf("next break in %s, want a full interval of %s", left, cfg.WorkDuration)
+			}
+			clock.Advance(cfg.WorkDuration - time.Second)
+			if n := events.count(EventBreakStarted); n != tt.wantBreaks {
+				t.Errorf("%d breaks before the next interval ended, want %d", n, tt.wantBreaks)
+			}
+			clock.Advance(time.Second)
+			if n := events.count(EventBreakStarted); n != tt.wantBreaks+1 {
+				t.Errorf("%d breaks after the next interval, want %d", n, tt.wantBreaks+1)
+			}
+		})
+	}
+}
+
+func TestWakeWithinInterval(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.CatchUpBreaks = true
+	m, clock := newTestManager(t, cfg)
+
+	m.Start()
+	clock.Advance(5 * time.Minute)
+	clock.Jump(5 * time.Minute)
+	m.HandleWake()
+
+	if state := m.GetState(); state != StateRunning {
+		t.Errorf("state after wake = %s, want %s", state, StateRunning)
+	}
+	if left, want := m.GetTimeUntilBreak(), cfg.WorkDuration-10*time.Minute; left != want {
+		t.Errorf("next break in %s, want %s", left, want)
+	}
+}
+
+func TestResumeAfterManualPause(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		paused    time.Duration
+		wantLeft  time.Duration
+	}{
+		{"continues without threshold", 0, time.Hour, 15 * time.Minute},
+		{"continues after a short pause", 10 * time.Minute, 5 * time.Minute, 15 * time.Minute},
+		{"restarts after a long pause", 10 * time.Minute, 30 * time.Minute, 20 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.ResetAfterPauseLongerThan = tt.threshold
+			m, clock := newTestManager(t, cfg)
+
+			m.Start()
+			clock.Advance(5 * time.Minute)
+			m.Pause()
+			clock.Advance(tt.paused)
+			m.Resume()
+
+			if left := m.GetTimeUntilBreak(); left != tt.wantLeft {
+				t.Errorf("next break in %s, want %s", left, tt.wantLeft)
+			}
+		})
+	}
+}
+
+func TestInitialDelay(t *testing.T) {
+	tests := []struct {
+		name  string
+		delay time.Duration
+	}{
+		{"none", 0},
+		{"later", 10 * time.Minute},
+		{"sooner", -5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.InitialDelay = tt.delay
+			m, clock := newTestManager(t, cfg)
+			var events eventRecorder
+			events.record(m)
+
+			m.Start()
+			first := cfg.WorkDuration + tt.delay
+			clock.Advance(first - time.Second)
+			if n := events.count(EventBreakStarted); n != 0 {
+				t.Fatalf("%d breaks before %s, want 0", n, first)
+			}
+			clock.Advance(time.Second)
+			if n := events.count(EventBreakStarted); n != 1 {
+				t.Fatalf("%d breaks after %s, want 1", n, first)
+			}
+
+			m.CompleteBreak()
+			if left := m.GetTimeUntilBreak(); left != cfg.WorkDuration {
+				t.Errorf("second interval of %s, want %s", left, cfg.WorkDuration)
+			}
+		})
+	}
+}
+
+func TestExtendWorkInterval(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.MaxExtension = 10 * time.Minute
+	m, clock := newTestManager(t, cfg)
+	var events eventRecorder
+	events.record(m)
+
+	m.Start()
+	if granted := m.ExtendWorkInterval(5 * time.Minute); granted != 5*time.Minute {
+		t.Errorf("first extension granted %s, want %s", granted, 5*time.Minute)
+	}
+	if granted := m.ExtendWorkInterval(10 * time.Minute); granted != 5*time.Minute {
+		t.Errorf("second extension granted %s, want %s", granted, 5*time.Minute)
+	}
+	if m.CanExtendWorkInterval() {
+		t.Error("interval can still be extended past the cap")
+	}
+
+	clock.Advance(cfg.WorkDuration + cfg.MaxExtension - time.Second)
+	if events.has(EventBreakStarted) {
+		t.Fatal("break started before the extended interval ended")
+	}
+	clock.Advance(time.Second)
+	if !events.has(EventBreakStarted) {
+		t.Fatal("no break after the extended interval")
+	}
+
+	// The next interval has the full cap again
+	m.CompleteBreak()
+	if left := m.GetTimeUntilBreak(); left != cfg.WorkDuration {
+		t.Errorf("next interval of %s, want %s", left, cfg.WorkDuration)
+	}
+	if !m.CanExtendWorkInterval() {
+		t.Error("next interval can't be extended")
+	}
+}
+
+func TestExtendWorkIntervalMidInterval(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDuration = 20 * time.Minute
+	cfg.MaxExtension = 10 * time.Minute
+	m, clock := newTestManager(t, cfg)
+	var events eventRecorder
+	events.record(m)
+
+	m.Start()
+	clock.Advance(15 * time.Minute)
+	if granted := m.ExtendWorkInterval(5 * time.Minute); granted != 5*time.Minute {
+		t.Fatalf("extension granted %s, want %s", granted, 5*time.Minute)
+	}
+	if left := m.GetTimeUntilBreak(); left != 10*time.Minute {
+		t.Errorf("GetTimeUntilBreak() = %s after extending at minute 15, want %s", left, 10*time.Minute)
+	}
+
+	clock.Advance(10*time.Minute - time.Second)
+	if events.has(EventBreakStarted) {
+		t.Fatal("break started before minute 25")
+	}
+	clock.Advance(time.Second)
+	if !events.has(EventBreakStarted) {
+		t.Fatal("no break at minute 25")
+	}
+}
+
+func TestIntervalJitter(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.IntervalJitter = 5 * time.Minute
+	cfg.MaxExtension = 10 * time.Minute
+	m, clock := newTestManager(t, cfg)
+
+	m.Start()
+	first := m.GetTimeUntilBreak()
+
+	// Extending right away keeps the interval's jitter
+	m.ExtendWorkInterval(time.Minute)
+	if left := m.GetTimeUntilBreak(); left != first+time.Minute {
+		t.Errorf("GetTimeUntilBreak() = %s after extending, want %s", left, first+time.Minute)
+	}
+
+	// The next interval draws its own
+	clock.Advance(first + time.Minute)
+	m.CompleteBreak()
+	if left := m.GetTimeUntilBreak(); left == first {
+		t.Errorf("next interval kept the jitter of the last one (%s)", left)
+	}
+}
+
+func TestConsecutiveCompletionsIgnoreManualBreaks(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m, clock := newTestManager(t, cfg)
+
+	m.Start()
+	clock.Advance(cfg.WorkDuration)
+	m.CompleteBreak()
+	if n := m.GetConsecutiveCompletions(); n != 1 {
+		t.Fatalf("%d completions in a row, want 1", n)
+	}
+
+	m.TriggerBreakNow()
+	m.CompleteBreak()
+	if n := m.GetConsecutiveCompletions(); n != 1 {
+		t.Errorf("%d completions in a row after a manual break, want 1", n)
+	}
+
+	m.TriggerBreakNow()
+	m.SkipBreak()
+	if n := m.GetConsecutiveCompletions(); n != 1 {
+		t.Errorf("%d completions in a row after skipping a manual break, want 1", n)
+	}
+
+	clock.Advance(cfg.WorkDuration)
+	m.SkipBreak()
+	if n := m.GetConsecutiveCompletions(); n != 0 {
+		t.Errorf("%d completions in a row after skipping a break, want 0", n)
+	}
+}
+
+// fakePresentation reports a presentation while presenting is set
+type fakePresentation struct {
+	presenting bool
+}
+
+func (f *fakePresentation) IsPresenting() bool { return f.presenting }
+
+// fakeApps reports bundleID as the frontmost app
+type fakeApps struct {
+	bundleID string
+}
+
+func (f *fakeApps) FrontmostApp() (string, bool) { return f.bundleID, false }
+
+func TestAppDeferralAfterPresentation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.DeferBreaksDuringPresentations = true
+	cfg.DeferInApps = []string{"com.apple.FinalCut"}
+	cfg.MaxAppDeferral = 10 * time.Minute
+	m, clock := newTestManager(t, cfg)
+	presentation := &fakePresentation{presenting: true}
+	apps := &fakeApps{bundleID: "com.apple.FinalCut"}
+	m.SetPresentationDetector(presentation)
+	m.SetAppSource(apps)
+	var events eventRecorder
+	events.record(m)
+
+	m.Start()
+	clock.Advance(cfg.WorkDuration)
+	clock.Advance(30 * time.Minute)
+	if !m.IsDeferred() || m.IsDeferredInApp() {
+		t.Fatal("break isn't waiting for the presentation")
+	}
+
+	// The presentation ends, but the app still holds the break back for
+	// its own MaxAppDeferral
+	presentation.presenting = false
+	clock.Advance(deferCheckInterval)
+	if !m.IsDeferredInApp() {
+		t.Fatal("break isn't waiting for the app after the presentation")
+	}
+	clock.Advance(cfg.MaxAppDeferral - 2*deferCheckInterval)
+	if events.has(EventBreakStarted) {
+		t.Fatal("break started before the app deferral ran out")
+	}
+	clock.Advance(2 * deferCheckInterval)
+	if !events.has(EventBreakStarted) {
+		t.Fatal("break didn't start after the app deferral ran out")
+	}
+	if n := events.count(EventBreakDeferred); n != 1 {
+		t.Errorf("%d deferred events, want 1", n)
+	}
+
+	// The next break gets the full app deferral again
+	m.CompleteBreak()
+	clock.Advance(cfg.WorkDuration + cfg.MaxAppDeferral - deferCheckInterval)
+	if n := events.count(EventBreakStarted); n != 1 {
+		t.Errorf("%d breaks started during the second app deferral, want 1", n)
+	}
+}
+
+func TestCadenceFixedWhenIntervalBegins(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WorkDuration = 20 * time.Minute
+	cfg.CadenceSchedule = []config.CadenceWindow{{StartHour: 9, EndHour: 10, WorkDuration: 30 * time.Minute}}
+	m, clock := newTestManager(t, cfg)
+	var events eventRecorder
+	events.record(m)
+
+	// Begin at 09:50 with the morning cadence and work past 10:00
+	clock.Advance(50 * time.Minute)
+	m.Start()
+	clock.Advance(15 * time.Minute)
+	if left := m.GetTimeUntilBreak(); left != 15*time.Minute {
+		t.Errorf("GetTimeUntilBreak() = %s after the cadence changed, want %s", left, 15*time.Minute)
+	}
+
+	clock.Advance(15 * time.Minute)
+	if !events.has(EventBreakStarted) {
+		t.Fatal("no break after the 30 minute interval")
+	}
+
+	// The next interval takes the cadence of its own start
+	m.CompleteBreak()
+	if left := m.GetTimeUntilBreak(); left != cfg.WorkDuration {
+		t.Errorf("next interval of %s, want %s", left, cfg.WorkDuration)
+	}
+}
+
+// fakeIdle reports the user idle for idleFor
+type fakeIdle struct {
+	idleFor time.Duration
+}
+
+func (f fakeIdle) IdleFor() time.Duration { return f.idleFor }
+
+func TestIdleAtTrigger(t *testing.T) {
+	tests := []struct {
+		name      string
+		idleFor   time.Duration
+		wantShown bool
+		wantEvent EventType
+	}{
+		{"active", 0, true, EventBreakStarted},
+		{"idle below the threshold", 4 * time.Minute, true, EventBreakStarted},
+		{"idle for the threshold", 5 * time.Minute, false, EventBreakIdle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.IdleThreshold = 5 * time.Minute
+			m, clock := newTestManager(t, cfg)
+			m.SetIdleDetector(fakeIdle{idleFor: tt.idleFor})
+			var events eventRecorder
+			events.record(m)
+			shown := false
+			m.SetOnBreakRequired(func(BreakInfo) { shown = true })
+
+			m.Start()
+			clock.Advance(cfg.WorkDuration)
+
+			if shown != tt.wantShown {
+				t.Errorf("break shown = %t, want %t", shown, tt.wantShown)
+			}
+			if !events.has(tt.wantEvent) {
+				t.Errorf("no %s event emitted", tt.wantEvent)
+			}
+			if !tt.wantShown {
+				if left := m.GetTimeUntilBreak(); left != cfg.WorkDuration {
+					t.Errorf("next interval of %s after the idle break, want %s", left, cfg.WorkDuration)
+				}
+			}
+		})
+	}
+}
+
+func TestSuspendUntilTomorrow(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.CadenceSchedule = []config.CadenceWindow{{StartHour: 0, EndHour: 6, WorkDuration: 30 * time.Minute}}
+	m, clock := newTestManager(t, cfg)
+	var events eventRecorder
+	events.record(m)
+
+	m.Start()
+	clock.Advance(5 * time.Minute)
+	m.SuspendUntilTomorrow()
+	if !m.IsSuspended() {
+		t.Fatal("not suspended")
+	}
+
+	// No break is due for the rest of the day, 09:05 to midnight
+	clock.Advance(15*time.Hour - 5*time.Minute - time.Second)
+	if events.has(EventBreakStarted) {
+		t.Fatal("break started while suspended")
+	}
+	if !m.IsSuspended() {
+		t.Error("suspension ended before midnight")
+	}
+
+	// At midnight breaks are back with a fresh interval of the night cadence
+	clock.Advance(time.Second)
+	if m.IsSuspended() {
+		t.Fatal("still suspended after midnight")
+	}
+	if left := m.GetTimeUntilBreak(); left != 30*time.Minute {
+		t.Errorf("first interval after midnight of %s, want %s", left, 30*time.Minute)
+	}
+	clock.Advance(30 * time.Minute)
+	if !events.has(EventBreakStarted) {
+		t.Error("no break after the suspension ended")
+	}
+}
+
+func TestCancelSuspension(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m, clock := newTestManager(t, cfg)
+	var events eventRecorder
+	events.record(m)
+
+	m.Start()
+	m.SuspendUntilTomorrow()
+	clock.Advance(time.Hour)
+	m.CancelSuspension()
+	if m.IsSuspended() {
+		t.Fatal("still suspended after CancelSuspension")
+	}
+
+	clock.Advance(cfg.WorkDuration)
+	if !events.has(EventBreakStarted) {
+		t.Error("no break after the suspension was cancelled")
+	}
+}
+
+// fakePower reports the Mac on battery while onBattery is set
+type fakePower struct {
+	onBattery bool
+}
+
+func (f *fakePower) OnBattery() bool { return f.onBattery }
+
+func TestSuppressedOnBattery(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.PauseOnBattery = true
+	m, clock := newTestManager(t, cfg)
+	power := &fakePower{onBattery: true}
+	m.SetPowerSource(power)
+	var events eventRecorder
+	events.record(m)
+
+	m.Start()
+	if !m.IsSuppressedOnBattery() {
+		t.Fatal("not suppressed on battery")
+	}
+	clock.Advance(cfg.WorkDuration)
+	if events.has(EventBreakStarted) {
+		t.Fatal("break started on battery")
+	}
+	if left := m.GetTimeUntilBreak(); left != cfg.WorkDuration {
+		t.Errorf("interval after the held break of %s, want %s", left, cfg.WorkDuration)
+	}
+
+	// Back on AC the next break is due as usual
+	power.onBattery = false
+	if m.IsSuppressedOnBattery() {
+		t.Error("suppressed on AC")
+	}
+	clock.Advance(cfg.WorkDuration)
+	if !events.has(EventBreakStarted) {
+		t.Fatal("no break on AC")
+	}
+
+	// Unplugging again holds the following break
+	m.CompleteBreak()
+	power.onBattery = true
+	clock.Advance(cfg.WorkDuration)
+	if n := events.count(EventBreakStarted); n != 1 {
+		t.Errorf("%d breaks started, want 1 after unplugging again", n)
+	}
+}
+
+func TestBatteryIgnoredWithoutPauseOnBattery(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.PauseOnBattery = false
+	m, clock := newTestManager(t, cfg)
+	m.SetPowerSource(&fakePower{onBattery: true})
+	var events eventRecorder
+	events.record(m)
+
+	m.Start()
+	if m.IsSuppressedOnBattery() {
+		t.Error("suppressed on battery without PauseOnBattery")
+	}
+	clock.Advance(cfg.WorkDuration)
+	if !events.has(EventBreakStarted) {
+		t.Error("no break on battery without PauseOnBattery")
+	}
+}
+
+func TestProgressAndBreakDue(t *testing.T) {
+	cfg := config.DefaultConfig()
+	m, clock := newTestManager(t, cfg)
+
+	check := func(when string, wantProgress float64, wantDue bool) {
+		t.Helper()
+		if got := m.GetProgress(); got != wantProgress {
+			t.Errorf("GetProgress() %s = %v, want %v", when, got, wantProgress)
+		}
+		if got := m.IsBreakDue(); got != wantDue {
+			t.Errorf("IsBreakDue() %s = %t, want %t", when, got, wantDue)
+		}
+	}
+
+	m.Start()
+	check("at the start", 0, false)
+	clock.Advance(5 * time.Minute)
+	check("a quarter in", 0.25, false)
+
+	// Progress stands still while paused
+	m.Pause()
+	clock.Advance(10 * time.Minute)
+	check("while paused", 0.25, false)
+	m.Resume()
+	clock.Advance(5 * time.Minute)
+	check("after resuming", 0.5, false)
+
+	clock.Advance(10 * time.Minute)
+	check("during the break", 1, true)
+	clock.Advance(5 * time.Second)
+	check("later in the break", 1, true)
+
+	m.CompleteBreak()
+	check("after the break", 0, false)
+}
+
+func TestSilentMode(t *testing.T) {
+	store := newTestStore(t)
+	cfg := config.DefaultConfig()
+	m := NewManager(cfg, store)
+	clock := newFakeClock()
+	m.clock = clock
+	t.Cleanup(m.Stop)
+	var events eventRecorder
+	events.record(m)
+	shown := 0
+	m.SetOnBreakRequired(func(BreakInfo) { shown++ })
+
+	from := time.Now().Add(-time.Second)
+	m.Start()
+	m.SetSilent(true)
+	if !m.IsSilent() {
+		t.Fatal("not silent after SetSilent(true)")
+	}
+
+	// The due break is only counted and the next interval starts
+	clock.Advance(cfg.WorkDuration)
+	if shown != 0 || events.has(EventBreakStarted) {
+		t.Error("break shown in silent mode")
+	}
+	if !events.has(EventBreakSuppressed) {
+		t.Error("no suppressed event in silent mode")
+	}
+	if state := m.GetState(); state != StateRunning {
+		t.Errorf("state = %s in silent mode, want %s", state, StateRunning)
+	}
+	if left := m.GetTimeUntilBreak(); left != cfg.WorkDuration {
+		t.Errorf("interval after the silent break of %s, want %s", left, cfg.WorkDuration)
+	}
+
+	counts, err := store.CountBreaksBetween(from, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("CountBreaksBetween: %v", err)
+	}
+	if counts[stats.OutcomeSuppressed] != 1 || len(counts) != 1 {
+		t.Errorf("recorded outcomes %v, want one suppressed break", counts)
+	}
+
+	// Turning it off shows the next break again
+	m.SetSilent(false)
+	clock.Advance(cfg.WorkDuration)
+	if shown != 1 || !events.has(EventBreakStarted) {
+		t.Errorf("%d breaks shown after silent mode ended, want 1", shown)
+	}
+
+	// A break already showing isn't affected
+	m.SetSilent(true)
+	if !m.IsBreakDue() {
+		t.Error("silent mode ended the break showing")
+	}
+}
+
+func TestBreakWatchdog(t *testing.T) {
+	tests := []struct {
+		name     string
+		extend   time.Duration
+		complete bool
+		wantFire bool
+	}{
+		{"overlay never reports back", 0, false, true},
+		{"extended break", time.Minute, false, true},
+		{"completed break", 0, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			m, clock := newTestManager(t, cfg)
+			var events eventRecorder
+			events.record(m)
+
+			m.Start()
+			clock.Advance(cfg.WorkDuration)
+			if !m.IsBreakDue() {
+				t.Fatal("no break due")
+			}
+			deadline := m.GetBreakTimeRemaining() + breakWatchdogSlack + tt.extend
+			if tt.extend > 0 {
+				// Extending within the slack moves the deadline
+				clock.Advance(breakWatchdogSlack)
+				m.ExtendBreak(tt.extend)
+				deadline -= breakWatchdogSlack
+			}
+			if tt.complete {
+				m.CompleteBreak()
+			}
+
+			clock.Advance(deadline - time.Second)
+			if events.has(EventBreakWatchdog) {
+				t.Fatal("watchdog fired before the deadline")
+			}
+			clock.Advance(time.Second)
+			if got := events.has(EventBreakWatchdog); got != tt.wantFire {
+				t.Fatalf("watchdog fired = %t at the deadline, want %t", got, tt.wantFire)
+			}
+			if m.IsBreakDue() {
+				t.Error("break still due after the deadline")
+			}
+			if tt.wantFire {
+				if n := events.count(EventBreakCompleted); n != 1 {
+					t.Errorf("%d completed breaks after the watchdog, want 1", n)
+				}
+				if left := m.GetTimeUntilBreak(); left != cfg.WorkDuration {
+					t.Errorf("interval after the watchdog of %s, want %s", left, cfg.WorkDuration)
+				}
+			}
+		})
+	}
+}
+
+func TestPostponeUpcomingBreakFromWarning(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.PreBreakWarning = time.Minute
+	m, clock := newTestManager(t, cfg)
+	var events eventRecorder
+	events.record(m)
+	warnings := make(chan time.Duration, 4)
+	m.SetOnBreakWarning(func(left time.Duration) { warnings <- left })
+
+	m.Start()
+	clock.Advance(cfg.WorkDuration - 2*time.Minute)
+	if m.PostponeUpcomingBreak(5 * time.Minute) {
+		t.Fatal("postponed a break before its warning")
+	}
+
+	clock.Advance(time.Minute)
+	select {
+	case left := <-warnings:
+		if left != cfg.PreBreakWarning {
+			t.Errorf("warning %s before the break, want %s", left, cfg.PreBreakWarning)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no warning before the break")
+	}
+
+	if !m.PostponeUpcomingBreak(5 * time.Minute) {
+		t.Fatal("break wasn't postponed from its warning")
+	}
+	if !events.has(EventBreakPostponed) {
+		t.Error("no postponed event")
+	}
+	if left := m.GetTimeUntilBreak(); left != 5*time.Minute {
+		t.Errorf("next break in %s after postponing, want %s", left, 5*time.Minute)
+	}
+
+	// The break doesn't start when it was due but after the postponement
+	clock.Advance(time.Minute)
+	if events.has(EventBreakStarted) {
+		t.Fatal("postponed break started when it was originally due")
+	}
+	clock.Advance(4 * time.Minute)
+	if !events.has(EventBreakStarted) {
+		t.Error("no break after the postponement")
+	}
+}
@@ -0,0 +1,215 @@
+package timer
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+// defaultWorkdayEndHour is the local hour after which no more breaks are
+// expected without a reminders window
+const defaultWorkdayEndHour = 18
+
+// isWeekend returns whether t falls on a Saturday or Sunday
+func isWeekend(t time.Time) bool {
+	weekday := t.Weekday()
+	return weekday == time.Saturday || weekday == time.Sunday
+}
+
+// cadenceAt returns the work duration of the first cadence window containing
+// now's hour, or fallback if none does
+func cadenceAt(now time.Time, schedule []config.CadenceWindow, fallback time.Duration) time.Duration {
+	hour := now.Hour()
+	for _, window := range schedule {
+		if hour >= window.StartHour && hour < window.EndHour {
+			return window.WorkDuration
+		}
+	}
+	return fallback
+}
+
+// appWorkDuration returns the work duration configured for the app with
+// bundleID, or fallback if the app has none
+func appWorkDuration(bundleID string, perApp map[string]time.Duration, fallback time.Duration) time.Duration {
+	if duration, ok := perApp[bundleID]; ok && bundleID != "" {
+		return duration
+	}
+	return fallback
+}
+
+// jitterOffset returns a random offset between -jitter and jitter drawn
+// uniformly from r, so jittered intervals average to base. The offset never
+// takes more than half of base away.
+func jitterOffset(r *rand.Rand, base, jitter time.Duration) time.Duration {
+	jitter = min(jitter, base/2)
+	if jitter <= 0 {
+		return 0
+	}
+	return time.Duration(r.Int64N(int64(2*jitter)+1)) - jitter
+}
+
+// effectiveConfig returns the configuration that applies at now. A weekend
+// work duration takes precedence on weekends, otherwise the cadence schedule
+// picks the work duration. cfg itself is not modified.
+func effectiveConfig(now time.Time, cfg *config.Config) *config.Config {
+	workDuration := cfg.WeekendWorkDuration
+	if !isWeekend(now) || workDuration <= 0 {
+		workDuration = cadenceAt(now, cfg.CadenceSchedule, cfg.WorkDuration)
+	}
+	if workDuration == cfg.WorkDuration {
+		return cfg
+	}
+
+	effective := cfg.Clone()
+	effective.WorkDuration = workDuration
+	return effective
+}
+
+// breaksEnabledAt returns whether breaks should be triggered at now
+func breaksEnabledAt(now time.Time, cfg *config.Config) bool {
+	if isWeekend(now) && !cfg.WeekendBreaksEnabled {
+		return false
+	}
+	return remindersActiveAt(now, cfg)
+}
+
+// remindersActiveAt returns whether now lies within the daily reminders
+// window from StartRemindersAt to StopRemindersAt. Without a window
+// reminders are active all day.
+func remindersActiveAt(now time.Time, cfg *config.Config) bool {
+	if cfg.StartRemindersAt == "" || cfg.StopRemindersAt == "" {
+		return true
+	}
+	startHour, startMinute, err := config.ParseTimeOfDay(cfg.StartRemindersAt)
+	if err != nil {
+		return true
+	}
+	stopHour, stopMinute, err := config.ParseTimeOfDay(cfg.StopRemindersAt)
+	if err != nil {
+		return true
+	}
+	start := time.Duration(startHour)*time.Hour + time.Duration(startMinute)*time.Minute
+	end := time.Duration(stopHour)*time.Hour + time.Duration(stopMinute)*time.Minute
+	return withinActiveWindow(now, start, end)
+}
+
+// ExpectedRemainingBreaks estimates how many breaks are still due today at
+// now, assuming continuous work until the reminders window closes. Each
+// interval takes the work duration that applies when it starts, so the
+// weekend schedule and cadence windows are respected.
+func ExpectedRemainingBreaks(now time.Time, cfg *config.Config) int {
+	if !breaksEnabledAt(now, cfg) {
+		return 0
+	}
+
+	end := workdayEnd(now, cfg)
+	count := 0
+	for t := now; ; count++ {
+		workDuration := effectiveConfig(t, cfg).WorkDuration
+		if workDuration <= 0 || t.Add(workDuration).After(end) {
+			return count
+		}
+		t = t.Add(workDuration)
+	}
+}
+
+// workdayEnd returns when the workday around now ends: at the next
+// StopRemindersAt, but no later than midnight, or at defaultWorkdayEndHour
+// without a reminders window
+func workdayEnd(now time.Time, cfg *config.Config) time.Time {
+	year, month, day := now.Date()
+	end := time.Date(year, month, day, defaultWorkdayEndHour, 0, 0, 0, now.Location())
+	if cfg.StartRemindersAt == "" || cfg.StopRemindersAt == "" {
+		return end
+	}
+	stopHour, stopMinute, err := config.ParseTimeOfDay(cfg.StopRemindersAt)
+	if err != nil {
+		return end
+	}
+
+	end = time.Date(year, month, day, stopHour, stopMinute, 0, 0, now.Location())
+	if !end.After(now) {
+		end = end.AddDate(0, 0, 1)
+	}
+	midnight := time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+	if end.After(midnight) {
+		return midnight
+	}
+	return end
+}
+
+// withinActiveWindow returns whether the time of day of now lies in
+// [start, end), both given as the time since midnight. A window whose end
+// is before its start runs overnight.
+func withinActiveWindow(now time.Time, start, end time.Duration) bool {
+	timeOfDay := time.Duration(now.Hour())*time.Hour +
+		time.Duration(now.Minute())*time.Minute +
+		time.Duration(now.Second())*time.Second
+	if start <= end {
+		return timeOfDay >= start && timeOfDay < end
+	}
+	return timeOfDay >= start || timeOfDay < end
+}
+
+// suppressOnBattery returns whether breaks are suppressed given the power source
+func suppressOnBattery(cfg *config.Config, onBattery bool) bool {
+	return cfg.PauseOnBattery && onBattery
+}
+
+// workProgress returns elapsed as a fraction of total, clamped to 0..1
+func workProgress(elapsed, total time.Duration) float64 {
+	if total <= 0 {
+		return 1
+	}
+	progress := float64(elapsed) / float64(total)
+	return min(max(progress, 0), 1)
+}
+
+// nextBreakAt returns when a work interval of workDuration that started at
+// start, with elapsed already worked before start, ends
+func nextBreakAt(start time.Time, elapsed, workDuration time.Duration) time.Time {
+	return start.Add(workDuration - elapsed)
+}
+
+// pauseRestartsInterval returns whether a manual pause of paused restarts
+// the work interval: it must be longer than threshold. Without a threshold
+// the interval always continues.
+func pauseRestartsInterval(paused, threshold time.Duration) bool {
+	return threshold > 0 && paused > threshold
+}
+
+// grantExtension returns how much of a requested extension d fits into the
+// cap, given the extension already granted in this interval
+func grantExtension(granted, d, limit time.Duration) time.Duration {
+	if d <= 0 || granted >= limit {
+		return 0
+	}
+	return min(d, limit-granted)
+}
+
+// wakeAction is what the timer does after the Mac woke from sleep
+type wakeAction int
+
+const (
+	// wakeContinue keeps the running work interval
+	wakeContinue wakeAction = iota
+	// wakeRestart starts the work interval over, counting the sleep as a break
+	wakeRestart
+	// wakeBreak takes a single break for all intervals that passed in sleep
+	wakeBreak
+)
+
+// wakeActionFor decides what happens at wake given the wall-clock time
+// worked in the interval, sleep included. However many intervals passed,
+// at most one break is taken.
+func wakeActionFor(worked, workDuration time.Duration, catchUp bool) wakeAction {
+	switch {
+	case worked < workDuration:
+		return wakeContinue
+	case catchUp:
+		return wakeBreak
+	default:
+		return wakeRestart
+	}
+}
@@ -0,0 +1,270 @@
+package timer
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/siegfried/2020rule/internal/config"
+)
+
+func TestWakeActionFor(t *testing.T) {
+	const work = 20 * time.Minute
+
+	tests := []struct {
+		name    string
+		worked  time.Duration
+		catchUp bool
+		want    wakeAction
+	}{
+		{"interval still running", 19 * time.Minute, false, wakeContinue},
+		{"interval still running with catch up", 19 * time.Minute, true, wakeContinue},
+		{"interval ran out", work, false, wakeRestart},
+		{"interval ran out with catch up", work, true, wakeBreak},
+		{"many intervals ran out", 5 * work, false, wakeRestart},
+		{"many intervals ran out with catch up", 5 * work, true, wakeBreak},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wakeActionFor(tt.worked, work, tt.catchUp); got != tt.want {
+				t.Errorf("wakeActionFor(%s) = %d, want %d", tt.worked, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPauseRestartsInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		paused    time.Duration
+		threshold time.Duration
+		want      bool
+	}{
+		{"without threshold", 10 * time.Hour, 0, false},
+		{"shorter than threshold", 5 * time.Minute, 10 * time.Minute, false},
+		{"exactly the threshold", 10 * time.Minute, 10 * time.Minute, false},
+		{"longer than threshold", 11 * time.Minute, 10 * time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pauseRestartsInterval(tt.paused, tt.threshold); got != tt.want {
+				t.Errorf("pauseRestartsInterval(%s, %s) = %t, want %t", tt.paused, tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCadenceAt(t *testing.T) {
+	schedule := []config.CadenceWindow{
+		{StartHour: 9, EndHour: 12, WorkDuration: 25 * time.Minute},
+		{StartHour: 11, EndHour: 17, WorkDuration: 30 * time.Minute},
+	}
+	const fallback = 20 * time.Minute
+
+	tests := []struct {
+		name string
+		hour int
+		want time.Duration
+	}{
+		{"before the first window", 8, fallback},
+		{"start is inclusive", 9, 25 * time.Minute},
+		{"first matching window wins", 11, 25 * time.Minute},
+		{"end is exclusive", 12, 30 * time.Minute},
+		{"after the last window", 17, fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2025, time.June, 4, tt.hour, 30, 0, 0, time.Local)
+			if got := cadenceAt(now, schedule, fallback); got != tt.want {
+				t.Errorf("cadenceAt(%02d:30) = %s, want %s", tt.hour, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveConfigCadence(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.CadenceSchedule = []config.CadenceWindow{{StartHour: 9, EndHour: 12, WorkDuration: 25 * time.Minute}}
+
+	morning := time.Date(2025, time.June, 4, 10, 0, 0, 0, time.Local)
+	if got := effectiveConfig(morning, cfg).WorkDuration; got != 25*time.Minute {
+		t.Errorf("work duration in the window = %s, want %s", got, 25*time.Minute)
+	}
+	if cfg.WorkDuration == 25*time.Minute {
+		t.Error("effectiveConfig modified the config")
+	}
+
+	afternoon := time.Date(2025, time.June, 4, 14, 0, 0, 0, time.Local)
+	if got := effectiveConfig(afternoon, cfg); got != cfg {
+		t.Error("effectiveConfig copied the config outside the schedule")
+	}
+}
+
+func TestJitterOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    time.Duration
+		jitter  time.Duration
+		wantMax time.Duration
+	}{
+		{"no jitter", 20 * time.Minute, 0, 0},
+		{"within the jitter", 20 * time.Minute, 3 * time.Minute, 3 * time.Minute},
+		{"capped at half the base", 20 * time.Minute, 15 * time.Minute, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(1, 2))
+			var sum time.Duration
+			const draws = 10000
+			for range draws {
+				offset := jitterOffset(r, tt.base, tt.jitter)
+				if offset < -tt.wantMax || offset > tt.wantMax {
+					t.Fatalf("jitterOffset() = %s, want within ±%s", offset, tt.wantMax)
+				}
+				sum += offset
+			}
+			// Jittered intervals average to the base
+			if mean := sum / draws; mean.Abs() > tt.wantMax/20 {
+				t.Errorf("mean offset = %s, want about 0", mean)
+			}
+		})
+	}
+}
+
+func TestWithinActiveWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, time.June, 4, hour, minute, 0, 0, time.Local)
+	}
+	const (
+		nine   = 9 * time.Hour
+		five   = 17 * time.Hour
+		ten    = 22 * time.Hour
+		six    = 6 * time.Hour
+		minute = time.Minute
+	)
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		start, end time.Duration
+		want       bool
+	}{
+		{"before the day window", at(8, 59), nine, five, false},
+		{"start is inclusive", at(9, 0), nine, five, true},
+		{"inside the day window", at(12, 30), nine, five, true},
+		{"end is exclusive", at(17, 0), nine, five, false},
+		{"overnight before midnight", at(23, 0), ten, six, true},
+		{"overnight after midnight", at(5, 59), ten, six, true},
+		{"outside the overnight window", at(12, 0), ten, six, false},
+		{"overnight end is exclusive", at(6, 0), ten, six, false},
+		{"empty window", at(9, 0), nine, nine, false},
+		{"seconds count", at(16, 59).Add(59 * time.Second), nine, five - minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withinActiveWindow(tt.now, tt.start, tt.end); got != tt.want {
+				t.Errorf("withinActiveWindow(%s, %s, %s) = %t, want %t", tt.now.Format("15:04:05"), tt.start, tt.end, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemindersActiveAt(t *testing.T) {
+	cfg := config.DefaultConfig()
+	evening := time.Date(2025, time.June, 4, 20, 0, 0, 0, time.Local)
+	if !remindersActiveAt(evening, cfg) {
+		t.Error("reminders inactive without a window")
+	}
+
+	cfg.StartRemindersAt = "09:00"
+	cfg.StopRemindersAt = "18:00"
+	if remindersActiveAt(evening, cfg) {
+		t.Error("reminders active after the window")
+	}
+}
+
+func TestGrantExtension(t *testing.T) {
+	const limit = 15 * time.Minute
+
+	tests := []struct {
+		name    string
+		granted time.Duration
+		d       time.Duration
+		want    time.Duration
+	}{
+		{"first extension", 0, 5 * time.Minute, 5 * time.Minute},
+		{"fits under the cap", 5 * time.Minute, 10 * time.Minute, 10 * time.Minute},
+		{"cut to the cap", 10 * time.Minute, 10 * time.Minute, 5 * time.Minute},
+		{"cap reached", limit, 5 * time.Minute, 0},
+		{"nothing requested", 0, 0, 0},
+		{"negative request", 0, -5 * time.Minute, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grantExtension(tt.granted, tt.d, limit); got != tt.want {
+				t.Errorf("grantExtension(%s, %s) = %s, want %s", tt.granted, tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpectedRemainingBreaks(t *testing.T) {
+	wednesday := func(hour, minute int) time.Time {
+		return time.Date(2025, time.June, 4, hour, minute, 0, 0, time.Local)
+	}
+	saturday := time.Date(2025, time.June, 7, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		change func(cfg *config.Config)
+		want   int
+	}{
+		{"until the default workday end", wednesday(9, 0), nil, 27},
+		{"last interval ending at the workday end", wednesday(17, 40), nil, 1},
+		{"interval past the workday end", wednesday(17, 50), nil, 0},
+		{"after the workday", wednesday(19, 0), nil, 0},
+		{"until stop reminders at", wednesday(9, 0), func(cfg *config.Config) {
+			cfg.StartRemindersAt = "08:00"
+			cfg.StopRemindersAt = "12:00"
+		}, 9},
+		{"outside the reminders window", wednesday(13, 0), func(cfg *config.Config) {
+			cfg.StartRemindersAt = "08:00"
+			cfg.StopRemindersAt = "12:00"
+		}, 0},
+		{"overnight window counts until midnight", wednesday(23, 0), func(cfg *config.Config) {
+			cfg.StartRemindersAt = "20:00"
+			cfg.StopRemindersAt = "02:00"
+		}, 3},
+		{"weekend without breaks", saturday, func(cfg *config.Config) {
+			cfg.WeekendBreaksEnabled = false
+		}, 0},
+		{"weekend work duration", saturday, func(cfg *config.Config) {
+			cfg.WeekendWorkDuration = 30 * time.Minute
+		}, 18},
+		{"weekday ignores the weekend work duration", wednesday(9, 0), func(cfg *config.Config) {
+			cfg.WeekendWorkDuration = 30 * time.Minute
+		}, 27},
+		{"cadence window", wednesday(9, 0), func(cfg *config.Config) {
+			cfg.CadenceSchedule = []config.CadenceWindow{{StartHour: 9, EndHour: 12, WorkDuration: 30 * time.Minute}}
+		}, 6 + 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			if tt.change != nil {
+				tt.change(cfg)
+			}
+			if got := ExpectedRemainingBreaks(tt.now, cfg); got != tt.want {
+				t.Errorf("ExpectedRemainingBreaks(%s) = %d, want %d", tt.now.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
@@ -1,26 +1,62 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/caseymrm/menuet"
+	"github.com/siegfried/2020rule/internal/config"
 	"github.com/siegfried/2020rule/internal/stats"
 	"github.com/siegfried/2020rule/internal/timer"
 )
 
+// Break duration histogram shown in the statistics menu
+const (
+	histogramBucketSecs = 5
+	histogramBarWidth   = 10
+)
+
+// shortPause is the length of the pause offered with automatic resume
+const shortPause = 30 * time.Minute
+
+// workExtension is how much the "+5 Min" item extends the work interval
+const workExtension = 5 * time.Minute
+
+// recoveryNudgeMargin is how many percentage points yesterday's compliance
+// must fall short of the goal before the menu encourages doing better
+const recoveryNudgeMargin = 20.0
+
+// ratingTrendThreshold is how much the average rating must change to count as a trend
+const ratingTrendThreshold = 0.1
+
 // MenuBar manages the menu bar application UI
 type MenuBar struct {
+	config       *config.Config
 	timerManager *timer.Manager
 	statsStore   *stats.Store
 	onPause      func()
+	onPauseFor   func(time.Duration)
 	onResume     func()
+	onSuspend    func()
+	onUnsuspend  func()
+	onExtend     func()
+	onExtendWork func(time.Duration)
+	onBreakNow   func()
+	onSilent     func(bool)
+	onRebuild    func()
+	onOpenData   func()
+	onDiagnose   func()
 	onQuit       func()
 }
 
 // NewMenuBar creates a new menu bar UI
-func NewMenuBar(tm *timer.Manager, store *stats.Store) *MenuBar {
+func NewMenuBar(cfg *config.Config, tm *timer.Manager, store *stats.Store) *MenuBar {
 	return &MenuBar{
+		config:       cfg,
 		timerManager: tm,
 		statsStore:   store,
 	}
@@ -31,16 +67,71 @@ func (m *MenuBar) SetOnPause(callback func()) {
 	m.onPause = callback
 }
 
+// SetOnPauseFor sets the callback for pausing with automatic resume
+func (m *MenuBar) SetOnPauseFor(callback func(time.Duration)) {
+	m.onPauseFor = callback
+}
+
 // SetOnResume sets the callback for resume action
 func (m *MenuBar) SetOnResume(callback func()) {
 	m.onResume = callback
 }
 
+// SetOnSuspend sets the callback for suspending breaks until tomorrow
+func (m *MenuBar) SetOnSuspend(callback func()) {
+	m.onSuspend = callback
+}
+
+// SetOnUnsuspend sets the callback for re-enabling suspended breaks
+func (m *MenuBar) SetOnUnsuspend(callback func()) {
+	m.onUnsuspend = callback
+}
+
+// SetOnExtendWork sets the callback for extending the running work interval
+func (m *MenuBar) SetOnExtendWork(callback func(time.Duration)) {
+	m.onExtendWork = callback
+}
+
+// SetOnBreakNow sets the callback for starting a break right away
+func (m *MenuBar) SetOnBreakNow(callback func()) {
+	m.onBreakNow = callback
+}
+
+// SetOnSilent sets the callback for turning silent mode on or off
+func (m *MenuBar) SetOnSilent(callback func(bool)) {
+	m.onSilent = callback
+}
+
+// SetOnRebuildStats sets the callback for recalculating the daily statistics
+func (m *MenuBar) SetOnRebuildStats(callback func()) {
+	m.onRebuild = callback
+}
+
+// SetOnExtend sets the callback for extending the current break
+func (m *MenuBar) SetOnExtend(callback func()) {
+	m.onExtend = callback
+}
+
+// SetOnDiagnose sets the callback for running the diagnostics
+func (m *MenuBar) SetOnDiagnose(callback func()) {
+	m.onDiagnose = callback
+}
+
+// SetOnOpenDataDir sets the callback for opening the data folder
+func (m *MenuBar) SetOnOpenDataDir(callback func()) {
+	m.onOpenData = callback
+}
+
 // SetOnQuit sets the callback for quit action
 func (m *MenuBar) SetOnQuit(callback func()) {
 	m.onQuit = callback
 }
 
+// UpdateConfig updates the configuration
+func (m *MenuBar) UpdateConfig(cfg *config.Config) {
+	m.config = cfg
+}
+
 // Start initializes and runs the menu bar
 func (m *MenuBar) Start() {
 	menuet.App().Label = "com.2020rule.app"
@@ -75,6 +166,18 @@ func (m *MenuBar) getStatusTitle() string {
 
 	switch state {
 	case timer.StateRunning:
+		if m.timerManager.IsSuspended() {
+			return "⏭ Ruht"
+		}
+		if m.timerManager.IsOutsideRemindersWindow() {
+			return "🌙 Feierabend"
+		}
+		if m.timerManager.IsSilent() {
+			return "🔕 Still"
+		}
+		if m.timerManager.IsDeferred() {
+			return "⏳ Später"
+		}
 		remaining := m.timerManager.GetTimeUntilBreak()
 		minutes := int(remaining.Minutes())
 		seconds := int(remaining.Seconds()) % 60
@@ -85,11 +188,18 @@ func (m *MenuBar) getStatusTitle() string {
 		seconds := int(remaining.Seconds())
 		return fmt.Sprintf("👁 Pause: %ds", seconds)
 
-	case timer.StatePausedManual:
-		return "⏸ Pausiert"
-
-	case timer.StatePausedInactive:
-		return "💤 Inaktiv"
+	case timer.StatePausedManual, timer.StatePausedInactive:
+		icon := "⏸"
+		if state == timer.StatePausedInactive {
+			icon = "💤"
+		}
+		now := time.Now()
+		pausedFor := now.Sub(m.timerManager.GetPausedSince())
+		var untilResume time.Duration
+		if resumeAt := m.timerManager.GetAutoResumeAt(); !resumeAt.IsZero() {
+			untilResume = resumeAt.Sub(now)
+		}
+		return formatPausedTitle(icon, pausedFor, untilResume)
 
 	default:
 		return "20-20-20"
@@ -104,11 +214,26 @@ func (m *MenuBar) menuItems() []menuet.MenuItem {
 		{
 			Text: m.getStatusInfo(),
 		},
-		{
-			Type: menuet.Separator,
-		},
 	}
 
+	// Add the health score of the last week
+	if scoreText := m.getHealthScoreText(); scoreText != "" {
+		items = append(items, menuet.MenuItem{
+			Text: scoreText,
+		})
+	}
+
+	// Add the breaks kept in a row this session
+	if inRow := m.timerManager.GetConsecutiveCompletions(); inRow > 0 {
+		items = append(items, menuet.MenuItem{
+			Text: formatCompletionStreak(inRow),
+		})
+	}
+
+	items = append(items, menuet.MenuItem{
+		Type: menuet.Separator,
+	})
+
 	// Add pause/resume button
 	if state == timer.StateRunning {
 		items = append(items, menuet.MenuItem{
@@ -119,6 +244,32 @@ func (m *MenuBar) menuItems() []menuet.MenuItem {
 				}
 			},
 		})
+		items = append(items, menuet.MenuItem{
+			Text: "30 Minuten pausieren",
+			Clicked: func() {
+				if m.onPauseFor != nil {
+					m.onPauseFor(shortPause)
+				}
+			},
+		})
+		if m.timerManager.CanExtendWorkInterval() {
+			items = append(items, menuet.MenuItem{
+				Text: "+5 Min",
+				Clicked: func() {
+					if m.onExtendWork != nil {
+						m.onExtendWork(workExtension)
+					}
+				},
+			})
+		}
+		items = append(items, menuet.MenuItem{
+			Text: "Jetzt Pause machen",
+			Clicked: func() {
+				if m.onBreakNow != nil {
+					m.onBreakNow()
+				}
+			},
+		})
 	} else if state == timer.StatePausedManual || state == timer.StatePausedInactive {
 		items = append(items, menuet.MenuItem{
 			Text: "Fortsetzen",
@@ -130,6 +281,53 @@ func (m *MenuBar) menuItems() []menuet.MenuItem {
 		})
 	}
 
+	// Add button to extend the current break
+	if state == timer.StateBreakRequired {
+		items = append(items, menuet.MenuItem{
+			Text: "Pause um 10 Sekunden verlängern",
+			Clicked: func() {
+				if m.onExtend != nil {
+					m.onExtend()
+				}
+			},
+		})
+	}
+
+	// Add silent mode toggle
+	silent := m.timerManager.IsSilent()
+	items = append(items, menuet.MenuItem{
+		Text:  "Stiller Modus",
+		State: silent,
+		Clicked: func() {
+			if m.onSilent != nil {
+				m.onSilent(!silent)
+			}
+		},
+	})
+
+	// Add suspend/unsuspend button
+	if state == timer.StateRunning {
+		if m.timerManager.IsSuspended() {
+			items = append(items, menuet.MenuItem{
+				Text: "Pausen wieder aktivieren",
+				Clicked: func() {
+					if m.onUnsuspend != nil {
+						m.onUnsuspend()
+					}
+				},
+			})
+		} else {
+			items = append(items, menuet.MenuItem{
+				Text: "Pausen bis morgen aussetzen",
+				Clicked: func() {
+					if m.onSuspend != nil {
+						m.onSuspend()
+					}
+				},
+			})
+		}
+	}
+
 	// Add statistics menu item
 	items = append(items, menuet.MenuItem{
 		Type: menuet.Separator,
@@ -142,6 +340,24 @@ func (m *MenuBar) menuItems() []menuet.MenuItem {
 		},
 	})
 
+	items = append(items, menuet.MenuItem{
+		Text: "Datenordner öffnen",
+		Clicked: func() {
+			if m.onOpenData != nil {
+				m.onOpenData()
+			}
+		},
+	})
+
+	items = append(items, menuet.MenuItem{
+		Text: "Diagnose ausführen",
+		Clicked: func() {
+			if m.onDiagnose != nil {
+				m.onDiagnose()
+			}
+		},
+	})
+
 	// Add quit button
 	items = append(items, menuet.MenuItem{
 		Type: menuet.Separator,
@@ -159,12 +375,41 @@ func (m *MenuBar) menuItems() []menuet.MenuItem {
 	return items
 }
 
+// formatPausedTitle renders the menu bar title while paused: the icon, how
+// long the timer has been paused and, if untilResume is positive, the time
+// until it resumes on its own, rounded up to the minute
+func formatPausedTitle(icon string, pausedFor, untilResume time.Duration) string {
+	title := icon + " " + formatGap(pausedFor)
+	if untilResume > 0 {
+		title += " · ▶ " + formatGap((untilResume + time.Minute - 1).Truncate(time.Minute))
+	}
+	return title
+}
+
 // getStatusInfo returns detailed status information
 func (m *MenuBar) getStatusInfo() string {
 	state := m.timerManager.GetState()
 
 	switch state {
 	case timer.StateRunning:
+		if m.timerManager.IsSuspended() {
+			return "⏭ Pausen ruhen bis morgen"
+		}
+		if m.timerManager.IsOutsideRemindersWindow() {
+			return "🌙 Feierabend – Pausen ab " + m.config.StartRemindersAt
+		}
+		if m.timerManager.IsSuppressedOnBattery() {
+			return "🔋 Pausen ruhen im Akkubetrieb"
+		}
+		if m.timerManager.IsSilent() {
+			return "🔕 Stiller Modus – Pausen werden nur gezählt"
+		}
+		if m.timerManager.IsDeferredInApp() {
+			return "⏳ Pause nach dieser App"
+		}
+		if m.timerManager.IsDeferred() {
+			return "⏳ Pause nach der Präsentation"
+		}
 		remaining := m.timerManager.GetTimeUntilBreak()
 		minutes := int(remaining.Minutes())
 		seconds := int(remaining.Seconds()) % 60
@@ -174,6 +419,9 @@ func (m *MenuBar) getStatusInfo() string {
 		return "Zeit für eine Augenpause!"
 
 	case timer.StatePausedManual:
+		if resumeAt := m.timerManager.GetAutoResumeAt(); !resumeAt.IsZero() {
+			return "Timer pausiert bis " + resumeAt.Format("15:04")
+		}
 		return "Timer ist pausiert"
 
 	case timer.StatePausedInactive:
@@ -184,53 +432,365 @@ func (m *MenuBar) getStatusInfo() string {
 	}
 }
 
+// formatCompletionStreak renders the number of breaks kept in a row
+func formatCompletionStreak(count int) string {
+	if count == 1 {
+		return "1 Pause in Folge eingehalten"
+	}
+	return fmt.Sprintf("%d Pausen in Folge eingehalten", count)
+}
+
+// formatUncountedBreaks renders the number of breaks taken outside the
+// schedule and of those suppressed in silent mode for a statistics line,
+// or nothing without any
+func formatUncountedBreaks(manual, silent int) string {
+	var parts []string
+	if manual > 0 {
+		parts = append(parts, fmt.Sprintf("%d außerplanmäßig", manual))
+	}
+	if silent > 0 {
+		parts = append(parts, fmt.Sprintf("%d still", silent))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " + " + strings.Join(parts, ", ")
+}
+
+// formatReportLine renders a compliance report as a statistics line titled
+// label. A failed query reads "Keine Daten", an unknown period is a bug and
+// says so rather than passing for missing data.
+func formatReportLine(label string, report *stats.ComplianceReport, err error) string {
+	switch {
+	case errors.Is(err, stats.ErrInvalidPeriod):
+		log.Printf("Warning: %v", err)
+		return label + ": Unbekannter Zeitraum"
+	case err != nil:
+		return label + ": Keine Daten"
+	}
+	return fmt.Sprintf("%s: %d/%d (%.0f%%)",
+		label,
+		report.CompletedBreaks,
+		report.TotalBreaks,
+		report.ComplianceRate) + formatUncountedBreaks(report.ManualBreaks, report.SilentBreaks)
+}
+
+// complianceMode returns the configured way of calculating compliance rates
+func (m *MenuBar) complianceMode() stats.ComplianceMode {
+	return stats.ComplianceMode(m.config.ComplianceMode)
+}
+
 // getStatisticsMenu returns the statistics submenu
 func (m *MenuBar) getStatisticsMenu() []menuet.MenuItem {
-	// Get today's stats
-	todayReport, err := m.statsStore.GetComplianceReport("today")
-	var todayText string
-	if err == nil {
-		todayText = fmt.Sprintf("Heute: %d/%d (%.0f%%)",
-			todayReport.CompletedBreaks,
-			todayReport.TotalBreaks,
-			todayReport.ComplianceRate)
-	} else {
-		todayText = "Heute: Keine Daten"
-	}
-
-	// Get week stats
-	weekReport, err := m.statsStore.GetComplianceReport("week")
-	var weekText string
-	if err == nil {
-		weekText = fmt.Sprintf("Woche: %d/%d (%.0f%%)",
-			weekReport.CompletedBreaks,
-			weekReport.TotalBreaks,
-			weekReport.ComplianceRate)
-	} else {
-		weekText = "Woche: Keine Daten"
-	}
-
-	// Get month stats
-	monthReport, err := m.statsStore.GetComplianceReport("month")
-	var monthText string
-	if err == nil {
-		monthText = fmt.Sprintf("Monat: %d/%d (%.0f%%)",
-			monthReport.CompletedBreaks,
-			monthReport.TotalBreaks,
-			monthReport.ComplianceRate)
-	} else {
-		monthText = "Monat: Keine Daten"
-	}
-
-	return []menuet.MenuItem{
+	// Get today's, this calendar week's and longer stats
+	todayReport, todayErr := m.statsStore.GetComplianceReport(stats.PeriodToday, m.complianceMode())
+	weekReport, weekErr := m.statsStore.GetCurrentWeekReport(m.complianceMode())
+	monthReport, monthErr := m.statsStore.GetComplianceReport(stats.PeriodMonth, m.complianceMode())
+	yearReport, yearErr := m.statsStore.GetComplianceReport(stats.PeriodYear, m.complianceMode())
+	allTimeReport, allTimeErr := m.statsStore.GetComplianceReport(stats.PeriodAllTime, m.complianceMode())
+
+	items := []menuet.MenuItem{
+		{
+			Text: formatReportLine("Heute", todayReport, todayErr),
+		},
+		{
+			Text: formatReportLine("Woche", weekReport, weekErr),
+		},
 		{
-			Text: todayText,
+			Text: formatReportLine("Monat", monthReport, monthErr),
 		},
 		{
-			Text: weekText,
+			Text: formatReportLine("Jahr", yearReport, yearErr),
 		},
 		{
-			Text: monthText,
+			Text: formatReportLine("Gesamt", allTimeReport, allTimeErr),
+		},
+	}
+
+	items = append(items, menuet.MenuItem{
+		Type: menuet.Separator,
+	})
+
+	// Add today compared to the personal baseline
+	if baselineText := m.getBaselineText(); baselineText != "" {
+		items = append(items, menuet.MenuItem{
+			Text: baselineText,
+		})
+	}
+
+	// Add today's longest stretch without a break
+	if gap, err := m.statsStore.GetLongestGap(time.Now()); err == nil {
+		items = append(items, menuet.MenuItem{
+			Text: "Längste Phase ohne Pause: " + formatGap(gap),
+		})
+	}
+
+	// Add how long the timer was paused today, manually or while idle
+	if paused, err := m.statsStore.GetPausedTimeToday(); err == nil {
+		items = append(items, menuet.MenuItem{
+			Text: "Heute pausiert: " + formatGap(paused),
+		})
+	}
+
+	// Add break duration distribution of the last week
+	if histogram := m.getDurationHistogramItems(); len(histogram) > 0 {
+		items = append(items, menuet.MenuItem{
+			Text: "Pausendauer (7 Tage)",
+			Children: func() []menuet.MenuItem {
+				return histogram
+			},
+		})
+	}
+
+	// Add eye comfort trend
+	if ratingText := m.getRatingText(); ratingText != "" {
+		items = append(items, menuet.MenuItem{
+			Text: ratingText,
+		})
+	}
+
+	// Add the most common reason for skipping
+	if skipText := m.getSkipReasonText(); skipText != "" {
+		items = append(items, menuet.MenuItem{
+			Text: skipText,
+		})
+	}
+
+	// Add goal progress
+	if goalText := m.getGoalText(); goalText != "" {
+		items = append(items, menuet.MenuItem{
+			Text: goalText,
+		})
+	}
+
+	// Add rest time goal progress
+	if restText := m.getRestGoalText(); restText != "" {
+		items = append(items, menuet.MenuItem{
+			Text: restText,
+		})
+	}
+
+	// Add encouragement after a bad day
+	if nudge := m.getRecoveryNudge(); nudge != "" {
+		items = append(items, menuet.MenuItem{
+			Text: nudge,
+		})
+	}
+
+	// Add maintenance action for stale statistics
+	items = append(items, menuet.MenuItem{
+		Type: menuet.Separator,
+	})
+	items = append(items, menuet.MenuItem{
+		Text: "Statistiken neu berechnen",
+		Clicked: func() {
+			if m.onRebuild != nil {
+				m.onRebuild()
+			}
 		},
+	})
+
+	return items
+}
+
+// getHealthScoreText returns the health score of the last week, or an empty
+// string if there are no breaks to score yet
+func (m *MenuBar) getHealthScoreText() string {
+	report, err := m.statsStore.GetComplianceReport(stats.PeriodWeek, m.complianceMode())
+	if err != nil || report.TotalBreaks == 0 {
+		return ""
+	}
+
+	score, err := m.statsStore.GetHealthScore()
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("❤️ Augen-Score: %d/100", score)
+}
+
+// getDurationHistogramItems returns one menu line per duration bucket of
+// the breaks completed in the last week
+func (m *MenuBar) getDurationHistogramItems() []menuet.MenuItem {
+	now := time.Now()
+	histogram, err := m.statsStore.GetDurationHistogram(now.AddDate(0, 0, -7), now, histogramBucketSecs)
+	if err != nil {
+		return nil
+	}
+
+	var items []menuet.MenuItem
+	for _, line := range formatHistogram(histogram, histogramBucketSecs) {
+		items = append(items, menuet.MenuItem{
+			Text: line,
+		})
+	}
+	return items
+}
+
+// formatHistogram renders a duration histogram as text bars, shortest
+// durations first, e.g. "10–14 s ▇▇▇▇ 4"
+func formatHistogram(histogram map[int]int, bucketSecs int) []string {
+	buckets := make([]int, 0, len(histogram))
+	maxCount := 0
+	for bucket, count := range histogram {
+		buckets = append(buckets, bucket)
+		maxCount = max(maxCount, count)
+	}
+	sort.Ints(buckets)
+
+	lines := make([]string, 0, len(buckets))
+	for _, bucket := range buckets {
+		count := histogram[bucket]
+		width := max(1, count*histogramBarWidth/maxCount)
+		lines = append(lines, fmt.Sprintf("%d–%d s %s %d",
+			bucket, bucket+bucketSecs-1, strings.Repeat("▇", width), count))
+	}
+	return lines
+}
+
+// getRatingText returns the average eye comfort rating of the last week
+// compared to the week before
+func (m *MenuBar) getRatingText() string {
+	now := time.Now()
+	weekAgo := now.AddDate(0, 0, -7)
+
+	average, count, err := m.statsStore.GetAverageRating(weekAgo, now)
+	if err != nil || count == 0 {
+		return ""
+	}
+
+	text := fmt.Sprintf("Augenkomfort: Ø %.1f", average)
+	previous, previousCount, err := m.statsStore.GetAverageRating(weekAgo.AddDate(0, 0, -7), weekAgo)
+	if err == nil && previousCount > 0 {
+		text += " " + ratingTrendArrow(average-previous)
+	}
+	return text
+}
+
+// skipReasonLabels are the skip reasons as shown in the menu
+var skipReasonLabels = map[stats.SkipReason]string{
+	stats.SkipReasonMeeting:     "Meeting",
+	stats.SkipReasonDeadline:    "Deadline",
+	stats.SkipReasonJustBecause: "einfach so",
+}
+
+// getSkipReasonText returns the reason given most often for skipping a
+// break in the last month, or an empty string if none was given
+func (m *MenuBar) getSkipReasonText() string {
+	breakdown, err := m.statsStore.GetSkipReasonBreakdown(stats.PeriodMonth)
+	if err != nil {
+		return ""
+	}
+	reason, count, ok := stats.MostCommonSkipReason(breakdown)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("Häufigster Grund: %s (%d×)", skipReasonLabels[reason], count)
+}
+
+// ratingTrendArrow returns an arrow for the change between two average ratings
+func ratingTrendArrow(delta float64) string {
+	switch {
+	case delta > ratingTrendThreshold:
+		return "↑"
+	case delta < -ratingTrendThreshold:
+		return "↓"
+	default:
+		return "→"
+	}
+}
+
+// formatGap formats a duration as hours and minutes, e.g. "1h47m"
+func formatGap(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours == 0 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", hours, minutes)
+}
+
+// getGoalText returns how many breaks are still needed for today's goal
+func (m *MenuBar) getGoalText() string {
+	goal := m.config.DailyComplianceGoal
+	if goal <= 0 {
+		return ""
+	}
+
+	expected := timer.ExpectedRemainingBreaks(time.Now(), m.config)
+	needed, onTrack, err := m.statsStore.ProjectGoalProgress(goal, expected)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case needed == 0:
+		return "Tagesziel erreicht ✓"
+	case !onTrack:
+		return "Tagesziel heute nicht mehr erreichbar"
+	case needed == 1:
+		return "Noch 1 Pause für dein Tagesziel"
+	default:
+		return fmt.Sprintf("Noch %d Pausen für dein Tagesziel", needed)
+	}
+}
+
+// getRestGoalText returns today's completed break time against the daily
+// rest goal, e.g. "Augenruhe: 6/7 min", or an empty string without a goal
+func (m *MenuBar) getRestGoalText() string {
+	goal := m.config.DailyRestGoalMinutes
+	if goal <= 0 {
+		return ""
+	}
+
+	rest, err := m.statsStore.GetRestMinutesToday()
+	if err != nil {
+		return ""
+	}
+
+	text := fmt.Sprintf("Augenruhe: %d/%d min", rest, goal)
+	if rest >= goal {
+		text += " ✓"
+	}
+	return text
+}
+
+// getBaselineText compares today's compliance with the average of the last
+// 30 days, or returns an empty string without breaks today or before. Both
+// use the compliance rate of required breaks that daily stats are kept in.
+func (m *MenuBar) getBaselineText() string {
+	today, err := m.statsStore.GetComplianceReport(stats.PeriodToday, stats.ComplianceOfRequired)
+	if err != nil || today.TotalBreaks == 0 {
+		return ""
+	}
+
+	average, days, err := m.statsStore.Get30DayAverageCompliance()
+	if err != nil || days == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("Heute %.0f%% (Schnitt %.0f%%)", today.ComplianceRate, average)
+}
+
+// getRecoveryNudge returns an encouraging line if yesterday's compliance was
+// clearly below the daily goal
+func (m *MenuBar) getRecoveryNudge() string {
+	yesterday, err := m.statsStore.GetYesterdayStats()
+	if err != nil {
+		return ""
+	}
+	return recoveryNudge(yesterday, m.config.DailyComplianceGoal)
+}
+
+// recoveryNudge selects the encouragement for yesterday's stats. It stays
+// quiet without a goal, without breaks yesterday and unless the compliance
+// rate fell at least recoveryNudgeMargin points short of the goal.
+func recoveryNudge(yesterday *stats.DailyStats, goal float64) string {
+	if goal <= 0 || yesterday == nil || yesterday.BreaksRequired == 0 {
+		return ""
+	}
+	if yesterday.ComplianceRate > goal-recoveryNudgeMargin {
+		return ""
 	}
+	return fmt.Sprintf("Gestern %.0f%% – heute schaffst du mehr 💪", yesterday.ComplianceRate)
 }
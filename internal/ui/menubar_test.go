@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatPausedTitle(t *testing.T) {
+	tests := []struct {
+		name        string
+		pausedFor   time.Duration
+		untilResume time.Duration
+		want        string
+	}{
+		{"just paused", 0, 0, "⏸ 0m"},
+		{"indefinite pause", 42 * time.Minute, 0, "⏸ 42m"},
+		{"over an hour", 75 * time.Minute, 0, "⏸ 1h15m"},
+		{"auto resume", 5 * time.Minute, 25 * time.Minute, "⏸ 5m · ▶ 25m"},
+		{"auto resume rounded up", 5*time.Minute + 30*time.Second, 24*time.Minute + 30*time.Second, "⏸ 5m · ▶ 25m"},
+		{"last seconds", 29*time.Minute + 59*time.Second, time.Second, "⏸ 29m · ▶ 1m"},
+		{"resume overdue", 31 * time.Minute, -time.Minute, "⏸ 31m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatPausedTitle("⏸", tt.pausedFor, tt.untilResume); got != tt.want {
+				t.Errorf("formatPausedTitle(%v, %v) = %q, want %q", tt.pausedFor, tt.untilResume, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package webhook
+
+import "errors"
+
+var (
+	// ErrUnexpectedStatus is returned when the webhook answers with a non-2xx status
+	ErrUnexpectedStatus = errors.New("unexpected webhook response status")
+
+	// ErrQueueFull is returned when a payload is dropped because too many are waiting
+	ErrQueueFull = errors.New("webhook queue is full")
+)
@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// queueSize is how many payloads may wait for delivery
+	queueSize = 32
+	// requestTimeout limits a single delivery attempt
+	requestTimeout = 5 * time.Second
+	// maxAttempts is how often a payload is tried before it is dropped
+	maxAttempts = 3
+	// initialBackoff is the wait before the first retry, doubling after each
+	initialBackoff = 2 * time.Second
+)
+
+// Payload types
+const (
+	// EventBreakCompleted is sent when a break is completed
+	EventBreakCompleted = "break_completed"
+	// EventBreakSkipped is sent when a break is skipped
+	EventBreakSkipped = "break_skipped"
+	// EventDailySummary is sent once a day has ended
+	EventDailySummary = "daily_summary"
+)
+
+// Doer sends HTTP requests, satisfied by *http.Client
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Summary holds the compliance of a finished day
+type Summary struct {
+	Date            string  `json:"date"` // YYYY-MM-DD
+	BreaksRequired  int     `json:"breaks_required"`
+	BreaksCompleted int     `json:"breaks_completed"`
+	BreaksSkipped   int     `json:"breaks_skipped"`
+	ComplianceRate  float64 `json:"compliance_rate"`
+}
+
+// Payload is the JSON body posted to the webhook
+type Payload struct {
+	Event   string    `json:"event"`
+	Time    time.Time `json:"timestamp"`
+	BreakID int64     `json:"break_id,omitempty"`
+	Summary *Summary  `json:"summary,omitempty"`
+}
+
+// Sender posts payloads to a webhook URL from a background goroutine, so
+// slow or failing endpoints never block the caller
+type Sender struct {
+	url     string
+	client  Doer
+	queue   chan Payload
+	ctx     context.Context // Done once Close is called, cancelling requests in flight
+	stop    context.CancelFunc
+	done    sync.WaitGroup
+	backoff time.Duration
+}
+
+// NewSender creates a sender posting to url with client. Start must be
+// called before payloads are delivered.
+func NewSender(url string, client Doer) *Sender {
+	ctx, stop := context.WithCancel(context.Background())
+	return &Sender{
+		url:     url,
+		client:  client,
+		queue:   make(chan Payload, queueSize),
+		ctx:     ctx,
+		stop:    stop,
+		backoff: initialBackoff,
+	}
+}
+
+// URL returns the webhook URL the sender posts to
+func (s *Sender) URL() string {
+	return s.url
+}
+
+// Start delivers queued payloads in the background until Close
+func (s *Sender) Start() {
+	s.done.Add(1)
+	go func() {
+		defer s.done.Done()
+		for {
+			select {
+			case payload := <-s.queue:
+				if err := s.deliver(payload); err != nil {
+					log.Printf("Warning: failed to deliver webhook %s: %v", payload.Event, err)
+				}
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Send queues payload for delivery without waiting. A full queue drops the
+// payload and returns ErrQueueFull.
+func (s *Sender) Send(payload Payload) error {
+	select {
+	case s.queue <- payload:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops delivery, abandoning payloads still queued, being retried or
+// being posted
+func (s *Sender) Close() {
+	s.stop()
+	s.done.Wait()
+}
+
+// deliver posts payload, retrying failed attempts with exponential backoff
+func (s *Sender) deliver(payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	backoff := s.backoff
+	for attempt := 1; ; attempt++ {
+		err = s.post(body)
+		if err == nil || attempt == maxAttempts {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-s.ctx.Done():
+			return err
+		}
+	}
+}
+
+// post makes a single delivery attempt, cut short by Close
+func (s *Sender) post(body []byte) error {
+	ctx, cancel := context.WithTimeout(s.ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+	return nil
+}
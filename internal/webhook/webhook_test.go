@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDoer answers requests with the next status, recording what it got
+type fakeDoer struct {
+	mu       sync.Mutex
+	statuses []int // Last one repeats
+	requests []*http.Request
+	bodies   [][]byte
+	served   chan struct{}
+}
+
+func newFakeDoer(statuses ...int) *fakeDoer {
+	return &fakeDoer{statuses: statuses, served: make(chan struct{}, 16)}
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	status := d.statuses[min(len(d.requests), len(d.statuses)-1)]
+	d.requests = append(d.requests, req)
+	d.bodies = append(d.bodies, body)
+	d.mu.Unlock()
+	d.served <- struct{}{}
+
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+// wait waits for n requests
+func (d *fakeDoer) wait(t *testing.T, n int) {
+	t.Helper()
+	for range n {
+		select {
+		case <-d.served:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a request")
+		}
+	}
+}
+
+func TestSenderPayload(t *testing.T) {
+	doer := newFakeDoer(http.StatusOK)
+	s := NewSender("https://example.com/hook", doer)
+	s.Start()
+	defer s.Close()
+
+	sent := Payload{
+		Event: EventDailySummary,
+		Time:  time.Date(2025, time.June, 4, 23, 59, 0, 0, time.UTC),
+		Summary: &Summary{
+			Date:            "2025-06-04",
+			BreaksRequired:  10,
+			BreaksCompleted: 8,
+			BreaksSkipped:   2,
+			ComplianceRate:  80,
+		},
+	}
+	if err := s.Send(sent); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	doer.wait(t, 1)
+
+	doer.mu.Lock()
+	defer doer.mu.Unlock()
+	req := doer.requests[0]
+	if req.Method != http.MethodPost || req.URL.String() != "https://example.com/hook" {
+		t.Errorf("request = %s %s, want POST to the webhook URL", req.Method, req.URL)
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(doer.bodies[0], &got); err != nil {
+		t.Fatalf("body is no JSON: %v", err)
+	}
+	if got["event"] != EventDailySummary || got["timestamp"] != "2025-06-04T23:59:00Z" {
+		t.Errorf("body = %v, want the daily summary at 23:59", got)
+	}
+	if _, ok := got["break_id"]; ok {
+		t.Error("body has a break_id without a break")
+	}
+	summary, ok := got["summary"].(map[string]interface{})
+	if !ok || summary["date"] != "2025-06-04" || summary["breaks_completed"] != 8.0 || summary["compliance_rate"] != 80.0 {
+		t.Errorf("summary = %v, want the sent one", got["summary"])
+	}
+}
+
+func TestSenderRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantErr      error
+	}{
+		{"delivered first time", []int{http.StatusNoContent}, 1, nil},
+		{"delivered on retry", []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK}, 3, nil},
+		{"gives up", []int{http.StatusServiceUnavailable}, maxAttempts, ErrUnexpectedStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doer := newFakeDoer(tt.statuses...)
+			s := NewSender("https://example.com/hook", doer)
+			s.backoff = time.Millisecond
+			defer s.Close()
+
+			err := s.deliver(Payload{Event: EventBreakCompleted, BreakID: 7})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("deliver() error = %v, want %v", err, tt.wantErr)
+			}
+			if n := len(doer.requests); n != tt.wantAttempts {
+				t.Errorf("%d attempts, want %d", n, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+// blockingDoer holds every request until its context is done
+type blockingDoer struct {
+	started chan struct{}
+}
+
+func (d blockingDoer) Do(req *http.Request) (*http.Response, error) {
+	d.started <- struct{}{}
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestCloseCancelsDelivery(t *testing.T) {
+	doer := blockingDoer{started: make(chan struct{}, 1)}
+	s := NewSender("https://example.com/hook", doer)
+	s.Start()
+	if err := s.Send(Payload{Event: EventBreakSkipped}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	<-doer.started
+
+	closed := make(chan struct{})
+	go func() {
+		s.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close waited for the request to time out")
+	}
+}
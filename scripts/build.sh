@@ -65,6 +65,9 @@ cat > "${APP_DIR}/Contents/Info.plist" << EOF
     <key>NSHighResolutionCapable</key>
     <true/>
 
+    <key>NSCameraUsageDescription</key>
+    <string>Prüft auf Wunsch, ob du während der Pause vom Bildschirm wegschaust. Es werden keine Bilder gespeichert.</string>
+
     <key>LSMinimumSystemVersion</key>
     <string>10.15</string>
 </dict>